@@ -1,14 +1,22 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql" // registers mariadb/mysql connection driver
 	"github.com/pedidopago/trainingsvc-clients/internal/clients-service/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
@@ -30,6 +38,191 @@ func main() {
 			EnvVars: []string{"DBCS"},
 			Usage:   "mariadb connection string: user:password@tcp(host:port)/ms_training?parseTime=true",
 		},
+		&cli.DurationFlag{
+			Name:    "idempotency-key-ttl",
+			EnvVars: []string{"IDEMPOTENCY_KEY_TTL"},
+			Usage:   "how long NewClient idempotency keys are kept",
+			Value:   24 * time.Hour,
+		},
+		&cli.BoolFlag{
+			Name:    "unique-names",
+			EnvVars: []string{"UNIQUE_NAMES"},
+			Usage:   "refuse clients with a name already in use",
+		},
+		&cli.StringFlag{
+			Name:    "phone-country-code",
+			EnvVars: []string{"PHONE_COUNTRY_CODE"},
+			Usage:   "country code assumed for phone numbers without one",
+			Value:   "55",
+		},
+		&cli.IntFlag{
+			Name:    "metadata-max-keys",
+			EnvVars: []string{"METADATA_MAX_KEYS"},
+			Usage:   "max number of metadata entries per client",
+			Value:   32,
+		},
+		&cli.IntFlag{
+			Name:    "metadata-max-bytes",
+			EnvVars: []string{"METADATA_MAX_BYTES"},
+			Usage:   "max total size of the metadata of a client",
+			Value:   4096,
+		},
+		&cli.IntFlag{
+			Name:    "notes-max-length",
+			EnvVars: []string{"NOTES_MAX_LENGTH"},
+			Usage:   "max size in bytes of the notes of a client",
+			Value:   2048,
+		},
+		&cli.IntFlag{
+			Name:    "max-clients",
+			EnvVars: []string{"MAX_CLIENTS"},
+			Usage:   "max number of clients, 0 for unlimited",
+		},
+		&cli.DurationFlag{
+			Name:    "archive-retention",
+			EnvVars: []string{"ARCHIVE_RETENTION"},
+			Usage:   "how long copies of deleted clients are kept",
+			Value:   365 * 24 * time.Hour,
+		},
+		&cli.Int64Flag{
+			Name:    "query-clients-max-limit",
+			EnvVars: []string{"QUERY_CLIENTS_MAX_LIMIT"},
+			Usage:   "largest page of ids returned by QueryClients",
+			Value:   1000,
+		},
+		&cli.IntFlag{
+			Name:    "get-clients-chunk-size",
+			EnvVars: []string{"GET_CLIENTS_CHUNK_SIZE"},
+			Usage:   "max number of ids read by each query of GetClients",
+			Value:   1000,
+		},
+		&cli.IntFlag{
+			Name:    "get-clients-max-ids",
+			EnvVars: []string{"GET_CLIENTS_MAX_IDS"},
+			Usage:   "max number of ids of a GetClients request",
+			Value:   100000,
+		},
+		&cli.BoolFlag{
+			Name:    "require-query-filters",
+			EnvVars: []string{"REQUIRE_QUERY_FILTERS"},
+			Usage:   "refuse QueryClients requests without filters, unless they set allow_unfiltered",
+		},
+		&cli.IntFlag{
+			Name:    "sort-stream-max-bytes",
+			EnvVars: []string{"SORT_STREAM_MAX_BYTES"},
+			Usage:   "max memory taken by the items of a SortStream call",
+			Value:   256 << 20,
+		},
+		&cli.DurationFlag{
+			Name:    "health-check-interval",
+			EnvVars: []string{"HEALTH_CHECK_INTERVAL"},
+			Usage:   "how often the database is pinged for the gRPC health service",
+			Value:   10 * time.Second,
+		},
+		&cli.BoolFlag{
+			Name:    "enable-reflection",
+			EnvVars: []string{"ENABLE_REFLECTION"},
+			Usage:   "register the gRPC server reflection service, for debugging with grpcurl",
+		},
+		&cli.StringFlag{
+			Name:    "metrics-addr",
+			EnvVars: []string{"METRICS_ADDRESS"},
+			Usage:   "host:port serving the Prometheus metrics on /metrics, none when empty",
+		},
+		&cli.DurationFlag{
+			Name:    "db-stats-interval",
+			EnvVars: []string{"DB_STATS_INTERVAL"},
+			Usage:   "how often the metrics of the database pool are refreshed",
+			Value:   15 * time.Second,
+		},
+		&cli.StringSliceFlag{
+			Name:    "log-payload-methods",
+			EnvVars: []string{"LOG_PAYLOAD_METHODS"},
+			Usage:   "full names of the methods (/pb.ClientsService/GetClient) whose requests and responses are logged",
+		},
+		&cli.IntFlag{
+			Name:    "log-max-payload-bytes",
+			EnvVars: []string{"LOG_MAX_PAYLOAD_BYTES"},
+			Usage:   "size the logged requests and responses are truncated to",
+			Value:   1024,
+		},
+		&cli.BoolFlag{
+			Name:    "full-text-search",
+			EnvVars: []string{"FULL_TEXT_SEARCH"},
+			Usage:   "search client names with the FULLTEXT index instead of LIKE",
+		},
+		&cli.DurationFlag{
+			Name:    "match-retention",
+			EnvVars: []string{"MATCH_RETENTION"},
+			Usage:   "how long matches are kept, 0 to keep them forever",
+		},
+		&cli.DurationFlag{
+			Name:    "match-retention-pause",
+			EnvVars: []string{"MATCH_RETENTION_PAUSE"},
+			Usage:   "pause between the batches of a matches deletion",
+			Value:   100 * time.Millisecond,
+		},
+		&cli.Int64Flag{
+			Name:    "min-match-score",
+			EnvVars: []string{"MIN_MATCH_SCORE"},
+			Usage:   "lowest score of a single match (unbounded when both limits are 0)",
+		},
+		&cli.Int64Flag{
+			Name:    "max-match-score",
+			EnvVars: []string{"MAX_MATCH_SCORE"},
+			Usage:   "highest score of a single match (unbounded when both limits are 0)",
+		},
+		&cli.Int64Flag{
+			Name:    "score-floor",
+			EnvVars: []string{"SCORE_FLOOR"},
+			Usage:   "lowest score a match can take a client to",
+		},
+		&cli.StringFlag{
+			Name:    "score-floor-mode",
+			EnvVars: []string{"SCORE_FLOOR_MODE"},
+			Usage:   "what to do with matches going below the score floor: clamp, reject or empty to allow them",
+		},
+		&cli.BoolFlag{
+			Name:    "exclude-practice-score",
+			EnvVars: []string{"EXCLUDE_PRACTICE_SCORE"},
+			Usage:   "don't add the score of practice matches to the client score",
+		},
+		&cli.Float64Flag{
+			Name:    "rate-limit",
+			EnvVars: []string{"RATE_LIMIT"},
+			Usage:   "max calls per second of each method, 0 for unlimited",
+		},
+		&cli.StringSliceFlag{
+			Name:    "rate-limits",
+			EnvVars: []string{"RATE_LIMITS"},
+			Usage:   "max calls per second of some methods, as Method=limit (DeleteAllClients=1), 0 for unlimited",
+		},
+		&cli.StringFlag{
+			Name:    "api-keys-file",
+			EnvVars: []string{"API_KEYS_FILE"},
+			Usage:   "file of the API keys required to call the service, a name=key per line, reloaded on SIGHUP; no auth when empty",
+		},
+		&cli.StringFlag{
+			Name:    "tls-cert-file",
+			EnvVars: []string{"TLS_CERT_FILE"},
+			Usage:   "PEM certificate of the server, served over TLS with tls-key-file; no TLS when empty",
+		},
+		&cli.StringFlag{
+			Name:    "tls-key-file",
+			EnvVars: []string{"TLS_KEY_FILE"},
+			Usage:   "PEM key of the tls-cert-file certificate",
+		},
+		&cli.StringFlag{
+			Name:    "tls-client-ca-file",
+			EnvVars: []string{"TLS_CLIENT_CA_FILE"},
+			Usage:   "PEM certificates of the CAs the client certificates must be signed by (mTLS); none required when empty",
+		},
+		&cli.DurationFlag{
+			Name:    "tls-reload-interval",
+			EnvVars: []string{"TLS_RELOAD_INTERVAL"},
+			Usage:   "how often the TLS files are checked for changes, to be read again",
+			Value:   30 * time.Second,
+		},
 	}
 
 	app.Action = run
@@ -48,14 +241,94 @@ func run(c *cli.Context) error {
 	}
 	defer lis.Close()
 
-	grpcServer := grpc.NewServer()
+	var metrics *service.Metrics
+	var serverOptions []grpc.ServerOption
+	if addr := c.String("metrics-addr"); addr != "" {
+		registry := prometheus.NewRegistry()
+		if metrics, err = service.NewMetrics(registry); err != nil {
+			return err
+		}
+		serverOptions = metrics.ServerOptions()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		metricsServer := &http.Server{Addr: addr, Handler: mux}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Error().Err(err).Str("addr", addr).Caller().Msg("metrics listen error")
+			}
+		}()
+		defer metricsServer.Close()
+	}
+
+	// the spans go to the global tracer provider, a no-op unless set up
+	serverOptions = append(service.TracingServerOptions(nil), serverOptions...)
+	serverOptions = append(serverOptions, service.RequestLogging{
+		Logger:          log.Logger,
+		PayloadMethods:  c.StringSlice("log-payload-methods"),
+		MaxPayloadBytes: c.Int("log-max-payload-bytes"),
+	}.ServerOptions()...)
 
 	ctx, cf := context.WithCancel(context.Background())
 	defer cf()
 
+	grpcServer, err := service.NewServer(ctx, service.TLSConfig{
+		CertFile:       c.String("tls-cert-file"),
+		KeyFile:        c.String("tls-key-file"),
+		ClientCAFile:   c.String("tls-client-ca-file"),
+		ReloadInterval: c.Duration("tls-reload-interval"),
+	}, serverOptions...)
+	if err != nil {
+		log.Error().Err(err).Caller().Msg("server error")
+		return err
+	}
+
+	rateLimits, err := parseRateLimits(c.StringSlice("rate-limits"))
+	if err != nil {
+		return err
+	}
+	var apiKeys *service.APIKeys
+	if path := c.String("api-keys-file"); path != "" {
+		keys, err := readAPIKeys(path)
+		if err != nil {
+			return err
+		}
+		if apiKeys, err = service.NewAPIKeys(keys); err != nil {
+			return err
+		}
+	}
+
+	// the database being down takes pb.ClientsService out of service on its own, setServing is for the shutdown
+	var setServing func(serving bool)
 	if err := service.New(ctx, grpcServer, service.Config{
-		DBCS: c.String("dbcs"),
-	}); err != nil {
+		DBCS:                 c.String("dbcs"),
+		IdempotencyKeyTTL:    c.Duration("idempotency-key-ttl"),
+		UniqueNames:          c.Bool("unique-names"),
+		PhoneCountryCode:     c.String("phone-country-code"),
+		MetadataMaxKeys:      c.Int("metadata-max-keys"),
+		MetadataMaxBytes:     c.Int("metadata-max-bytes"),
+		NotesMaxLength:       c.Int("notes-max-length"),
+		MaxClients:           c.Int("max-clients"),
+		ArchiveRetention:     c.Duration("archive-retention"),
+		MinMatchScore:        c.Int64("min-match-score"),
+		MaxMatchScore:        c.Int64("max-match-score"),
+		ScoreFloor:           c.Int64("score-floor"),
+		ScoreFloorMode:       service.ScoreFloorMode(c.String("score-floor-mode")),
+		ExcludePracticeScore: c.Bool("exclude-practice-score"),
+		MatchRetention:       c.Duration("match-retention"),
+		MatchRetentionPause:  c.Duration("match-retention-pause"),
+		QueryClientsMaxLimit: c.Int64("query-clients-max-limit"),
+		FullTextSearch:       c.Bool("full-text-search"),
+		GetClientsChunkSize:  c.Int("get-clients-chunk-size"),
+		GetClientsMaxIDs:     c.Int("get-clients-max-ids"),
+		RequireQueryFilters:  c.Bool("require-query-filters"),
+		SortStreamMaxBytes:   c.Int("sort-stream-max-bytes"),
+		HealthCheckInterval:  c.Duration("health-check-interval"),
+		Metrics:              metrics,
+		DBStatsInterval:      c.Duration("db-stats-interval"),
+		RateLimit:            c.Float64("rate-limit"),
+		RateLimits:           rateLimits,
+		APIKeys:              apiKeys,
+	}, service.WithReflection(c.Bool("enable-reflection")), service.WithServingSetter(&setServing)); err != nil {
 		log.Error().Err(err).Caller().Msg("service starter error")
 		return err
 	}
@@ -75,10 +348,68 @@ func run(c *cli.Context) error {
 
 	ch := make(chan os.Signal, 1)
 	// signal...
-	signal.Notify(ch, os.Interrupt)
-	<-ch
+	signal.Notify(ch, os.Interrupt, syscall.SIGHUP)
+	for sig := <-ch; sig == syscall.SIGHUP; sig = <-ch {
+		if apiKeys != nil {
+			reloadAPIKeys(apiKeys, c.String("api-keys-file"))
+		}
+	}
 
+	setServing(false)
 	grpcServer.GracefulStop()
 	log.Warn().Msg("shutting down")
 	return nil
 }
+
+// parseRateLimits parses the Method=limit rate limits of the flags
+func parseRateLimits(values []string) (map[string]float64, error) {
+	limits := map[string]float64{}
+	for _, v := range values {
+		i := strings.Index(v, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, want Method=limit", v)
+		}
+		limit, err := strconv.ParseFloat(v[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q: %v", v, err)
+		}
+		limits[v[:i]] = limit
+	}
+	return limits, nil
+}
+
+// readAPIKeys reads the name=key lines of an API keys file, skipping the empty ones and the # comments
+func readAPIKeys(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%s:%d: want name=key", path, n)
+		}
+		keys[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+	}
+	return keys, scanner.Err()
+}
+
+// reloadAPIKeys replaces the API keys with the ones of the file, keeping the current ones if it's invalid
+func reloadAPIKeys(apiKeys *service.APIKeys, path string) {
+	keys, err := readAPIKeys(path)
+	if err == nil {
+		err = apiKeys.Set(keys)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("path", path).Caller().Msg("api keys reload error")
+		return
+	}
+	log.Info().Int("keys", len(keys)).Msg("api keys reloaded")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pedidopago/trainingsvc-clients/protos/pb"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -19,10 +20,21 @@ func main() {
 			EnvVars: []string{"SERVER"},
 			Value:   "127.0.0.1:6000",
 		},
+		&cli.StringFlag{
+			Name:    "api-key",
+			EnvVars: []string{"API_KEY"},
+			Usage:   "API key sent with every call, for servers with API keys",
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
-		conn, err := grpc.Dial(c.String("server"), grpc.WithInsecure())
+		opts := []grpc.DialOption{grpc.WithInsecure()}
+		if key := c.String("api-key"); key != "" {
+			opts = append(opts, grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+key), method, req, reply, cc, opts...)
+			}))
+		}
+		conn, err := grpc.Dial(c.String("server"), opts...)
 		if err != nil {
 			return cli.NewExitError(err.Error(), 2)
 		}
@@ -38,7 +50,11 @@ func runTests(cl pb.ClientsServiceClient) error {
 
 	ctx := context.Background()
 
-	if _, err := cl.DeleteAllClients(ctx, &pb.DeleteAllClientsRequest{}); err != nil {
+	countResp, err := cl.DeleteAllClients(ctx, &pb.DeleteAllClientsRequest{DryRun: true})
+	if err != nil {
+		return cli.NewExitError(err.Error(), 3)
+	}
+	if _, err := cl.DeleteAllClients(ctx, &pb.DeleteAllClientsRequest{ConfirmCount: countResp.Deleted}); err != nil {
 		return cli.NewExitError(err.Error(), 3)
 	}
 
@@ -3,15 +3,21 @@ module github.com/pedidopago/trainingsvc-clients
 go 1.16
 
 require (
-	github.com/DATA-DOG/go-sqlmock v1.5.0 // indirect
-	github.com/Masterminds/squirrel v1.5.0 // indirect
-	github.com/go-sql-driver/mysql v1.5.0 // indirect
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/Masterminds/squirrel v1.5.0
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang/protobuf v1.4.2
-	github.com/jmoiron/sqlx v1.3.1 // indirect
-	github.com/oklog/ulid/v2 v2.0.2 // indirect
+	github.com/jmoiron/sqlx v1.3.1
+	github.com/oklog/ulid/v2 v2.0.2
+	github.com/prometheus/client_golang v1.7.1
 	github.com/rs/zerolog v1.20.0
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/stretchr/testify v1.7.0
 	github.com/urfave/cli/v2 v2.3.0
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/sdk v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
+	golang.org/x/text v0.3.0
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.36.0
 	google.golang.org/protobuf v1.25.0
 )
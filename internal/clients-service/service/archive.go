@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+)
+
+// archiveColumns are the columns of clients_archive: the client columns plus deleted_at
+var archiveColumns = append(append(make([]string, 0, len(clientColumns)+1), clientColumns...), "deleted_at")
+
+// archiveUpdates refreshes an archived copy when a client is archived again
+// (e.g. soft deleted, restored and deleted once more)
+var archiveUpdates = func() string {
+	sets := make([]string, 0, len(archiveColumns)-1)
+	for _, col := range archiveColumns[1:] {
+		sets = append(sets, col+" = VALUES("+col+")")
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}()
+
+// archiveClients copies the clients matching pred into clients_archive.
+// It must run in the same transaction as the delete, so both happen or neither does.
+func archiveClients(ctx context.Context, tx sqlx.ExecerContext, pred sq.Sqlizer) error {
+	// clients being soft deleted don't have deleted_at set yet
+	sel := sq.Select(clientColumns...).Column("COALESCE(deleted_at, NOW())").From("clients").Where(pred)
+	q, args, err := sq.Insert("clients_archive").Columns(archiveColumns...).
+		Select(sel).Suffix(archiveUpdates).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, q, args...)
+	return err
+}
+
+type archivedClientRow struct {
+	clientRow
+	DeletedAt time.Time `db:"deleted_at"`
+}
+
+// GetArchivedClient returns the copy of a deleted client kept in clients_archive
+func (s *Service) GetArchivedClient(ctx context.Context, req *pb.GetArchivedClientRequest) (*pb.GetArchivedClientResponse, error) {
+	q, args, err := sq.Select(archiveColumns...).From("clients_archive").
+		Where(sq.Eq{"id": req.Id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	row := archivedClientRow{}
+	if err := s.db.GetContext(ctx, &row, q, args...); err != nil {
+		return nil, notFoundOr(err, "archived client "+req.Id+" not found")
+	}
+	return &pb.GetArchivedClientResponse{
+		Client:    row.toPB(),
+		DeletedAt: row.DeletedAt.UnixNano(),
+	}, nil
+}
+
+// expireArchivedClients periodically removes the archived clients deleted before the configured retention
+func (s *Service) expireArchivedClients(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.db.ExecContext(ctx, "DELETE FROM clients_archive WHERE deleted_at < ?",
+				time.Now().Add(-s.config.ArchiveRetention))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestArchiveClients(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive (" + strings.Join(archiveColumns, ",") + ") " +
+		"SELECT " + strings.Join(clientColumns, ", ") + ", COALESCE(deleted_at, NOW()) " +
+		"FROM clients WHERE (id = ? AND deleted_at IS NULL) ON DUPLICATE KEY UPDATE name = VALUES(name)")).
+		WithArgs("MOCKID").WillReturnError(errors.New("archive failed"))
+	mock.ExpectRollback()
+	_, err := service.DeleteClient(context.Background(), &pb.DeleteClientRequest{Id: "MOCKID"})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetArchivedClient(t *testing.T) {
+	service, mock := newTestService(t)
+	deletedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + strings.Join(archiveColumns, ", ") + " FROM clients_archive WHERE id = ?")).
+		WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "created_at", "deleted_at"}).
+			AddRow("MOCKID", "Alice", 10, time.Now(), deletedAt))
+	resp, err := service.GetArchivedClient(context.Background(), &pb.GetArchivedClientRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", resp.Client.Name)
+	assert.Equal(t, deletedAt.UnixNano(), resp.DeletedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM clients_archive").WithArgs("MISSING").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.GetArchivedClient(context.Background(), &pb.GetArchivedClientRequest{Id: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationHeader is the metadata key of the API keys, given bare or as "Bearer <key>"
+const authorizationHeader = "authorization"
+
+// APIKeys are the keys allowed to call the clients service, by name. They can be replaced with Set at any
+// time, the calls already authenticated going on.
+type APIKeys struct {
+	keys atomic.Value // []apiKey
+}
+
+type apiKey struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+// NewAPIKeys creates the API keys with keys, by name
+func NewAPIKeys(keys map[string]string) (*APIKeys, error) {
+	k := &APIKeys{}
+	if err := k.Set(keys); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Set replaces the keys with keys, by name, all at once. An empty key is an error, and leaves the
+// current keys in place.
+func (k *APIKeys) Set(keys map[string]string) error {
+	hashed := make([]apiKey, 0, len(keys))
+	for name, key := range keys {
+		if key == "" {
+			return errors.New("empty API key " + name)
+		}
+		hashed = append(hashed, apiKey{name: name, hash: sha256.Sum256([]byte(key))})
+	}
+	k.keys.Store(hashed)
+	return nil
+}
+
+// name is the name of key, if it's one of the keys. Every key is compared, in constant time, and the
+// hashes are compared rather than the keys so that their lengths don't show either.
+func (k *APIKeys) name(key string) (string, bool) {
+	hash := sha256.Sum256([]byte(key))
+	var name string
+	found := 0
+	for _, allowed := range k.keys.Load().([]apiKey) {
+		if subtle.ConstantTimeCompare(hash[:], allowed.hash[:]) == 1 {
+			name = allowed.name
+			found = 1
+		}
+	}
+	return name, found == 1
+}
+
+type apiKeyNameKey struct{}
+
+// APIKeyName is the name of the API key the call of ctx was authenticated with, if the service has
+// Config.APIKeys
+func APIKeyName(ctx context.Context) string {
+	name, _ := ctx.Value(apiKeyNameKey{}).(string)
+	return name
+}
+
+// authenticate checks the API key of the incoming metadata of ctx, and returns ctx with its name, also
+// added to the logger of ctx. It lets everything through without Config.APIKeys.
+func (s *Service) authenticate(ctx context.Context) (context.Context, error) {
+	if s.config.APIKeys == nil {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing API key")
+	}
+	key := values[0]
+	if len(key) > len("Bearer ") && strings.EqualFold(key[:len("Bearer ")], "Bearer ") {
+		key = key[len("Bearer "):]
+	}
+	name, ok := s.config.APIKeys.name(key)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	ctx = context.WithValue(ctx, apiKeyNameKey{}, name)
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		keyLogger := logger.With().Str("api_key", name).Logger()
+		ctx = keyLogger.WithContext(ctx)
+	}
+	return ctx, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestAuthenticate(t *testing.T) {
+	keys, err := NewAPIKeys(map[string]string{"batch": "KEY-1", "admin": "KEY-2"})
+	require.NoError(t, err)
+	service := &Service{config: Config{APIKeys: keys}.withDefaults()}
+
+	// call is a unary call with the authorization metadata, returning the key name seen by the handler
+	call := func(authorization ...string) (string, error) {
+		ctx := context.Background()
+		for _, a := range authorization {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, a))
+		}
+		var name string
+		_, err := service.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pb.ClientsService/DeleteAllClients"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				name = APIKeyName(ctx)
+				return nil, nil
+			})
+		return name, err
+	}
+
+	name, err := call("KEY-1")
+	require.NoError(t, err)
+	assert.Equal(t, "batch", name)
+	name, err = call("Bearer KEY-2")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", name)
+
+	_, err = call()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "missing API key", status.Convert(err).Message())
+	for _, authorization := range []string{"", "KEY", "KEY-10", "Bearer KEY-3", "Bearer ", "key-1"} {
+		_, err = call(authorization)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), authorization)
+	}
+
+	// the keys rotate without restarting
+	require.NoError(t, keys.Set(map[string]string{"batch": "KEY-3"}))
+	_, err = call("KEY-1")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	name, err = call("KEY-3")
+	require.NoError(t, err)
+	assert.Equal(t, "batch", name)
+	assert.EqualError(t, keys.Set(map[string]string{"batch": ""}), "empty API key batch")
+	_, err = call("KEY-3")
+	assert.NoError(t, err, "an invalid set keeps the keys")
+
+	// the streams get the key name in their context too
+	err = service.streamInterceptor(nil, &sortStream{ctx: metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(authorizationHeader, "KEY-3"))}, &grpc.StreamServerInfo{FullMethod: "/pb.ClientsService/SortStream"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			name = APIKeyName(stream.Context())
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "batch", name)
+	err = service.streamInterceptor(nil, &sortStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/pb.ClientsService/SortStream"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			t.Error("the handler isn't called without a key")
+			return nil
+		})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAPIKeysConcurrentSet(t *testing.T) {
+	keys, err := NewAPIKeys(map[string]string{"batch": "KEY-1"})
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, keys.Set(map[string]string{"batch": "KEY-1", "admin": "KEY-2"}))
+		}()
+		go func() {
+			defer wg.Done()
+			name, ok := keys.name("KEY-1")
+			assert.True(t, ok)
+			assert.Equal(t, "batch", name)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAuthenticateExemptServices(t *testing.T) {
+	keys, err := NewAPIKeys(map[string]string{"batch": "KEY-1"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lis := bufconn.Listen(1 << 20)
+	sv := grpc.NewServer()
+	require.NoError(t, New(ctx, sv, Config{APIKeys: keys}))
+	go func() { _ = sv.Serve(lis) }()
+	defer sv.Stop()
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = pb.NewClientsServiceClient(conn).Sort(ctx, &pb.SortRequest{Items: []string{"b", "a"}})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	resp, err := pb.NewClientsServiceClient(conn).Sort(metadata.AppendToOutgoingContext(ctx, authorizationHeader, "Bearer KEY-1"),
+		&pb.SortRequest{Items: []string{"b", "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, resp.Items)
+
+	// the health service needs no key
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxUpcomingBirthdaysDays is the longest window of GetUpcomingBirthdays: every birthday is within a year
+const maxUpcomingBirthdaysDays = 365
+
+// nextBirthdaySQL is the first anniversary of the birthday on or after the reference day ($ref): the
+// birthday moved to the year of $ref, or to the next one when it already passed. MySQL clamps a Feb 29
+// moved to a non-leap year to Feb 28.
+const nextBirthdaySQL = "DATE(birthday) + INTERVAL (YEAR($ref) - YEAR(birthday) + " +
+	"(DATE_FORMAT(birthday, '%m%d') < DATE_FORMAT($ref, '%m%d'))) YEAR"
+
+// GetUpcomingBirthdays returns the clients whose birthday falls in the req.Days days after the reference
+// day (today by default, as of the database), the soonest first, with how many days away it is.
+// The birthdays are computed for every client with one, so the birthday index isn't used.
+func (s *Service) GetUpcomingBirthdays(ctx context.Context, req *pb.GetUpcomingBirthdaysRequest) (*pb.GetUpcomingBirthdaysResponse, error) {
+	if req.Days < 0 || req.Days > maxUpcomingBirthdaysDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 0 and %d", maxUpcomingBirthdaysDays)
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit can't be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultQueryClientsLimit
+	}
+	if limit > s.config.QueryClientsMaxLimit {
+		limit = s.config.QueryClientsMaxLimit
+	}
+
+	ref, refArgs := "CURDATE()", []interface{}{}
+	if req.From != nil {
+		ref, refArgs = "DATE(?)", []interface{}{time.Unix(0, req.From.Value)}
+	}
+	// $ref appears three times in the expression: twice in the next birthday and once more for DATEDIFF
+	args := append(append(append([]interface{}{}, refArgs...), refArgs...), refArgs...)
+	daysUntil := sq.Expr("DATEDIFF("+strings.ReplaceAll(nextBirthdaySQL, "$ref", ref)+", "+ref+") AS days_until", args...)
+	q, args, err := sq.Select(clientColumns...).Column(daysUntil).From("clients").
+		Where("deleted_at IS NULL").Where("birthday IS NOT NULL").
+		Having("days_until <= ?", req.Days).
+		OrderBy("days_until", "id").Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		clientRow
+		DaysUntil int64 `db:"days_until"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetUpcomingBirthdaysResponse{Birthdays: make([]*pb.UpcomingBirthday, 0, len(rows))}
+	clients := make([]*pb.Client, 0, len(rows))
+	for _, row := range rows {
+		birthday := &pb.UpcomingBirthday{Client: row.toPB(), DaysUntil: row.DaysUntil}
+		resp.Birthdays = append(resp.Birthdays, birthday)
+		clients = append(clients, birthday.Client)
+	}
+	if err := loadClientTags(ctx, s.db, clients...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
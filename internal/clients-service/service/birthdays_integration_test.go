@@ -0,0 +1,73 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetUpcomingBirthdaysIntegration pins the year wrap-around and the Feb 29 birthdays
+func TestGetUpcomingBirthdaysIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("birthdays-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	newClient := func(name string, birthday time.Time) string {
+		resp, err := service.NewClient(ctx, &pb.NewClientRequest{Name: prefix + name, Birthday: birthday.UnixNano()})
+		require.NoError(t, err)
+		return resp.Id
+	}
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	newYear := newClient("new year", date(1990, 1, 2))
+	leap := newClient("leap", date(2000, 2, 29))
+	march := newClient("march", date(1985, 3, 1))
+
+	tests := []struct {
+		name string
+		from time.Time
+		days int64
+		want map[string]int64
+	}{
+		{"wraps around the year", date(2026, 12, 30), 5, map[string]int64{newYear: 3}},
+		{"leap birthday on Feb 28 of a non-leap year", date(2027, 2, 28), 1, map[string]int64{leap: 0, march: 1}},
+		{"leap birthday passed", date(2027, 3, 1), 365, map[string]int64{march: 0, newYear: 307, leap: 365}},
+		{"leap birthday on a leap year", date(2028, 2, 28), 1, map[string]int64{leap: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.GetUpcomingBirthdays(ctx, &pb.GetUpcomingBirthdaysRequest{
+				Days:  tt.days,
+				From:  &pb.OptInt64{Value: tt.from.UnixNano()},
+				Limit: service.config.QueryClientsMaxLimit,
+			})
+			require.NoError(t, err)
+			got := make(map[string]int64)
+			for _, b := range resp.Birthdays {
+				if _, ok := map[string]bool{newYear: true, leap: true, march: true}[b.Client.Id]; ok {
+					got[b.Client.Id] = b.DaysUntil
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetUpcomingBirthdays(t *testing.T) {
+	service, mock := newTestService(t)
+	next := "DATE(birthday) + INTERVAL (YEAR(CURDATE()) - YEAR(birthday) + " +
+		"(DATE_FORMAT(birthday, '%m%d') < DATE_FORMAT(CURDATE(), '%m%d'))) YEAR"
+
+	mock.ExpectQuery(regexp.QuoteMeta(", DATEDIFF(" + next + ", CURDATE()) AS days_until FROM clients " +
+		"WHERE deleted_at IS NULL AND birthday IS NOT NULL HAVING days_until <= ? ORDER BY days_until, id LIMIT 100")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "days_until"}).
+			AddRow("ALICE", "Alice", 0).
+			AddRow("BOB", "Bob", 6))
+	expectClientTags(mock)
+	resp, err := service.GetUpcomingBirthdays(context.Background(), &pb.GetUpcomingBirthdaysRequest{Days: 7})
+	require.NoError(t, err)
+	require.Len(t, resp.Birthdays, 2)
+	assert.Equal(t, "ALICE", resp.Birthdays[0].Client.Id)
+	assert.Equal(t, int64(0), resp.Birthdays[0].DaysUntil)
+	assert.Equal(t, int64(6), resp.Birthdays[1].DaysUntil)
+
+	from := time.Date(2026, 12, 30, 12, 0, 0, 0, time.Local)
+	mock.ExpectQuery(regexp.QuoteMeta("DATEDIFF(DATE(birthday) + INTERVAL (YEAR(DATE(?)) - YEAR(birthday) + "+
+		"(DATE_FORMAT(birthday, '%m%d') < DATE_FORMAT(DATE(?), '%m%d'))) YEAR, DATE(?)) AS days_until")).
+		WithArgs(from, from, from, int64(30)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "days_until"}))
+	_, err = service.GetUpcomingBirthdays(context.Background(), &pb.GetUpcomingBirthdaysRequest{
+		Days:  30,
+		From:  &pb.OptInt64{Value: from.UnixNano()},
+		Limit: 5000,
+	})
+	require.NoError(t, err)
+
+	for _, req := range []*pb.GetUpcomingBirthdaysRequest{{Days: -1}, {Days: 366}, {Days: 7, Limit: -1}} {
+		_, err = service.GetUpcomingBirthdays(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
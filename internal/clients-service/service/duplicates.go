@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultDuplicateGroupsLimit is the number of groups FindDuplicateClients returns when no limit is given
+	defaultDuplicateGroupsLimit = 50
+	// maxDuplicateGroupsLimit is the largest page of FindDuplicateClients
+	maxDuplicateGroupsLimit = 500
+)
+
+// normalizedNameSQL is the name duplicates are grouped by: trimmed, lowercased and with its runs of
+// whitespace collapsed into a single space (REGEXP_REPLACE needs MariaDB 10.0.5+ or MySQL 8.0+)
+const normalizedNameSQL = "LOWER(TRIM(REGEXP_REPLACE(name, '[[:space:]]+', ' ')))"
+
+// duplicateKey identifies a group of probable duplicates
+type duplicateKey struct {
+	Name     string       `db:"name_key"`
+	Birthday sql.NullTime `db:"birthday_key"`
+}
+
+// groupKey identifies the group of k in a map, where the times read can't be compared reliably
+type groupKey struct {
+	name     string
+	birthday int64
+}
+
+func (k duplicateKey) groupKey() groupKey {
+	return groupKey{name: k.Name, birthday: unixNano(k.Birthday)}
+}
+
+// FindDuplicateClients returns a page of groups of clients sharing a normalized name (and, with
+// req.MatchBirthday, a birth date), for a reviewer to pick the survivors to keep with MergeClients.
+func (s *Service) FindDuplicateClients(ctx context.Context, req *pb.FindDuplicateClientsRequest) (*pb.FindDuplicateClientsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset can't be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultDuplicateGroupsLimit
+	}
+	if limit > maxDuplicateGroupsLimit {
+		limit = maxDuplicateGroupsLimit
+	}
+
+	keyColumns := []string{normalizedNameSQL + " AS name_key"}
+	groupBy := []string{"name_key"}
+	clients := sq.Select().From("clients").Where("deleted_at IS NULL")
+	if req.MatchBirthday {
+		keyColumns = append(keyColumns, "DATE(birthday) AS birthday_key")
+		groupBy = append(groupBy, "birthday_key")
+		clients = clients.Where("birthday IS NOT NULL")
+	}
+	q, args, err := clients.Columns(keyColumns...).GroupBy(groupBy...).Having("COUNT(*) > 1").
+		OrderBy(groupBy...).Limit(uint64(limit)).Offset(uint64(req.Offset)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	keys := []duplicateKey{}
+	if err := s.db.SelectContext(ctx, &keys, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.FindDuplicateClientsResponse{Groups: make([]*pb.DuplicateClientGroup, 0, len(keys))}
+	if len(keys) == 0 {
+		return resp, nil
+	}
+
+	groups := make(map[groupKey]*pb.DuplicateClientGroup, len(keys))
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		group := &pb.DuplicateClientGroup{NormalizedName: key.Name, Birthday: unixNano(key.Birthday)}
+		groups[key.groupKey()] = group
+		resp.Groups = append(resp.Groups, group)
+		// with birthdays, a name can be in many groups
+		if len(names) == 0 || names[len(names)-1] != key.Name {
+			names = append(names, key.Name)
+		}
+	}
+	q, args, err = clients.Columns(append([]string{"id", "name", "score", "created_at", "birthday"}, keyColumns...)...).
+		Where(sq.Eq{normalizedNameSQL: names}).OrderBy("created_at", "id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		duplicateKey
+		ID        string        `db:"id"`
+		Name      string        `db:"name"`
+		Score     sql.NullInt64 `db:"score"`
+		CreatedAt time.Time     `db:"created_at"`
+		Birthday  sql.NullTime  `db:"birthday"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		// with birthdays, a name also matches the clients of the groups of other pages (or of none)
+		group, ok := groups[row.duplicateKey.groupKey()]
+		if !ok {
+			continue
+		}
+		group.Clients = append(group.Clients, &pb.DuplicateClient{
+			Id:        row.ID,
+			Name:      row.Name,
+			Score:     row.Score.Int64,
+			CreatedAt: row.CreatedAt.UnixNano(),
+			Birthday:  unixNano(row.Birthday),
+		})
+	}
+	return resp, nil
+}
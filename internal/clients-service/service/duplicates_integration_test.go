@@ -0,0 +1,65 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFindDuplicateClientsIntegration checks the name normalization of the database
+func TestFindDuplicateClientsIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	// the groups are ordered by name, so the ones of this run come first
+	prefix := fmt.Sprintf("0000-dups-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", "%"+prefix+"%")
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC).UnixNano()
+	newClient := func(name string, birthday int64) string {
+		resp, err := service.NewClient(ctx, &pb.NewClientRequest{Name: name, Birthday: birthday})
+		require.NoError(t, err)
+		return resp.Id
+	}
+	ana := newClient(prefix+"Ana Maria", birthday)
+	anaSpaced := newClient("  "+strings.ToUpper(prefix)+"ana \t maria ", birthday)
+	anaOther := newClient(prefix+"ANA MARIA", 0)
+	newClient(prefix+"Ana Mariana", birthday)
+
+	ids := func(req *pb.FindDuplicateClientsRequest) [][]string {
+		resp, err := service.FindDuplicateClients(ctx, req)
+		require.NoError(t, err)
+		groups := make([][]string, 0)
+		for _, group := range resp.Groups {
+			if !strings.HasPrefix(group.NormalizedName, prefix) {
+				continue
+			}
+			assert.Equal(t, prefix+"ana maria", group.NormalizedName)
+			groupIDs := make([]string, 0, len(group.Clients))
+			for _, c := range group.Clients {
+				groupIDs = append(groupIDs, c.Id)
+			}
+			groups = append(groups, groupIDs)
+		}
+		return groups
+	}
+	assert.Equal(t, [][]string{{ana, anaSpaced, anaOther}}, ids(&pb.FindDuplicateClientsRequest{Limit: 500}))
+	assert.Equal(t, [][]string{{ana, anaSpaced}}, ids(&pb.FindDuplicateClientsRequest{Limit: 500, MatchBirthday: true}))
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFindDuplicateClients(t *testing.T) {
+	service, mock := newTestService(t)
+	created := time.Unix(0, time.Now().UnixNano())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + normalizedNameSQL + " AS name_key FROM clients WHERE deleted_at IS NULL " +
+		"GROUP BY name_key HAVING COUNT(*) > 1 ORDER BY name_key LIMIT 50 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"name_key"}).AddRow("ana maria").AddRow("bob"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, score, created_at, birthday, "+normalizedNameSQL+" AS name_key "+
+		"FROM clients WHERE deleted_at IS NULL AND "+normalizedNameSQL+" IN (?,?) ORDER BY created_at, id")).
+		WithArgs("ana maria", "bob").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "created_at", "birthday", "name_key"}).
+			AddRow("ANA1", "Ana Maria", 10, created, nil, "ana maria").
+			AddRow("BOB1", "Bob", nil, created, nil, "bob").
+			AddRow("ANA2", " ana  MARIA", 5, created, nil, "ana maria").
+			AddRow("BOB2", "bob ", 0, created, nil, "bob"))
+	resp, err := service.FindDuplicateClients(context.Background(), &pb.FindDuplicateClientsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Groups, 2)
+	assert.Equal(t, "ana maria", resp.Groups[0].NormalizedName)
+	assert.Equal(t, []*pb.DuplicateClient{
+		{Id: "ANA1", Name: "Ana Maria", Score: 10, CreatedAt: created.UnixNano()},
+		{Id: "ANA2", Name: " ana  MARIA", Score: 5, CreatedAt: created.UnixNano()},
+	}, resp.Groups[0].Clients)
+	assert.Len(t, resp.Groups[1].Clients, 2)
+
+	// by birth date: the clients of a name with another birthday are in no group of the page
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("AS name_key, DATE(birthday) AS birthday_key FROM clients " +
+		"WHERE deleted_at IS NULL AND birthday IS NOT NULL GROUP BY name_key, birthday_key HAVING COUNT(*) > 1 " +
+		"ORDER BY name_key, birthday_key LIMIT 500 OFFSET 10")).
+		WillReturnRows(sqlmock.NewRows([]string{"name_key", "birthday_key"}).AddRow("bob", birthday))
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL AND birthday IS NOT NULL AND " + normalizedNameSQL + " IN (?)")).
+		WithArgs("bob").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "created_at", "birthday", "name_key", "birthday_key"}).
+			AddRow("BOB1", "Bob", 1, created, birthday.Add(8*time.Hour), "bob", birthday).
+			AddRow("BOB3", "Bob", 1, created, birthday.AddDate(1, 0, 0), "bob", birthday.AddDate(1, 0, 0)).
+			AddRow("BOB2", "bob", 2, created, birthday, "bob", birthday))
+	resp, err = service.FindDuplicateClients(context.Background(), &pb.FindDuplicateClientsRequest{
+		MatchBirthday: true,
+		Limit:         1000,
+		Offset:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Groups, 1)
+	assert.Equal(t, birthday.UnixNano(), resp.Groups[0].Birthday)
+	require.Len(t, resp.Groups[0].Clients, 2)
+	assert.Equal(t, "BOB1", resp.Groups[0].Clients[0].Id)
+	assert.Equal(t, "BOB2", resp.Groups[0].Clients[1].Id)
+
+	_, err = service.FindDuplicateClients(context.Background(), &pb.FindDuplicateClientsRequest{Offset: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,168 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClientFiltersIntegration runs the QueryClients filters against a database with the schema of the
+// README, checking that each of them narrows the results to the expected clients:
+//
+//	TEST_DBCS="user:password@tcp(host:port)/ms_training_test?parseTime=true" go test -tags integration ./...
+func TestClientFiltersIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	// the clients of this run are told apart by their name prefix
+	prefix := fmt.Sprintf("filters-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	start := time.Now().Add(-time.Second)
+
+	newClient := func(req *pb.NewClientRequest) string {
+		req.Name = prefix + req.Name
+		resp, err := service.NewClient(ctx, req)
+		require.NoError(t, err)
+		return resp.Id
+	}
+	alice := newClient(&pb.NewClientRequest{
+		Name:       "alice",
+		Birthday:   time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+		Score:      50,
+		Email:      prefix + "alice@example.com",
+		Phone:      "11 91234-0001",
+		ExternalId: prefix + "A",
+	})
+	bob := newClient(&pb.NewClientRequest{
+		Name:     "bob",
+		Birthday: time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+		Score:    10,
+	})
+	carol := newClient(&pb.NewClientRequest{Name: "carol"})
+	dave := newClient(&pb.NewClientRequest{Name: "100%_dave"})
+	_, err = service.AddClientTags(ctx, &pb.AddClientTagsRequest{ClientId: bob, Tags: []string{"vip"}})
+	require.NoError(t, err)
+	_, err = service.TouchClient(ctx, &pb.TouchClientRequest{Id: carol})
+	require.NoError(t, err)
+
+	mid := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	ninety := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	twoThousandFive := time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	tests := []struct {
+		name string
+		req  *pb.QueryClientsRequest
+		want []string
+	}{
+		{"none", &pb.QueryClientsRequest{}, []string{alice, bob, carol, dave}},
+		{"id", &pb.QueryClientsRequest{Id: &pb.OptString{Value: bob}}, []string{bob}},
+		{"name", &pb.QueryClientsRequest{Name: &pb.OptString{Value: prefix + "a%"}}, []string{alice}},
+		{"name exact", &pb.QueryClientsRequest{Name: &pb.OptString{Value: prefix + "100%_dave"}, NameMatch: pb.NameMatch_EXACT}, []string{dave}},
+		{"name prefix", &pb.QueryClientsRequest{Name: &pb.OptString{Value: prefix + "100%_"}, NameMatch: pb.NameMatch_PREFIX}, []string{dave}},
+		{"name prefix literal", &pb.QueryClientsRequest{Name: &pb.OptString{Value: prefix + "_"}, NameMatch: pb.NameMatch_PREFIX}, []string{}},
+		{"name contains", &pb.QueryClientsRequest{Name: &pb.OptString{Value: prefix[1:] + "100%_d"}, NameMatch: pb.NameMatch_CONTAINS}, []string{dave}},
+		{"birthday", &pb.QueryClientsRequest{Birthday: &pb.Int64Comp{Value: mid, Op: "<"}}, []string{alice}},
+		// alice and bob are born at the first instant of 1990 and 2005, clients without a birthday never match
+		{"birthday range", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: ninety}, BirthdayTo: &pb.OptInt64{Value: twoThousandFive}}, []string{alice, bob}},
+		{"birthday range from", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: ninety + 1}, BirthdayTo: &pb.OptInt64{Value: twoThousandFive}}, []string{bob}},
+		{"birthday range to", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: ninety}, BirthdayTo: &pb.OptInt64{Value: twoThousandFive - 1}}, []string{alice}},
+		{"birthday is null", &pb.QueryClientsRequest{BirthdayIsNull: &pb.OptBool{Value: true}}, []string{carol, dave}},
+		{"birthday is not null", &pb.QueryClientsRequest{BirthdayIsNull: &pb.OptBool{}}, []string{alice, bob}},
+		{"birthday from", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: 0}}, []string{alice, bob}},
+		{"score", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: ">"}}, []string{alice}},
+		{"score range", &pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: 10}, ScoreMax: &pb.OptInt64{Value: 50}}, []string{alice, bob}},
+		{"score range inside the bounds", &pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: 11}, ScoreMax: &pb.OptInt64{Value: 49}}, []string{}},
+		{"created_at", &pb.QueryClientsRequest{CreatedAt: &pb.Int64Comp{Value: start.UnixNano(), Op: ">="}}, []string{alice, bob, carol, dave}},
+		{"created_at before", &pb.QueryClientsRequest{CreatedAt: &pb.Int64Comp{Value: start.UnixNano(), Op: "<"}}, []string{}},
+		{"created_within", &pb.QueryClientsRequest{CreatedWithin: 3600}, []string{alice, bob, carol, dave}},
+		{"updated_at", &pb.QueryClientsRequest{UpdatedAt: &pb.Int64Comp{Value: start.UnixNano(), Op: "<"}}, []string{}},
+		{"last_seen_at", &pb.QueryClientsRequest{LastSeenAt: &pb.Int64Comp{Value: start.UnixNano(), Op: ">"}}, []string{carol}},
+		{"email", &pb.QueryClientsRequest{Email: &pb.OptString{Value: prefix + "alice@example.com"}}, []string{alice}},
+		{"external_id", &pb.QueryClientsRequest{ExternalId: &pb.OptString{Value: prefix + "A"}}, []string{alice}},
+		{"phone", &pb.QueryClientsRequest{Phone: &pb.OptString{Value: "5511912340001"}}, []string{alice}},
+		{"status", &pb.QueryClientsRequest{Status: []pb.ClientStatus{pb.ClientStatus_BANNED}}, []string{}},
+		{"tags", &pb.QueryClientsRequest{Tags: []string{"vip"}}, []string{bob}},
+		{"filter groups", &pb.QueryClientsRequest{FilterGroups: []*pb.QueryClientsRequest{
+			{Name: &pb.OptString{Value: prefix + "c%"}},
+			{Score: &pb.Int64Comp{Value: 10, Op: ">"}},
+		}}, []string{alice, carol}},
+		{"ids", &pb.QueryClientsRequest{Ids: []string{alice, bob, "MISSING"}, Score: &pb.Int64Comp{Value: 10, Op: ">"}}, []string{alice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Name == nil {
+				req.Name = &pb.OptString{Value: prefix + "%"}
+			}
+			resp, err := service.QueryClients(ctx, req)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, resp.Ids)
+		})
+	}
+}
+
+// TestAgeFiltersIntegration pins the bounds of the age filters around birthdays, as of the database date
+func TestAgeFiltersIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	var today time.Time
+	require.NoError(t, db.GetContext(ctx, &today, "SELECT CURDATE()"))
+	prefix := fmt.Sprintf("ages-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	newClient := func(name string, birthday int64) string {
+		resp, err := service.NewClient(ctx, &pb.NewClientRequest{Name: prefix + name, Birthday: birthday})
+		require.NoError(t, err)
+		return resp.Id
+	}
+	turns18Today := newClient("turns 18 today", today.AddDate(-18, 0, 0).Add(3*time.Hour).UnixNano())
+	turns18Tomorrow := newClient("turns 18 tomorrow", today.AddDate(-18, 0, 1).UnixNano())
+	turns26Today := newClient("turns 26 today", today.AddDate(-26, 0, 0).UnixNano())
+	turns26Tomorrow := newClient("turns 26 tomorrow", today.AddDate(-26, 0, 1).UnixNano())
+	newClient("no birthday", 0)
+
+	tests := []struct {
+		name     string
+		min, max *pb.OptInt64
+		want     []string
+	}{
+		{"18 to 25", &pb.OptInt64{Value: 18}, &pb.OptInt64{Value: 25}, []string{turns18Today, turns26Tomorrow}},
+		{"from 18", &pb.OptInt64{Value: 18}, nil, []string{turns18Today, turns26Today, turns26Tomorrow}},
+		{"up to 17", nil, &pb.OptInt64{Value: 17}, []string{turns18Tomorrow}},
+		{"26", &pb.OptInt64{Value: 26}, &pb.OptInt64{Value: 26}, []string{turns26Today}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.QueryClients(ctx, &pb.QueryClientsRequest{
+				Name:   &pb.OptString{Value: prefix + "%"},
+				AgeMin: tt.min,
+				AgeMax: tt.max,
+			})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, resp.Ids)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// clientsServiceName is the name the status of pb.ClientsService is reported under
+const clientsServiceName = "pb.ClientsService"
+
+// serviceHealth publishes the health of the service on the standard gRPC health service. The overall ("")
+// status follows the database, and the status of pb.ClientsService also goes NOT_SERVING while it's taken out
+// of service with SetClientsServing. Both are NOT_SERVING until the database answers a first ping.
+type serviceHealth struct {
+	server *health.Server
+
+	mu        sync.Mutex
+	dbUp      bool
+	clientsUp bool
+}
+
+func newServiceHealth() *serviceHealth {
+	h := &serviceHealth{server: health.NewServer(), clientsUp: true}
+	h.publish()
+	return h
+}
+
+func (h *serviceHealth) setDBUp(up bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dbUp = up
+	h.publish()
+}
+
+func (h *serviceHealth) setClientsUp(up bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clientsUp = up
+	h.publish()
+}
+
+// publish sets the statuses of the health server, with h.mu held (or before h is shared)
+func (h *serviceHealth) publish() {
+	h.server.SetServingStatus("", servingStatus(h.dbUp))
+	h.server.SetServingStatus(clientsServiceName, servingStatus(h.dbUp && h.clientsUp))
+}
+
+func servingStatus(up bool) healthpb.HealthCheckResponse_ServingStatus {
+	if up {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// SetClientsServing takes pb.ClientsService out of service (as before a shutdown) or back into it on the
+// health service, regardless of the overall status. It's still NOT_SERVING while the database is down.
+func (s *Service) SetClientsServing(serving bool) {
+	s.health.setClientsUp(serving)
+}
+
+// pingDB pings the database every Config.HealthCheckInterval, reporting it down while the pings fail, until
+// ctx is done and everything is reported NOT_SERVING for good
+func (s *Service) pingDB(ctx context.Context) {
+	ticker := time.NewTicker(s.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.checkDB(ctx)
+		select {
+		case <-ctx.Done():
+			s.health.server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDB pings the database once, giving up at the next ping
+func (s *Service) checkDB(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.HealthCheckInterval)
+	defer cancel()
+	s.health.setDBUp(s.db.PingContext(ctx) == nil)
+}
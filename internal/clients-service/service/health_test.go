@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newHealthTestService is newTestService with the pings of the database expected as well
+func newHealthTestService(t *testing.T) (*Service, sqlmock.Sqlmock) {
+	rdb, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	service := &Service{
+		db:     sqlx.NewDb(rdb, "sqlmock"),
+		config: Config{HealthCheckInterval: time.Hour}.withDefaults(),
+		health: newServiceHealth(),
+	}
+	return service, mock
+}
+
+// assertHealth checks the overall status and the one of pb.ClientsService
+func assertHealth(t *testing.T, service *Service, overall, clients healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	for name, want := range map[string]healthpb.HealthCheckResponse_ServingStatus{"": overall, clientsServiceName: clients} {
+		resp, err := service.health.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+		require.NoError(t, err)
+		assert.Equal(t, want, resp.Status, "status of %q", name)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	service, mock := newHealthTestService(t)
+	ctx := context.Background()
+	serving, notServing := healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+
+	// nothing is serving before the first ping
+	assertHealth(t, service, notServing, notServing)
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	service.checkDB(ctx)
+	assertHealth(t, service, notServing, notServing)
+	mock.ExpectPing()
+	service.checkDB(ctx)
+	assertHealth(t, service, serving, serving)
+
+	service.SetClientsServing(false)
+	assertHealth(t, service, serving, notServing)
+	mock.ExpectPing().WillReturnError(errors.New("connection reset"))
+	service.checkDB(ctx)
+	assertHealth(t, service, notServing, notServing)
+	// the database comes back, pb.ClientsService stays out of service until set back
+	mock.ExpectPing()
+	service.checkDB(ctx)
+	assertHealth(t, service, serving, notServing)
+	service.SetClientsServing(true)
+	assertHealth(t, service, serving, serving)
+
+	// while the database is down, setting pb.ClientsService back doesn't make it serve
+	mock.ExpectPing().WillReturnError(errors.New("connection reset"))
+	service.checkDB(ctx)
+	service.SetClientsServing(true)
+	assertHealth(t, service, notServing, notServing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthPingDBStops(t *testing.T) {
+	service, mock := newHealthTestService(t)
+	mock.ExpectPing()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.pingDB(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		resp, err := service.health.server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && resp.Status == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pingDB did not stop")
+	}
+	// the statuses stay NOT_SERVING for good once stopped
+	service.SetClientsServing(true)
+	service.health.setDBUp(true)
+	assertHealth(t, service, healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithServingSetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lis := bufconn.Listen(1 << 20)
+	sv := grpc.NewServer()
+	var setServing func(serving bool)
+	require.NoError(t, New(ctx, sv, Config{HealthCheckInterval: time.Hour}, WithServingSetter(&setServing)))
+	require.NotNil(t, setServing)
+	go func() { _ = sv.Serve(lis) }()
+	defer sv.Stop()
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	setServing(false)
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: clientsServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
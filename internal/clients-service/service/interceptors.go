@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc"
+)
+
+// registerClientsService registers s on sv with the interceptors of the service itself, which run inside the
+// ones of the server so that these see their errors: the authentication, the rate limits, then the recovery
+// of the panics. The other services, as the health one, have none of them.
+func registerClientsService(sv *grpc.Server, s *Service) {
+	desc := pb.ClientsServiceDesc
+	desc.Methods = append([]grpc.MethodDesc(nil), desc.Methods...)
+	for i := range desc.Methods {
+		handler := desc.Methods[i].Handler
+		desc.Methods[i].Handler = func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			return handler(srv, ctx, dec, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (interface{}, error) {
+				if interceptor == nil {
+					return s.unaryInterceptor(ctx, req, info, h)
+				}
+				return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return s.unaryInterceptor(ctx, req, info, h)
+				})
+			})
+		}
+	}
+	desc.Streams = append([]grpc.StreamDesc(nil), desc.Streams...)
+	for i := range desc.Streams {
+		handler := desc.Streams[i].Handler
+		info := &grpc.StreamServerInfo{
+			FullMethod:     "/" + desc.ServiceName + "/" + desc.Streams[i].StreamName,
+			IsClientStream: desc.Streams[i].ClientStreams,
+			IsServerStream: desc.Streams[i].ServerStreams,
+		}
+		desc.Streams[i].Handler = func(srv interface{}, stream grpc.ServerStream) error {
+			return s.streamInterceptor(srv, stream, info, handler)
+		}
+	}
+	sv.RegisterService(&desc, s)
+}
+
+func (s *Service) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.limiter.allow(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return s.recoveryUnaryInterceptor(ctx, req, info, handler)
+}
+
+func (s *Service) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err := s.limiter.allow(info.FullMethod); err != nil {
+		return err
+	}
+	return s.recoveryStreamInterceptor(srv, &contextServerStream{ServerStream: ss, ctx: ctx}, info, handler)
+}
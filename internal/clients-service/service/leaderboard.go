@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultLeaderboardLimit is the number of entries returned when no limit is given
+	defaultLeaderboardLimit = 10
+	// maxLeaderboardLimit is the largest page of a leaderboard
+	maxLeaderboardLimit = 1000
+)
+
+// leaderboardPage validates a leaderboard page, applying the default and max limits
+func leaderboardPage(limit, offset int64) (uint64, uint64, error) {
+	if limit < 0 || offset < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "limit and offset can't be negative")
+	}
+	if limit == 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+	return uint64(limit), uint64(offset), nil
+}
+
+// GetLeaderboard returns the clients with the highest scores. The order is total (score, then
+// created_at, then id), so the ranks stay consistent across pages.
+// With a season, the clients are ranked by the sum of their matches in the season instead, and the
+// clients without matches in it are left out.
+func (s *Service) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.GetLeaderboardResponse, error) {
+	limit, offset, err := leaderboardPage(req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	lq := sq.Select(clientColumns...).From("`clients`").Where("deleted_at IS NULL")
+	rankBy := "score DESC"
+	if req.SeasonId != "" {
+		if err := checkSeason(ctx, s.db, req.SeasonId); err != nil {
+			return nil, err
+		}
+		seasonSQL, seasonArgs, err := sq.Select("client_id", "SUM(score) AS season_score").From("client_matches").
+			Where(sq.Eq{"season_id": req.SeasonId}).Where(s.scoringMatches("")).
+			GroupBy("client_id").ToSql()
+		if err != nil {
+			return nil, err
+		}
+		lq = lq.Column("s.season_score").
+			Join("("+seasonSQL+") s ON s.client_id = `clients`.id", seasonArgs...)
+		rankBy = "s.season_score DESC"
+	}
+	q, args, err := lq.OrderBy(rankBy, "created_at ASC", "id ASC").Limit(limit).Offset(offset).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		clientRow
+		SeasonScore int64 `db:"season_score"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetLeaderboardResponse{Entries: make([]*pb.LeaderboardEntry, 0, len(rows))}
+	clients := make([]*pb.Client, 0, len(rows))
+	for i, row := range rows {
+		entry := &pb.LeaderboardEntry{Client: row.toPB(), SeasonScore: row.SeasonScore}
+		if req.IncludeRank {
+			entry.Rank = int64(offset) + int64(i) + 1
+		}
+		resp.Entries = append(resp.Entries, entry)
+		clients = append(clients, entry.Client)
+	}
+	if err := loadClientTags(ctx, s.db, clients...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLeaderboardForPeriod ranks the clients by the sum of the scores of the matches they played in
+// [from, to), optionally only counting the matches of a season. Like the score, practice matches
+// don't count with ExcludePracticeScore. Clients without matches in the period are left out.
+func (s *Service) GetLeaderboardForPeriod(ctx context.Context, req *pb.GetLeaderboardForPeriodRequest) (*pb.GetLeaderboardForPeriodResponse, error) {
+	limit, _, err := leaderboardPage(req.Limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	to := time.Now()
+	if req.To != 0 {
+		to = time.Unix(0, req.To)
+	}
+	from := time.Unix(0, req.From)
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	if err := checkSeason(ctx, s.db, req.SeasonId); err != nil {
+		return nil, err
+	}
+	var season sq.Sqlizer
+	if req.SeasonId != "" {
+		season = sq.Eq{"m.season_id": req.SeasonId}
+	}
+	q, args, err := sq.Select("c.id", "c.name", "SUM(m.score) AS period_score").
+		From("client_matches m").
+		Join("clients c ON c.id = m.client_id").
+		Where("m.played_at >= ? AND m.played_at < ?", from, to).
+		Where(season).
+		Where(s.scoringMatches("m.")).
+		Where("c.deleted_at IS NULL").
+		GroupBy("c.id", "c.name").
+		OrderBy("period_score DESC", "c.id ASC").
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		ID    string `db:"id"`
+		Name  string `db:"name"`
+		Score int64  `db:"period_score"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetLeaderboardForPeriodResponse{Entries: make([]*pb.PeriodLeaderboardEntry, 0, len(rows))}
+	for i, row := range rows {
+		resp.Entries = append(resp.Entries, &pb.PeriodLeaderboardEntry{
+			ClientId: row.ID,
+			Name:     row.Name,
+			Score:    row.Score,
+			Rank:     int64(i) + 1,
+		})
+	}
+	return resp, nil
+}
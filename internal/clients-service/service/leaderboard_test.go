@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetLeaderboard(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE deleted_at IS NULL " +
+		"ORDER BY score DESC, created_at ASC, id ASC LIMIT 2 OFFSET 10")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}).
+			AddRow("ALICE", "Alice", 90).
+			AddRow("BOB", "Bob", 90))
+	expectClientTags(mock, [2]string{"BOB", "vip"})
+	resp, err := service.GetLeaderboard(context.Background(), &pb.GetLeaderboardRequest{Limit: 2, Offset: 10, IncludeRank: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	assert.Equal(t, "ALICE", resp.Entries[0].Client.Id)
+	assert.Equal(t, int64(11), resp.Entries[0].Rank)
+	assert.Equal(t, int64(12), resp.Entries[1].Rank)
+	assert.Equal(t, []string{"vip"}, resp.Entries[1].Client.Tags)
+
+	mock.ExpectQuery(regexp.QuoteMeta("LIMIT 1000 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}).AddRow("ALICE", "Alice", 90))
+	expectClientTags(mock)
+	resp, err = service.GetLeaderboard(context.Background(), &pb.GetLeaderboardRequest{Limit: 5000})
+	require.NoError(t, err)
+	assert.Zero(t, resp.Entries[0].Rank)
+
+	_, err = service.GetLeaderboard(context.Background(), &pb.GetLeaderboardRequest{Offset: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLeaderboardForPeriod(t *testing.T) {
+	service, mock := newTestService(t)
+	to := time.Unix(0, time.Now().UnixNano())
+	from := to.Add(-7 * 24 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.name, SUM(m.score) AS period_score FROM client_matches m "+
+		"JOIN clients c ON c.id = m.client_id WHERE m.played_at >= ? AND m.played_at < ? AND c.deleted_at IS NULL "+
+		"GROUP BY c.id, c.name ORDER BY period_score DESC, c.id ASC LIMIT 10")).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "period_score"}).
+			AddRow("BOB", "Bob", 30).
+			AddRow("ALICE", "Alice", 12))
+	resp, err := service.GetLeaderboardForPeriod(context.Background(), &pb.GetLeaderboardForPeriodRequest{
+		From: from.UnixNano(),
+		To:   to.UnixNano(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.PeriodLeaderboardEntry{
+		{ClientId: "BOB", Name: "Bob", Score: 30, Rank: 1},
+		{ClientId: "ALICE", Name: "Alice", Score: 12, Rank: 2},
+	}, resp.Entries)
+
+	_, err = service.GetLeaderboardForPeriod(context.Background(), &pb.GetLeaderboardForPeriodRequest{
+		From: to.UnixNano(),
+		To:   from.UnixNano(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// practice matches don't count for the period score either
+	service.config.ExcludePracticeScore = true
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.name, SUM(m.score) AS period_score FROM client_matches m "+
+		"JOIN clients c ON c.id = m.client_id WHERE m.played_at >= ? AND m.played_at < ? AND m.match_type <> ? AND c.deleted_at IS NULL "+
+		"GROUP BY c.id, c.name ORDER BY period_score DESC, c.id ASC LIMIT 10")).
+		WithArgs(from, to, "PRACTICE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "period_score"}).AddRow("BOB", "Bob", 20))
+	resp, err = service.GetLeaderboardForPeriod(context.Background(), &pb.GetLeaderboardForPeriodRequest{
+		From: from.UnixNano(),
+		To:   to.UnixNano(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.PeriodLeaderboardEntry{{ClientId: "BOB", Name: "Bob", Score: 20, Rank: 1}}, resp.Entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pedidopago/trainingsvc-clients/utils"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// requestIDHeader is the metadata key of the request ids, both incoming and sent back
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds the incoming request ids, longer ones being replaced
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID is the id of the request of ctx, as set by the RequestLogging interceptors
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestLogging logs a line per RPC with its method, duration, code and request id, and the common name of
+// the client certificate if any. The request id is taken from the x-request-id metadata, or generated, and
+// sent back in the same header. It's in the context of the handlers, along with Logger, for RequestID and
+// zerolog.Ctx.
+type RequestLogging struct {
+	Logger zerolog.Logger
+	// PayloadMethods are the full names of the unary methods ("/pb.ClientsService/GetClient") whose request
+	// and response are logged too, as JSON truncated to MaxPayloadBytes (default 1024)
+	PayloadMethods  []string
+	MaxPayloadBytes int
+}
+
+// ServerOptions are the grpc.NewServer options logging the RPCs
+func (l RequestLogging) ServerOptions() []grpc.ServerOption {
+	r := l.requestLogger()
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(r.unaryInterceptor),
+		grpc.ChainStreamInterceptor(r.streamInterceptor),
+	}
+}
+
+func (l RequestLogging) requestLogger() requestLogger {
+	r := requestLogger{logger: l.Logger, payloadMethods: map[string]bool{}, maxPayload: l.MaxPayloadBytes}
+	for _, method := range l.PayloadMethods {
+		r.payloadMethods[method] = true
+	}
+	if r.maxPayload <= 0 {
+		r.maxPayload = 1024
+	}
+	return r
+}
+
+// requestLogger has the interceptors of RequestLogging
+type requestLogger struct {
+	logger         zerolog.Logger
+	payloadMethods map[string]bool
+	maxPayload     int
+}
+
+func (r requestLogger) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, logger := r.requestContext(ctx, info.FullMethod)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, RequestID(ctx)))
+	resp, err := handler(ctx, req)
+
+	event := r.event(logger, start, err)
+	if r.payloadMethods[info.FullMethod] {
+		event = event.Str("request", r.payload(req))
+		if err == nil {
+			event = event.Str("response", r.payload(resp))
+		}
+	}
+	event.Msg("rpc")
+	return resp, err
+}
+
+func (r requestLogger) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, logger := r.requestContext(ss.Context(), info.FullMethod)
+	_ = ss.SetHeader(metadata.Pairs(requestIDHeader, RequestID(ctx)))
+	err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	r.event(logger, start, err).Msg("rpc")
+	return err
+}
+
+// requestContext adds the request id and a logger with it, method and the client certificate name to ctx
+func (r requestLogger) requestContext(ctx context.Context, method string) (context.Context, zerolog.Logger) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDHeader); len(ids) > 0 && validRequestID(ids[0]) {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = utils.SecureID().String()
+	}
+	logCtx := r.logger.With().Str("request_id", id).Str("method", method)
+	if cn := ClientCommonName(ctx); cn != "" {
+		logCtx = logCtx.Str("client_cn", cn)
+	}
+	logger := logCtx.Logger()
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return logger.WithContext(ctx), logger
+}
+
+// validRequestID tells if an incoming request id can be taken as it is: not too long, nor with any
+// characters that could garble the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// event is the log line of an RPC started at start that returned err, as an error for the codes that
+// point to the service rather than to the caller
+func (r requestLogger) event(logger zerolog.Logger, start time.Time, err error) *zerolog.Event {
+	code := status.Code(err)
+	var event *zerolog.Event
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		event = logger.Error()
+	default:
+		event = logger.Info()
+	}
+	event = event.Dur("duration", time.Since(start)).Str("code", code.String())
+	if err != nil {
+		event = event.Str("error", status.Convert(err).Message())
+	}
+	return event
+}
+
+// payload is m as JSON, truncated to the max payload size
+func (r requestLogger) payload(m interface{}) string {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return ""
+	}
+	b, err := protojson.Marshal(proto.MessageV2(msg))
+	if err != nil {
+		return ""
+	}
+	if len(b) > r.maxPayload {
+		n := r.maxPayload
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
+		return string(b[:n]) + "..."
+	}
+	return string(b)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/pedidopago/trainingsvc-clients/utils"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// logLines are the JSON lines written to buf since the last call
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), line)
+		lines = append(lines, fields)
+	}
+	buf.Reset()
+	return lines
+}
+
+func TestRequestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := RequestLogging{
+		Logger:          zerolog.New(&buf),
+		PayloadMethods:  []string{"/pb.ClientsService/Sort"},
+		MaxPayloadBytes: 20,
+	}.requestLogger()
+	info := func(method string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: "/pb.ClientsService/" + method}
+	}
+
+	// the request id of the caller is taken, the handler logging with it
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+	_, err := logger.unaryInterceptor(ctx, &pb.GetClientRequest{Id: "A"}, info("GetClient"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, "req-1", RequestID(ctx))
+			zerolog.Ctx(ctx).Info().Msg("reading the client")
+			return nil, status.Error(codes.NotFound, "client not found")
+		})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, "reading the client", lines[0]["message"])
+	assert.Equal(t, "req-1", lines[1]["request_id"])
+	assert.Equal(t, "/pb.ClientsService/GetClient", lines[1]["method"])
+	assert.Equal(t, "NotFound", lines[1]["code"])
+	assert.Equal(t, "client not found", lines[1]["error"])
+	assert.Equal(t, "info", lines[1]["level"])
+	assert.Contains(t, lines[1], "duration")
+	assert.NotContains(t, lines[1], "request", "the payloads are off by default")
+
+	// a request id is generated when missing, or unfit for the logs
+	for _, md := range []metadata.MD{nil, metadata.Pairs("x-request-id", "bad\nid"), metadata.Pairs("x-request-id", strings.Repeat("x", 129))} {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		var id string
+		_, err := logger.unaryInterceptor(ctx, nil, info("GetClient"), func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = RequestID(ctx)
+			return nil, status.Error(codes.Internal, "boom")
+		})
+		require.Error(t, err)
+		assert.True(t, utils.IsIDValid(id), id)
+		lines := logLines(t, &buf)
+		require.Len(t, lines, 1)
+		assert.Equal(t, id, lines[0]["request_id"])
+		assert.Equal(t, "error", lines[0]["level"])
+	}
+
+	// the payloads of the chosen methods, truncated
+	_, err = logger.unaryInterceptor(context.Background(), &pb.SortRequest{Items: []string{"b", "a"}}, info("Sort"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.SortResponse{Items: []string{"a", "b"}}, nil
+		})
+	require.NoError(t, err)
+	lines = logLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.JSONEq(t, `{"items":["b","a"]}`, lines[0]["request"].(string))
+	assert.JSONEq(t, `{"items":["a","b"]}`, lines[0]["response"].(string))
+	assert.Equal(t, "OK", lines[0]["code"])
+	_, err = logger.unaryInterceptor(context.Background(), &pb.SortRequest{Items: []string{"ááááá", "ééééé"}}, info("Sort"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.InvalidArgument, "no")
+		})
+	require.Error(t, err)
+	lines = logLines(t, &buf)
+	// cut at 20 bytes, between runes
+	request := lines[0]["request"].(string)
+	assert.True(t, strings.HasSuffix(request, "..."), request)
+	assert.LessOrEqual(t, len(request), 20+len("..."))
+	assert.True(t, utf8.ValidString(request), request)
+	assert.NotContains(t, lines[0], "response")
+
+	// streams too, with the request id in their context
+	stream := &sortStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-2"))}
+	err = logger.streamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/pb.ClientsService/SortStream"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			assert.Equal(t, "req-2", RequestID(ss.Context()))
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-2"}, stream.header.Get("x-request-id"), "the request id is sent back")
+	lines = logLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "req-2", lines[0]["request_id"])
+	assert.Equal(t, "/pb.ClientsService/SortStream", lines[0]["method"])
+}
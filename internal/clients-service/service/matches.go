@@ -0,0 +1,807 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultMatchesLimit is the page size of ListMatches when no limit is given
+	defaultMatchesLimit = 100
+	// maxMatchesLimit is the largest page ListMatches returns
+	maxMatchesLimit = 1000
+	// defaultTopMatchesLimit is the number of matches GetTopMatches returns when no limit is given
+	defaultTopMatchesLimit = 10
+	// maxTopMatchesLimit is the most matches GetTopMatches returns
+	maxTopMatchesLimit = 100
+	// maxScoreHistoryBuckets is the most points GetScoreHistory returns
+	maxScoreHistoryBuckets = 1000
+)
+
+var matchColumns = []string{"id", "client_id", "score", "created_at", "opponent_id", "result", "played_at", "match_type"}
+
+type matchRow struct {
+	ID         int64          `db:"id"`
+	ClientID   string         `db:"client_id"`
+	Score      int64          `db:"score"`
+	CreatedAt  sql.NullTime   `db:"created_at"`
+	OpponentID sql.NullString `db:"opponent_id"`
+	Result     sql.NullString `db:"result"`
+	PlayedAt   sql.NullTime   `db:"played_at"`
+	MatchType  string         `db:"match_type"`
+}
+
+func (r matchRow) toPB() *pb.Match {
+	return &pb.Match{
+		Id:         r.ID,
+		ClientId:   r.ClientID,
+		Score:      r.Score,
+		CreatedAt:  r.CreatedAt.Time.UnixNano(),
+		OpponentId: r.OpponentID.String,
+		Result:     pb.MatchResult(pb.MatchResult_value[r.Result.String]),
+		PlayedAt:   r.PlayedAt.Time.UnixNano(),
+		MatchType:  pb.MatchType(pb.MatchType_value[r.MatchType]),
+	}
+}
+
+// countsForScore tells if the matches of a type are added to clients.score
+func (s *Service) countsForScore(matchType string) bool {
+	return !s.config.ExcludePracticeScore || matchType != pb.MatchType_PRACTICE.String()
+}
+
+// scoringMatches restricts a query on client_matches (aliased as table) to the matches added to clients.score,
+// returning nil when all of them are
+func (s *Service) scoringMatches(table string) sq.Sqlizer {
+	if !s.config.ExcludePracticeScore {
+		return nil
+	}
+	return sq.NotEq{table + "match_type": pb.MatchType_PRACTICE.String()}
+}
+
+// matchTypesFilter filters the matches by type, returning nil when types is empty
+func matchTypesFilter(column string, types []pb.MatchType) (sq.Sqlizer, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if _, ok := pb.MatchType_name[int32(t)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid match type %d", t)
+		}
+		names = append(names, t.String())
+	}
+	return sq.Eq{column: names}, nil
+}
+
+// GetMatch returns a single match by its id
+func (s *Service) GetMatch(ctx context.Context, req *pb.GetMatchRequest) (*pb.GetMatchResponse, error) {
+	q, args, err := sq.Select(matchColumns...).From("client_matches").Where(sq.Eq{"id": req.Id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	row := matchRow{}
+	if err := s.db.GetContext(ctx, &row, q, args...); err != nil {
+		return nil, notFoundOr(err, fmt.Sprintf("match %d not found", req.Id))
+	}
+	return &pb.GetMatchResponse{Match: row.toPB()}, nil
+}
+
+// UpdateMatch corrects the score of a match, adding the difference to the client score in the same transaction.
+// The match row is locked, so concurrent updates of the same match apply their differences one after the other.
+// The score is bounded and floored as the one of NewMatch, the floored score being the one recorded.
+func (s *Service) UpdateMatch(ctx context.Context, req *pb.UpdateMatchRequest) (*pb.UpdateMatchResponse, error) {
+	if err := s.checkMatchScore("score", req.Score); err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q, args, err := sq.Select(matchColumns...).From("client_matches").Where(sq.Eq{"id": req.Id}).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	match := matchRow{}
+	if err := tx.GetContext(ctx, &match, q, args...); err != nil {
+		return nil, notFoundOr(err, fmt.Sprintf("match %d not found", req.Id))
+	}
+	delta := req.Score - match.Score
+	counts := s.countsForScore(match.MatchType)
+	if counts && delta < 0 && s.config.ScoreFloorMode != ScoreFloorOff {
+		var score sql.NullInt64
+		if err := tx.GetContext(ctx, &score, "SELECT score FROM clients WHERE id = ? FOR UPDATE", match.ClientID); err != nil {
+			return nil, err
+		}
+		if delta, err = s.floorScore(match.ClientID, score.Int64, delta); err != nil {
+			return nil, err
+		}
+	}
+	score := match.Score + delta
+	if _, err := tx.ExecContext(ctx, "UPDATE client_matches SET score = ? WHERE id = ?", score, req.Id); err != nil {
+		return nil, err
+	}
+	if delta != 0 && counts {
+		if _, err := tx.ExecContext(ctx, "UPDATE clients SET score = score + ?, updated_at = NOW() WHERE id = ?",
+			delta, match.ClientID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	match.Score = score
+	return &pb.UpdateMatchResponse{Match: match.toPB()}, nil
+}
+
+// DeleteMatch removes a match and subtracts its score from the client, in a single transaction.
+// The match row is locked, so concurrent deletes of the same match roll the score back only once.
+func (s *Service) DeleteMatch(ctx context.Context, req *pb.DeleteMatchRequest) (*pb.DeleteMatchResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	match := matchRow{}
+	if err := tx.GetContext(ctx, &match, "SELECT client_id, score, match_type FROM client_matches WHERE id = ? FOR UPDATE", req.Id); err != nil {
+		return nil, notFoundOr(err, fmt.Sprintf("match %d not found", req.Id))
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM client_matches WHERE id = ?", req.Id); err != nil {
+		return nil, err
+	}
+	// soft deleted clients are adjusted too, so a restore brings back the right score
+	if s.countsForScore(match.MatchType) {
+		if _, err := tx.ExecContext(ctx, "UPDATE clients SET score = score - ?, updated_at = NOW() WHERE id = ?",
+			match.Score, match.ClientID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteMatchResponse{}, nil
+}
+
+// UndoLastMatch removes the most recent match of a client and subtracts its score from the client.
+// The client row is locked first, so concurrent undos (and new matches) of the client queue up and
+// each undo removes a different match.
+func (s *Service) UndoLastMatch(ctx context.Context, req *pb.UndoLastMatchRequest) (*pb.UndoLastMatchResponse, error) {
+	q, args, err := sq.Select(matchColumns...).From("client_matches").
+		Where(sq.Eq{"client_id": req.ClientId}).
+		OrderBy("id DESC").Limit(1).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var clientID string
+	if err := tx.GetContext(ctx, &clientID, "SELECT id FROM clients WHERE id = ? FOR UPDATE", req.ClientId); err != nil {
+		return nil, notFoundOr(err, fmt.Sprintf("client %s not found", req.ClientId))
+	}
+	match := matchRow{}
+	if err := tx.GetContext(ctx, &match, q, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "client %s has no matches", req.ClientId)
+		}
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM client_matches WHERE id = ?", match.ID); err != nil {
+		return nil, err
+	}
+	if s.countsForScore(match.MatchType) {
+		if _, err := tx.ExecContext(ctx, "UPDATE clients SET score = score - ?, updated_at = NOW() WHERE id = ?",
+			match.Score, match.ClientID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.UndoLastMatchResponse{Match: match.toPB()}, nil
+}
+
+// matchPageTokenPrefix starts the decoded match page tokens, so random strings aren't taken for one
+const matchPageTokenPrefix = "match:"
+
+// encodeMatchPageToken returns the page token resuming a listing after the match lastID
+func encodeMatchPageToken(lastID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(matchPageTokenPrefix + strconv.FormatInt(lastID, 10)))
+}
+
+// decodeMatchPageToken returns the id of the last match of the previous page
+func decodeMatchPageToken(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), matchPageTokenPrefix) {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(string(raw), matchPageTokenPrefix), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return id, nil
+}
+
+// ListMatches returns a page of the matches of a client, newest first.
+// Pages are read either by offset or, with req.PageToken, from where the previous page stopped; tokens
+// stay valid while new matches are recorded, and don't make the database skip over the previous pages.
+func (s *Service) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset can't be negative")
+	}
+	if req.PageToken != "" && req.Offset != 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_token and offset can't be used together")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultMatchesLimit
+	}
+	if limit > maxMatchesLimit {
+		limit = maxMatchesLimit
+	}
+
+	lq, err := matchesQuery(req.ClientId, req.CreatedAfter, req.CreatedBefore, req.MatchTypes)
+	if err != nil {
+		return nil, err
+	}
+	if req.PlayedFrom != nil && req.PlayedTo != nil && req.PlayedFrom.Value > req.PlayedTo.Value {
+		return nil, status.Error(codes.InvalidArgument, "played_from is after played_to")
+	}
+	if req.PlayedFrom != nil {
+		lq = lq.Where((&pb.Int64Comp{Value: req.PlayedFrom.Value, Op: ">="}).TimePred("played_at"))
+	}
+	if req.PlayedTo != nil {
+		lq = lq.Where((&pb.Int64Comp{Value: req.PlayedTo.Value, Op: "<"}).TimePred("played_at"))
+	}
+	if req.PageToken != "" {
+		lastID, err := decodeMatchPageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		lq = lq.Where(sq.Lt{"id": lastID})
+	}
+	q, args, err := lq.OrderBy("id DESC").Limit(uint64(limit)).Offset(uint64(req.Offset)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]matchRow, 0)
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.ListMatchesResponse{
+		Matches: make([]*pb.Match, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Matches = append(resp.Matches, row.toPB())
+	}
+	if int64(len(rows)) == limit {
+		resp.NextPageToken = encodeMatchPageToken(rows[len(rows)-1].ID)
+	}
+	return resp, nil
+}
+
+// GetTopMatches returns the highest scoring matches of a client. Ties go to the oldest match,
+// so repeated calls return the same list.
+func (s *Service) GetTopMatches(ctx context.Context, req *pb.GetTopMatchesRequest) (*pb.GetTopMatchesResponse, error) {
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit can't be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTopMatchesLimit
+	}
+	if limit > maxTopMatchesLimit {
+		limit = maxTopMatchesLimit
+	}
+	q, args, err := sq.Select(matchColumns...).From("client_matches").Where(sq.Eq{"client_id": req.ClientId}).
+		OrderBy("score DESC", "id ASC").Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]matchRow, 0)
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.GetTopMatchesResponse{Matches: make([]*pb.Match, 0, len(rows))}
+	for _, row := range rows {
+		resp.Matches = append(resp.Matches, row.toPB())
+	}
+	return resp, nil
+}
+
+// matchesQuery selects the matches of a client created in [createdAfter, createdBefore) (unixnano, 0 for
+// no bound) of the given types (all of them if empty)
+func matchesQuery(clientID string, createdAfter, createdBefore int64, types []pb.MatchType) (sq.SelectBuilder, error) {
+	lq := sq.Select(matchColumns...).From("client_matches").Where(sq.Eq{"client_id": clientID})
+	if createdAfter != 0 {
+		lq = lq.Where(sq.GtOrEq{"created_at": time.Unix(0, createdAfter)})
+	}
+	if createdBefore != 0 {
+		lq = lq.Where(sq.Lt{"created_at": time.Unix(0, createdBefore)})
+	}
+	pred, err := matchTypesFilter("match_type", types)
+	if err != nil {
+		return lq, err
+	}
+	return lq.Where(pred), nil
+}
+
+// streamMatchesBatchSize is how many matches StreamMatches reads per query
+const streamMatchesBatchSize = 500
+
+// StreamMatches sends all the matches of a client, oldest first. They are read in batches by id,
+// so histories of any size are streamed without holding them in memory, and a client hanging up
+// stops the reads at the next batch.
+func (s *Service) StreamMatches(req *pb.StreamMatchesRequest, stream pb.ClientsService_StreamMatchesServer) error {
+	if req.ClientId == "" {
+		return status.Error(codes.InvalidArgument, "client_id is required")
+	}
+	lq, err := matchesQuery(req.ClientId, req.CreatedAfter, req.CreatedBefore, req.MatchTypes)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	var lastID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		q, args, err := lq.Where(sq.Gt{"id": lastID}).OrderBy("id").Limit(streamMatchesBatchSize).ToSql()
+		if err != nil {
+			return err
+		}
+		rows := make([]matchRow, 0, streamMatchesBatchSize)
+		if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := stream.Send(row.toPB()); err != nil {
+				return err
+			}
+		}
+		if len(rows) < streamMatchesBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// headToHeadSQL aggregates the matches between two clients. A match is recorded from the point of view
+// of client_id, so a WIN of b is a loss of a and vice versa.
+const headToHeadSQL = `SELECT
+	COUNT(*) AS matches,
+	COALESCE(SUM((client_id = ? AND result = 'WIN') OR (client_id = ? AND result = 'LOSS')), 0) AS a_wins,
+	COALESCE(SUM((client_id = ? AND result = 'WIN') OR (client_id = ? AND result = 'LOSS')), 0) AS b_wins,
+	COALESCE(SUM(result = 'DRAW'), 0) AS draws,
+	COALESCE(SUM(IF(client_id = ?, score, 0)), 0) AS a_score,
+	COALESCE(SUM(IF(client_id = ?, score, 0)), 0) AS b_score
+FROM client_matches
+WHERE ((client_id = ? AND opponent_id = ?) OR (client_id = ? AND opponent_id = ?))`
+
+// GetHeadToHead returns the statistics of the matches between two clients, in either direction
+func (s *Service) GetHeadToHead(ctx context.Context, req *pb.GetHeadToHeadRequest) (*pb.GetHeadToHeadResponse, error) {
+	a, b := req.ClientA, req.ClientB
+	if a == "" || b == "" {
+		return nil, status.Error(codes.InvalidArgument, "both client ids are required")
+	}
+	if a == b {
+		return nil, status.Error(codes.InvalidArgument, "a client has no matches against itself")
+	}
+	// deleted clients still have their matches, so they count as existing here
+	var found []string
+	if err := s.db.SelectContext(ctx, &found, "SELECT id FROM clients WHERE id IN (?, ?)", a, b); err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+	switch {
+	case !exists[a] && !exists[b]:
+		return nil, status.Errorf(codes.NotFound, "clients %s and %s not found", a, b)
+	case !exists[a]:
+		return nil, status.Errorf(codes.NotFound, "client %s not found", a)
+	case !exists[b]:
+		return nil, status.Errorf(codes.NotFound, "client %s not found", b)
+	}
+	q, args := headToHeadSQL, []interface{}{a, b, b, a, a, b, a, b, b, a}
+	if pred, err := matchTypesFilter("match_type", req.MatchTypes); err != nil {
+		return nil, err
+	} else if pred != nil {
+		predSQL, predArgs, err := pred.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		q, args = q+" AND "+predSQL, append(args, predArgs...)
+	}
+
+	row := struct {
+		Matches int64 `db:"matches"`
+		AWins   int64 `db:"a_wins"`
+		BWins   int64 `db:"b_wins"`
+		Draws   int64 `db:"draws"`
+		AScore  int64 `db:"a_score"`
+		BScore  int64 `db:"b_score"`
+	}{}
+	if err := s.db.GetContext(ctx, &row, q, args...); err != nil {
+		return nil, err
+	}
+	return &pb.GetHeadToHeadResponse{
+		Matches:      row.Matches,
+		ClientAWins:  row.AWins,
+		ClientBWins:  row.BWins,
+		Draws:        row.Draws,
+		ClientAScore: row.AScore,
+		ClientBScore: row.BScore,
+	}, nil
+}
+
+type clientStatsRow struct {
+	ClientID     string       `db:"client_id"`
+	MatchCount   int64        `db:"match_count"`
+	TotalScore   int64        `db:"total_score"`
+	AverageScore float64      `db:"average_score"`
+	BestScore    int64        `db:"best_score"`
+	WorstScore   int64        `db:"worst_score"`
+	FirstMatchAt sql.NullTime `db:"first_match_at"`
+	LastMatchAt  sql.NullTime `db:"last_match_at"`
+}
+
+func (r clientStatsRow) toPB() *pb.ClientStats {
+	stats := &pb.ClientStats{
+		MatchCount:   r.MatchCount,
+		TotalScore:   r.TotalScore,
+		AverageScore: r.AverageScore,
+		BestScore:    r.BestScore,
+		WorstScore:   r.WorstScore,
+	}
+	if r.FirstMatchAt.Valid {
+		stats.FirstMatchAt = r.FirstMatchAt.Time.UnixNano()
+	}
+	if r.LastMatchAt.Valid {
+		stats.LastMatchAt = r.LastMatchAt.Time.UnixNano()
+	}
+	return stats
+}
+
+// clientsStats aggregates the matches (of the given types, or all of them, and of the given season,
+// if any) of each of the given clients in a single query. The left join keeps the clients without
+// matches, with zeroed stats.
+func (s *Service) clientsStats(ctx context.Context, ids []string, types []pb.MatchType, seasonID string) (map[string]*pb.ClientStats, error) {
+	if err := checkSeason(ctx, s.db, seasonID); err != nil {
+		return nil, err
+	}
+	join, joinArgs := "client_matches m ON m.client_id = c.id", []interface{}{}
+	if pred, err := matchTypesFilter("m.match_type", types); err != nil {
+		return nil, err
+	} else if pred != nil {
+		predSQL, predArgs, err := pred.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		join, joinArgs = join+" AND "+predSQL, predArgs
+	}
+	if seasonID != "" {
+		join, joinArgs = join+" AND m.season_id = ?", append(joinArgs, seasonID)
+	}
+	q, args, err := sq.Select(
+		"c.id AS client_id",
+		"COUNT(m.id) AS match_count",
+		"COALESCE(SUM(m.score), 0) AS total_score",
+		"COALESCE(AVG(m.score), 0) AS average_score",
+		"COALESCE(MAX(m.score), 0) AS best_score",
+		"COALESCE(MIN(m.score), 0) AS worst_score",
+		"MIN(m.played_at) AS first_match_at",
+		"MAX(m.played_at) AS last_match_at",
+	).From("clients c").
+		LeftJoin(join, joinArgs...).
+		Where(sq.Eq{"c.id": ids}).Where("c.deleted_at IS NULL").
+		GroupBy("c.id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []clientStatsRow{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]*pb.ClientStats, len(rows))
+	for _, row := range rows {
+		stats[row.ClientID] = row.toPB()
+	}
+	return stats, nil
+}
+
+// GetClientStats returns the match statistics of a client
+func (s *Service) GetClientStats(ctx context.Context, req *pb.GetClientStatsRequest) (*pb.GetClientStatsResponse, error) {
+	stats, err := s.clientsStats(ctx, []string{req.ClientId}, req.MatchTypes, req.SeasonId)
+	if err != nil {
+		return nil, err
+	}
+	if stats[req.ClientId] == nil {
+		return nil, status.Errorf(codes.NotFound, "client %s not found", req.ClientId)
+	}
+	return &pb.GetClientStatsResponse{Stats: stats[req.ClientId]}, nil
+}
+
+// GetClientsStats returns the match statistics of several clients, keyed by client id
+func (s *Service) GetClientsStats(ctx context.Context, req *pb.GetClientsStatsRequest) (*pb.GetClientsStatsResponse, error) {
+	if len(req.ClientIds) == 0 {
+		return &pb.GetClientsStatsResponse{Stats: map[string]*pb.ClientStats{}}, nil
+	}
+	stats, err := s.clientsStats(ctx, req.ClientIds, req.MatchTypes, req.SeasonId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetClientsStatsResponse{Stats: stats}, nil
+}
+
+// NewMatches records a batch of matches in a single transaction, with one insert per match (so each
+// id is the one reported for its row) and one update for the scores of all the clients.
+// Either every match is recorded or none is.
+func (s *Service) NewMatches(ctx context.Context, req *pb.NewMatchesRequest) (*pb.NewMatchesResponse, error) {
+	if len(req.Matches) == 0 {
+		return &pb.NewMatchesResponse{Ids: []int64{}}, nil
+	}
+	// clients in the order they first appear
+	clientIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	lookup := make([]interface{}, 0, len(req.Matches))
+	for i, m := range req.Matches {
+		if m.OpponentId != "" && m.OpponentId == m.ClientId {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: a client can't be its own opponent", i)
+		}
+		if _, ok := pb.MatchResult_name[int32(m.Result)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: invalid result %d", i, m.Result)
+		}
+		if _, ok := pb.MatchType_name[int32(m.MatchType)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: invalid match type %d", i, m.MatchType)
+		}
+		if m.PlayedAt > time.Now().UnixNano() {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: played_at is in the future", i)
+		}
+		if m.IdempotencyKey != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: idempotency_key is only supported by NewMatch", i)
+		}
+		if err := s.checkMatchScore(fmt.Sprintf("matches[%d].score", i), m.Score); err != nil {
+			return nil, err
+		}
+		if !seen[m.ClientId] {
+			seen[m.ClientId] = true
+			clientIDs = append(clientIDs, m.ClientId)
+			lookup = append(lookup, m.ClientId)
+		}
+		if m.OpponentId != "" {
+			lookup = append(lookup, m.OpponentId)
+		}
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q, args, err := sq.Select("id", "status", "score").From("clients").
+		Where(sq.Eq{"id": lookup}).Where("deleted_at IS NULL").Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		ID     string        `db:"id"`
+		Status string        `db:"status"`
+		Score  sql.NullInt64 `db:"score"`
+	}{}
+	if err := tx.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]string, len(rows))
+	scores := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		statuses[row.ID] = row.Status
+		scores[row.ID] = row.Score.Int64
+	}
+	// the matches are applied in order, so the score floor sees the earlier matches of the batch
+	totals := make(map[string]int64, len(clientIDs))
+	values := make([][]interface{}, 0, len(req.Matches))
+	for i, m := range req.Matches {
+		st, ok := statuses[m.ClientId]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: client %s not found", i, m.ClientId)
+		}
+		if st != pb.ClientStatus_ACTIVE.String() {
+			return nil, status.Errorf(codes.FailedPrecondition, "matches[%d]: client %s is %s", i, m.ClientId, st)
+		}
+		if _, ok := statuses[m.OpponentId]; m.OpponentId != "" && !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "matches[%d]: opponent %s not found", i, m.OpponentId)
+		}
+		score := m.Score
+		if s.countsForScore(m.MatchType.String()) {
+			if score, err = s.floorScore(m.ClientId, scores[m.ClientId], m.Score); err != nil {
+				return nil, status.Errorf(status.Code(err), "matches[%d]: %s", i, status.Convert(err).Message())
+			}
+			scores[m.ClientId] += score
+			totals[m.ClientId] += score
+		}
+		var opponentID, result interface{}
+		var playedAt interface{} = sq.Expr("NOW()")
+		if m.OpponentId != "" {
+			opponentID = m.OpponentId
+		}
+		if m.Result != pb.MatchResult_NO_RESULT {
+			result = m.Result.String()
+		}
+		if m.PlayedAt != 0 {
+			playedAt = time.Unix(0, m.PlayedAt)
+		}
+		values = append(values, []interface{}{m.ClientId, score, opponentID, result, playedAt, m.MatchType.String(), currentSeason})
+	}
+	resp := &pb.NewMatchesResponse{Ids: make([]int64, 0, len(values))}
+	for _, v := range values {
+		insertSQL, insertArgs, err := sq.Insert("client_matches").
+			Columns("client_id", "score", "opponent_id", "result", "played_at", "match_type", "season_id").
+			Values(v...).ToSql()
+		if err != nil {
+			return nil, err
+		}
+		result, err := tx.ExecContext(ctx, insertSQL, insertArgs...)
+		if err != nil {
+			return nil, err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		resp.Ids = append(resp.Ids, id)
+	}
+
+	// only the clients with matches counting for the score
+	cases := make([]string, 0, len(clientIDs))
+	args = make([]interface{}, 0, 3*len(clientIDs))
+	ids := make([]interface{}, 0, len(clientIDs))
+	for _, id := range clientIDs {
+		if total, ok := totals[id]; ok {
+			cases = append(cases, "WHEN ? THEN ?")
+			args = append(args, id, total)
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	q, args, err = sq.Update("clients").
+		Set("score", sq.Expr("score + CASE id "+strings.Join(cases, " ")+" END", args...)).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": ids}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// scoreHistoryBuckets are the SQL expressions truncating played_at to the start of each bucket
+var scoreHistoryBuckets = map[pb.ScoreHistoryBucket]string{
+	pb.ScoreHistoryBucket_DAY:  "DATE(played_at)",
+	pb.ScoreHistoryBucket_WEEK: "DATE(played_at - INTERVAL WEEKDAY(played_at) DAY)",
+}
+
+// bucketStart truncates t to the start of its bucket, like the scoreHistoryBuckets expressions
+func bucketStart(t time.Time, bucket pb.ScoreHistoryBucket) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket == pb.ScoreHistoryBucket_WEEK {
+		// time.Weekday starts on sunday, WEEKDAY() on monday
+		day = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
+
+func bucketNext(t time.Time, bucket pb.ScoreHistoryBucket) time.Time {
+	if bucket == pb.ScoreHistoryBucket_WEEK {
+		return t.AddDate(0, 0, 7)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// GetScoreHistory returns the cumulative score of a client at the end of each day or week in [from, to).
+// The database sums the matches of each bucket; the running sum is done here, so buckets
+// without matches keep the previous score. The sum starts from the client score less the matches
+// played since from, so the initial score of the client counts and the series ends at clients.score.
+func (s *Service) GetScoreHistory(ctx context.Context, req *pb.GetScoreHistoryRequest) (*pb.GetScoreHistoryResponse, error) {
+	bucketSQL, ok := scoreHistoryBuckets[req.Bucket]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket %d", req.Bucket)
+	}
+	to := time.Now()
+	if req.To != 0 {
+		to = time.Unix(0, req.To)
+	}
+	from := time.Unix(0, req.From)
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	buckets := make([]time.Time, 0)
+	for b := bucketStart(from, req.Bucket); b.Before(to); b = bucketNext(b, req.Bucket) {
+		if len(buckets) == maxScoreHistoryBuckets {
+			return nil, status.Errorf(codes.InvalidArgument, "the range has more than %d buckets", maxScoreHistoryBuckets)
+		}
+		buckets = append(buckets, b)
+	}
+
+	var current sql.NullInt64
+	if err := s.db.GetContext(ctx, &current, "SELECT score FROM clients WHERE id = ? AND deleted_at IS NULL", req.ClientId); err != nil {
+		return nil, notFoundOr(err, "client "+req.ClientId+" not found")
+	}
+	// only the matches counting for clients.score
+	q, args, err := sq.Select("COALESCE(SUM(score), 0)").From("client_matches").
+		Where(sq.Eq{"client_id": req.ClientId}).Where("played_at >= ?", from).
+		Where(s.scoringMatches("")).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var since int64
+	if err := s.db.GetContext(ctx, &since, q, args...); err != nil {
+		return nil, err
+	}
+	score := current.Int64 - since
+	q, args, err = sq.Select(bucketSQL+" AS bucket", "SUM(score) AS delta").From("client_matches").
+		Where(sq.Eq{"client_id": req.ClientId}).
+		Where("played_at >= ? AND played_at < ?", from, to).
+		Where(s.scoringMatches("")).
+		GroupBy("bucket").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		Bucket time.Time `db:"bucket"`
+		Delta  int64     `db:"delta"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	deltas := make(map[time.Time]int64, len(rows))
+	for _, row := range rows {
+		deltas[bucketStart(row.Bucket, req.Bucket)] += row.Delta
+	}
+
+	resp := &pb.GetScoreHistoryResponse{Points: make([]*pb.ScorePoint, 0, len(buckets))}
+	for _, b := range buckets {
+		score += deltas[b]
+		resp.Points = append(resp.Points, &pb.ScorePoint{
+			BucketStart: b.UnixNano(),
+			Delta:       deltas[b],
+			Score:       score,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,965 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetMatch(t *testing.T) {
+	service, mock := newTestService(t)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, client_id, score, created_at, opponent_id, result, played_at, match_type FROM client_matches WHERE id = ?")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(7, "MOCKID", 5, now, "RIVAL", "DRAW", now.Add(-time.Hour), "PRACTICE"))
+	resp, err := service.GetMatch(context.Background(), &pb.GetMatchRequest{Id: 7})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.Match{
+		Id:         7,
+		ClientId:   "MOCKID",
+		Score:      5,
+		CreatedAt:  now.UnixNano(),
+		OpponentId: "RIVAL",
+		Result:     pb.MatchResult_DRAW,
+		PlayedAt:   now.Add(-time.Hour).UnixNano(),
+		MatchType:  pb.MatchType_PRACTICE,
+	}, resp.Match)
+
+	mock.ExpectQuery("SELECT (.+) FROM client_matches").WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows(matchColumns))
+	_, err = service.GetMatch(context.Background(), &pb.GetMatchRequest{Id: 8})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMatch(t *testing.T) {
+	service, mock := newTestService(t)
+	selectSQL := regexp.QuoteMeta("SELECT id, client_id, score, created_at, opponent_id, result, played_at, match_type FROM client_matches WHERE id = ? FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs(int64(9)).WillReturnRows(sqlmock.NewRows(matchColumns))
+	mock.ExpectRollback()
+	_, err := service.UpdateMatch(context.Background(), &pb.UpdateMatchRequest{Id: 9, Score: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMatchConcurrent(t *testing.T) {
+	db := newMatchesDB()
+	db.clients["MOCKID"] = &fakeClient{score: 5}
+	db.matches[7] = &fakeMatch{clientID: "MOCKID", score: 5, matchType: "RANKED"}
+	service := db.service()
+
+	// two concurrent corrections from 5 to 9: the second one waits for the lock and sees 9 already,
+	// so the client gets the difference only once
+	errs := make([]error, 2)
+	concurrently(len(errs), func(i int) {
+		var resp *pb.UpdateMatchResponse
+		if resp, errs[i] = service.UpdateMatch(context.Background(), &pb.UpdateMatchRequest{Id: 7, Score: 9}); errs[i] == nil {
+			assert.Equal(t, int64(9), resp.Match.Score)
+		}
+	})
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.Equal(t, int64(9), db.matches[7].score)
+	assert.Equal(t, int64(9), db.clients["MOCKID"].score)
+}
+
+func TestUpdateMatchScoreLimits(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.MinMatchScore, service.config.MaxMatchScore = -10, 10
+	service.config.ScoreFloorMode = ScoreFloorClamp
+	selectSQL := regexp.QuoteMeta("SELECT id, client_id, score, created_at, opponent_id, result, played_at, match_type FROM client_matches WHERE id = ? FOR UPDATE")
+	clientSQL := regexp.QuoteMeta("SELECT score FROM clients WHERE id = ? FOR UPDATE")
+
+	for _, score := range []int64{-11, 11} {
+		_, err := service.UpdateMatch(context.Background(), &pb.UpdateMatchRequest{Id: 7, Score: score})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), score)
+		assert.Equal(t, "score must be between -10 and 10", status.Convert(err).Message())
+	}
+
+	// a client at 3 with a match of 5 corrected to -10 only goes down to the floor (0)
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(7, "MOCKID", 5, time.Now(), nil, nil, time.Now(), "RANKED"))
+	mock.ExpectQuery(clientSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"score"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE client_matches SET score = ? WHERE id = ?")).
+		WithArgs(int64(2), int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = score + ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(-3), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.UpdateMatch(context.Background(), &pb.UpdateMatchRequest{Id: 7, Score: -10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Match.Score)
+
+	service.config.ScoreFloorMode = ScoreFloorReject
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(7, "MOCKID", 5, time.Now(), nil, nil, time.Now(), "RANKED"))
+	mock.ExpectQuery(clientSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"score"}).AddRow(3))
+	mock.ExpectRollback()
+	_, err = service.UpdateMatch(context.Background(), &pb.UpdateMatchRequest{Id: 7, Score: -10})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMatch(t *testing.T) {
+	service, mock := newTestService(t)
+	selectSQL := regexp.QuoteMeta("SELECT client_id, score, match_type FROM client_matches WHERE id = ? FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs(int64(9)).WillReturnRows(sqlmock.NewRows([]string{"client_id", "score"}))
+	mock.ExpectRollback()
+	_, err := service.DeleteMatch(context.Background(), &pb.DeleteMatchRequest{Id: 9})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMatchConcurrent(t *testing.T) {
+	db := newMatchesDB()
+	db.clients["MOCKID"] = &fakeClient{score: 5}
+	db.matches[7] = &fakeMatch{clientID: "MOCKID", score: 5, matchType: "RANKED"}
+	service := db.service()
+
+	// two concurrent deletes of the same match: the row lock lets only one of them see it,
+	// so the score is subtracted once
+	errs := make([]error, 2)
+	concurrently(len(errs), func(i int) {
+		_, errs[i] = service.DeleteMatch(context.Background(), &pb.DeleteMatchRequest{Id: 7})
+	})
+	assert.ElementsMatch(t, []codes.Code{codes.OK, codes.NotFound}, []codes.Code{status.Code(errs[0]), status.Code(errs[1])})
+	assert.Empty(t, db.matches)
+	assert.Equal(t, int64(0), db.clients["MOCKID"].score)
+}
+
+func TestListMatches(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, client_id, score, created_at, opponent_id, result, played_at, match_type FROM client_matches " +
+		"WHERE client_id = ? ORDER BY id DESC LIMIT 100 OFFSET 0")).
+		WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(matchColumns).
+			AddRow(2, "MOCKID", -3, now, nil, nil, now, "RANKED").
+			AddRow(1, "MOCKID", 10, now.Add(-time.Hour), "RIVAL", "LOSS", now.Add(-time.Hour), "RANKED"))
+	resp, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+	require.Len(t, resp.Matches, 2)
+	assert.Equal(t, &pb.Match{Id: 2, ClientId: "MOCKID", Score: -3, CreatedAt: now.UnixNano(), PlayedAt: now.UnixNano()}, resp.Matches[0])
+	assert.Equal(t, pb.MatchResult_LOSS, resp.Matches[1].Result)
+
+	after := time.Unix(0, now.Add(-24*time.Hour).UnixNano())
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, client_id, score, created_at, opponent_id, result, played_at, match_type FROM client_matches "+
+		"WHERE client_id = ? AND created_at >= ? ORDER BY id DESC LIMIT 1000 OFFSET 20")).
+		WithArgs("EMPTY", after).
+		WillReturnRows(sqlmock.NewRows(matchColumns))
+	resp, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{
+		ClientId:     "EMPTY",
+		CreatedAfter: after.UnixNano(),
+		Limit:        5000,
+		Offset:       20,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Matches)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetHeadToHead(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"matches", "a_wins", "b_wins", "draws", "a_score", "b_score"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE id IN (?, ?)")).WithArgs("ALICE", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ALICE").AddRow("BOB"))
+	mock.ExpectQuery(regexp.QuoteMeta(headToHeadSQL)).
+		WithArgs("ALICE", "BOB", "BOB", "ALICE", "ALICE", "BOB", "ALICE", "BOB", "BOB", "ALICE").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(5, 2, 1, 2, 40, 25))
+	resp, err := service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "ALICE", ClientB: "BOB"})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.GetHeadToHeadResponse{
+		Matches:      5,
+		ClientAWins:  2,
+		ClientBWins:  1,
+		Draws:        2,
+		ClientAScore: 40,
+		ClientBScore: 25,
+	}, resp)
+
+	// never played each other
+	mock.ExpectQuery("SELECT id FROM clients").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ALICE").AddRow("CAROL"))
+	mock.ExpectQuery("SELECT(.+)FROM client_matches").WillReturnRows(sqlmock.NewRows(cols).AddRow(0, 0, 0, 0, 0, 0))
+	resp, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "ALICE", ClientB: "CAROL"})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.GetHeadToHeadResponse{}, resp)
+
+	// only the missing clients are named
+	mock.ExpectQuery("SELECT id FROM clients").WithArgs("ALICE", "MISSING").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ALICE"))
+	_, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "ALICE", ClientB: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "client MISSING not found", status.Convert(err).Message())
+	mock.ExpectQuery("SELECT id FROM clients").WithArgs("MISSING", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("BOB"))
+	_, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "MISSING", ClientB: "BOB"})
+	assert.Equal(t, "client MISSING not found", status.Convert(err).Message())
+	mock.ExpectQuery("SELECT id FROM clients").WithArgs("MISSING", "GONE").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "MISSING", ClientB: "GONE"})
+	assert.Equal(t, "clients MISSING and GONE not found", status.Convert(err).Message())
+
+	_, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "ALICE", ClientB: "ALICE"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientStats(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"client_id", "match_count", "total_score", "average_score", "best_score", "worst_score",
+		"first_match_at", "last_match_at"}
+	statsSQL := regexp.QuoteMeta("SELECT c.id AS client_id, COUNT(m.id) AS match_count, COALESCE(SUM(m.score), 0) AS total_score, " +
+		"COALESCE(AVG(m.score), 0) AS average_score, COALESCE(MAX(m.score), 0) AS best_score, " +
+		"COALESCE(MIN(m.score), 0) AS worst_score, MIN(m.played_at) AS first_match_at, MAX(m.played_at) AS last_match_at " +
+		"FROM clients c LEFT JOIN client_matches m ON m.client_id = c.id WHERE c.id IN (?) AND c.deleted_at IS NULL GROUP BY c.id")
+
+	first, last := time.Now().Add(-48*time.Hour), time.Now()
+	mock.ExpectQuery(statsSQL).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("MOCKID", 3, 12, "4.0000", 10, -2, first, last))
+	resp, err := service.GetClientStats(context.Background(), &pb.GetClientStatsRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.ClientStats{
+		MatchCount:   3,
+		TotalScore:   12,
+		AverageScore: 4,
+		BestScore:    10,
+		WorstScore:   -2,
+		FirstMatchAt: first.UnixNano(),
+		LastMatchAt:  last.UnixNano(),
+	}, resp.Stats)
+
+	// no matches: the left join still returns the client
+	mock.ExpectQuery(statsSQL).WithArgs("NEWBIE").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("NEWBIE", 0, 0, "0.0000", 0, 0, nil, nil))
+	resp, err = service.GetClientStats(context.Background(), &pb.GetClientStatsRequest{ClientId: "NEWBIE"})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.ClientStats{}, resp.Stats)
+
+	mock.ExpectQuery(statsSQL).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows(cols))
+	_, err = service.GetClientStats(context.Background(), &pb.GetClientStatsRequest{ClientId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsStats(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"client_id", "match_count", "total_score", "average_score", "best_score", "worst_score",
+		"first_match_at", "last_match_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE c.id IN (?,?,?) AND c.deleted_at IS NULL GROUP BY c.id")).
+		WithArgs("A", "B", "MISSING").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("A", 2, 7, "3.5000", 5, 2, time.Now(), time.Now()).
+			AddRow("B", 0, 0, "0.0000", 0, 0, nil, nil))
+	resp, err := service.GetClientsStats(context.Background(), &pb.GetClientsStatsRequest{ClientIds: []string{"A", "B", "MISSING"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Stats, 2)
+	assert.Equal(t, 3.5, resp.Stats["A"].AverageScore)
+	assert.Equal(t, &pb.ClientStats{}, resp.Stats["B"])
+
+	resp, err = service.GetClientsStats(context.Background(), &pb.GetClientsStatsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewMatches(t *testing.T) {
+	service, mock := newTestService(t)
+	playedAt := time.Unix(0, time.Now().Add(-time.Hour).UnixNano())
+	matches := []*pb.NewMatchRequest{
+		{ClientId: "ALICE", Score: 5},
+		{ClientId: "BOB", Score: 2, OpponentId: "ALICE", Result: pb.MatchResult_LOSS, PlayedAt: playedAt.UnixNano()},
+		{ClientId: "ALICE", Score: -1},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, status, score FROM clients WHERE id IN (?,?,?) AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("ALICE", "BOB", "ALICE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("ALICE", "ACTIVE").AddRow("BOB", "ACTIVE"))
+	// one insert per match: the ids aren't consecutive when other inserts interleave
+	insertSQL := regexp.QuoteMeta("INSERT INTO client_matches (client_id,score,opponent_id,result,played_at,match_type,season_id) VALUES ")
+	season := regexp.QuoteMeta("(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1)")
+	mock.ExpectExec(insertSQL+regexp.QuoteMeta("(?,?,?,?,NOW(),?,")+season+"\\)$").
+		WithArgs("ALICE", int64(5), nil, nil, "RANKED").WillReturnResult(sqlmock.NewResult(20, 1))
+	mock.ExpectExec(insertSQL+regexp.QuoteMeta("(?,?,?,?,?,?,")+season+"\\)$").
+		WithArgs("BOB", int64(2), "ALICE", "LOSS", playedAt, "RANKED").WillReturnResult(sqlmock.NewResult(24, 1))
+	mock.ExpectExec(insertSQL+regexp.QuoteMeta("(?,?,?,?,NOW(),?,")+season+"\\)$").
+		WithArgs("ALICE", int64(-1), nil, nil, "RANKED").WillReturnResult(sqlmock.NewResult(25, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = score + CASE id WHEN ? THEN ? WHEN ? THEN ? END, "+
+		"updated_at = NOW() WHERE id IN (?,?)")).
+		WithArgs("ALICE", int64(4), "BOB", int64(2), "ALICE", "BOB").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	resp, err := service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: matches})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{20, 24, 25}, resp.Ids)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, status, score FROM clients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("ALICE", "ACTIVE"))
+	mock.ExpectRollback()
+	_, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: []*pb.NewMatchRequest{
+		{ClientId: "ALICE", Score: 1},
+		{ClientId: "MISSING", Score: 1},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "matches[1]")
+
+	_, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: []*pb.NewMatchRequest{
+		{ClientId: "ALICE", OpponentId: "ALICE"},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetScoreHistory(t *testing.T) {
+	service, mock := newTestService(t)
+	// monday to friday, in the location the service converts the unixnano arguments to
+	from := time.Unix(0, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+	to := time.Unix(0, time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC).UnixNano())
+	day := func(d int) time.Time { return time.Date(2021, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// the client was created with 100 points, and has the matches of the range only
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT score FROM clients WHERE id = ? AND deleted_at IS NULL")).
+		WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"score"}).AddRow(103))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(score), 0) FROM client_matches WHERE client_id = ? AND played_at >= ?")).
+		WithArgs("MOCKID", from).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DATE(played_at) AS bucket, SUM(score) AS delta FROM client_matches "+
+		"WHERE client_id = ? AND played_at >= ? AND played_at < ? GROUP BY bucket")).
+		WithArgs("MOCKID", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "delta"}).AddRow(day(1), 5).AddRow(day(3), -2))
+	resp, err := service.GetScoreHistory(context.Background(), &pb.GetScoreHistoryRequest{
+		ClientId: "MOCKID",
+		From:     from.UnixNano(),
+		To:       to.UnixNano(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.ScorePoint{
+		{BucketStart: day(1).UnixNano(), Delta: 5, Score: 105},
+		{BucketStart: day(2).UnixNano(), Delta: 0, Score: 105},
+		{BucketStart: day(3).UnixNano(), Delta: -2, Score: 103},
+		{BucketStart: day(4).UnixNano(), Delta: 0, Score: 103},
+	}, resp.Points)
+	assert.Equal(t, int64(103), resp.Points[len(resp.Points)-1].Score, "the series ends at the client score")
+
+	mock.ExpectQuery("SELECT score FROM clients").WillReturnRows(sqlmock.NewRows([]string{"score"}).AddRow(7))
+	mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(7))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DATE(played_at - INTERVAL WEEKDAY(played_at) DAY) AS bucket")).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "delta"}).AddRow(day(8), 7))
+	resp, err = service.GetScoreHistory(context.Background(), &pb.GetScoreHistoryRequest{
+		ClientId: "MOCKID",
+		From:     day(3).UnixNano(),
+		To:       day(16).UnixNano(),
+		Bucket:   pb.ScoreHistoryBucket_WEEK,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Points, 3)
+	assert.Equal(t, day(1).UnixNano(), resp.Points[0].BucketStart)
+	assert.Equal(t, []int64{0, 7, 7}, []int64{resp.Points[0].Score, resp.Points[1].Score, resp.Points[2].Score})
+
+	mock.ExpectQuery("SELECT score FROM clients").WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"score"}))
+	_, err = service.GetScoreHistory(context.Background(), &pb.GetScoreHistoryRequest{ClientId: "MISSING", From: from.UnixNano(), To: to.UnixNano()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = service.GetScoreHistory(context.Background(), &pb.GetScoreHistoryRequest{ClientId: "MOCKID", From: to.UnixNano(), To: from.UnixNano()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.GetScoreHistory(context.Background(), &pb.GetScoreHistoryRequest{ClientId: "MOCKID", From: 1, To: to.UnixNano()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPracticeMatches(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.ExcludePracticeScore = true
+
+	// recorded, but the client score is left alone
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "score"}).AddRow("ACTIVE", 10))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_matches (client_id,score,match_type,season_id) VALUES (?,?,?,(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1))")).
+		WithArgs("MOCKID", int64(5), "PRACTICE").WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectQuery("SELECT created_at FROM client_matches").WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+	mock.ExpectCommit()
+	_, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 5, MatchType: pb.MatchType_PRACTICE})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT client_id, score, match_type FROM client_matches").WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "score", "match_type"}).AddRow("MOCKID", 5, "PRACTICE"))
+	mock.ExpectExec("DELETE FROM client_matches").WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = service.DeleteMatch(context.Background(), &pb.DeleteMatchRequest{Id: 3})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.score, (c.score_baseline + (SELECT COALESCE(SUM(m.score), 0) FROM client_matches m "+
+		"WHERE m.client_id = c.id AND m.match_type <> ?)) AS new_score FROM clients c WHERE c.id = ?")).
+		WithArgs("PRACTICE", "MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "new_score"}).AddRow("MOCKID", 10, 10))
+	mock.ExpectCommit()
+	_, err = service.RecalculateScore(context.Background(), &pb.RecalculateScoreRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", MatchType: 42})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchTypeFilters(t *testing.T) {
+	service, mock := newTestService(t)
+	ranked := []pb.MatchType{pb.MatchType_RANKED}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM client_matches WHERE client_id = ? AND match_type IN (?) ORDER BY id DESC")).
+		WithArgs("MOCKID", "RANKED").WillReturnRows(sqlmock.NewRows(matchColumns))
+	_, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", MatchTypes: ranked})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN client_matches m ON m.client_id = c.id AND m.match_type IN (?) WHERE c.id IN (?)")).
+		WithArgs("RANKED", "MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "match_count"}).AddRow("MOCKID", 0))
+	_, err = service.GetClientStats(context.Background(), &pb.GetClientStatsRequest{ClientId: "MOCKID", MatchTypes: ranked})
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id FROM clients").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A").AddRow("B"))
+	mock.ExpectQuery(regexp.QuoteMeta(headToHeadSQL+" AND match_type IN (?)")).
+		WithArgs("A", "B", "B", "A", "A", "B", "A", "B", "B", "A", "RANKED").
+		WillReturnRows(sqlmock.NewRows([]string{"matches"}).AddRow(0))
+	_, err = service.GetHeadToHead(context.Background(), &pb.GetHeadToHeadRequest{ClientA: "A", ClientB: "B", MatchTypes: ranked})
+	require.NoError(t, err)
+
+	_, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", MatchTypes: []pb.MatchType{42}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// matchStream collects the matches sent by StreamMatches, calling onSend after each one
+type matchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	matches []*pb.Match
+	onSend  func()
+}
+
+func (s *matchStream) Context() context.Context { return s.ctx }
+
+func (s *matchStream) Send(m *pb.Match) error {
+	s.matches = append(s.matches, m)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return nil
+}
+
+func TestStreamMatches(t *testing.T) {
+	service, mock := newTestService(t)
+	batchSQL := func(rest string) string {
+		return regexp.QuoteMeta("SELECT " + strings.Join(matchColumns, ", ") + " FROM client_matches WHERE client_id = ? " + rest)
+	}
+
+	full := sqlmock.NewRows(matchColumns)
+	for i := 1; i <= streamMatchesBatchSize; i++ {
+		full.AddRow(i, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED")
+	}
+	mock.ExpectQuery(batchSQL(fmt.Sprintf("AND id > ? ORDER BY id LIMIT %d", streamMatchesBatchSize))).
+		WithArgs("MOCKID", int64(0)).WillReturnRows(full)
+	mock.ExpectQuery(batchSQL("AND id > ?")).WithArgs("MOCKID", int64(streamMatchesBatchSize)).
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(streamMatchesBatchSize+1, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED"))
+	stream := &matchStream{ctx: context.Background()}
+	require.NoError(t, service.StreamMatches(&pb.StreamMatchesRequest{ClientId: "MOCKID"}, stream))
+	assert.Len(t, stream.matches, streamMatchesBatchSize+1)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the client hangs up during the first batch: the next one is never read
+	full = sqlmock.NewRows(matchColumns)
+	for i := 1; i <= streamMatchesBatchSize; i++ {
+		full.AddRow(i, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED")
+	}
+	mock.ExpectQuery(batchSQL("AND id > ?")).WithArgs("MOCKID", int64(0)).WillReturnRows(full)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream = &matchStream{ctx: ctx, onSend: cancel}
+	err := service.StreamMatches(&pb.StreamMatchesRequest{ClientId: "MOCKID"}, stream)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	err = service.StreamMatches(&pb.StreamMatchesRequest{}, &matchStream{ctx: context.Background()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListMatchesPageToken(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE client_id = ? ORDER BY id DESC LIMIT 2 OFFSET 0")).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(matchColumns).
+			AddRow(9, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED").
+			AddRow(7, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED"))
+	resp, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", Limit: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	// the last page resumes after match 7, whatever was inserted meanwhile
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE client_id = ? AND id < ? ORDER BY id DESC LIMIT 2 OFFSET 0")).
+		WithArgs("MOCKID", int64(7)).
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(3, "MOCKID", 1, time.Now(), nil, nil, time.Now(), "RANKED"))
+	resp, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", Limit: 2, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	assert.Len(t, resp.Matches, 1)
+	assert.Empty(t, resp.NextPageToken)
+
+	for _, token := range []string{"garbage", "bWF0Y2g6", encodeMatchPageToken(0), "aWQ6NQ"} {
+		_, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", PageToken: token})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), token)
+	}
+	_, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", Offset: 2, PageToken: encodeMatchPageToken(7)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTopMatches(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + strings.Join(matchColumns, ", ") + " FROM client_matches " +
+		"WHERE client_id = ? ORDER BY score DESC, id ASC LIMIT 10")).
+		WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(matchColumns).
+			AddRow(4, "MOCKID", 50, time.Now(), nil, nil, time.Now(), "RANKED").
+			AddRow(9, "MOCKID", 50, time.Now(), nil, nil, time.Now(), "RANKED"))
+	resp, err := service.GetTopMatches(context.Background(), &pb.GetTopMatchesRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+	require.Len(t, resp.Matches, 2)
+	assert.Equal(t, int64(4), resp.Matches[0].Id)
+
+	mock.ExpectQuery(regexp.QuoteMeta("LIMIT 100")).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows(matchColumns))
+	resp, err = service.GetTopMatches(context.Background(), &pb.GetTopMatchesRequest{ClientId: "MOCKID", Limit: 1000})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Matches)
+
+	_, err = service.GetTopMatches(context.Background(), &pb.GetTopMatchesRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMatchesPlayedRange(t *testing.T) {
+	service, mock := newTestService(t)
+	to := time.Unix(0, time.Now().UnixNano())
+	from := to.AddDate(0, -1, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE client_id = ? AND played_at >= ? AND played_at < ? ORDER BY id DESC")).
+		WithArgs("MOCKID", from, to).WillReturnRows(sqlmock.NewRows(matchColumns))
+	_, err := service.ListMatches(context.Background(), &pb.ListMatchesRequest{
+		ClientId:   "MOCKID",
+		PlayedFrom: &pb.OptInt64{Value: from.UnixNano()},
+		PlayedTo:   &pb.OptInt64{Value: to.UnixNano()},
+	})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE client_id = ? AND played_at < ? ORDER BY id DESC")).
+		WithArgs("MOCKID", to).WillReturnRows(sqlmock.NewRows(matchColumns))
+	_, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{ClientId: "MOCKID", PlayedTo: &pb.OptInt64{Value: to.UnixNano()}})
+	require.NoError(t, err)
+
+	_, err = service.ListMatches(context.Background(), &pb.ListMatchesRequest{
+		ClientId:   "MOCKID",
+		PlayedFrom: &pb.OptInt64{Value: to.UnixNano()},
+		PlayedTo:   &pb.OptInt64{Value: from.UnixNano()},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUndoLastMatch(t *testing.T) {
+	service, mock := newTestService(t)
+	lockSQL := regexp.QuoteMeta("SELECT id FROM clients WHERE id = ? FOR UPDATE")
+	lastSQL := regexp.QuoteMeta("SELECT " + strings.Join(matchColumns, ", ") + " FROM client_matches WHERE client_id = ? ORDER BY id DESC LIMIT 1 FOR UPDATE")
+	createdAt := time.Unix(0, time.Now().UnixNano())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	mock.ExpectQuery(lastSQL).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(matchColumns).AddRow(9, "MOCKID", 5, createdAt, nil, "WIN", createdAt, "RANKED"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_matches WHERE id = ?")).WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = score - ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(5), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.UndoLastMatch(context.Background(), &pb.UndoLastMatchRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), resp.Match.Id)
+	assert.Equal(t, int64(5), resp.Match.Score)
+	assert.Equal(t, pb.MatchResult_WIN, resp.Match.Result)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	mock.ExpectQuery(lastSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows(matchColumns))
+	mock.ExpectRollback()
+	_, err = service.UndoLastMatch(context.Background(), &pb.UndoLastMatchRequest{ClientId: "MOCKID"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSQL).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+	_, err = service.UndoLastMatch(context.Background(), &pb.UndoLastMatchRequest{ClientId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUndoLastMatchConcurrent(t *testing.T) {
+	db := newMatchesDB()
+	db.clients["MOCKID"] = &fakeClient{score: 5}
+	db.matches[7] = &fakeMatch{clientID: "MOCKID", score: 2, matchType: "RANKED"}
+	db.matches[8] = &fakeMatch{clientID: "MOCKID", score: 3, matchType: "RANKED"}
+	service := db.service()
+
+	// two concurrent undos: the second one waits for the locks and sees the match left by the first,
+	// so each match is subtracted once
+	errs := make([]error, 2)
+	undone := make([]int64, 2)
+	concurrently(len(errs), func(i int) {
+		var resp *pb.UndoLastMatchResponse
+		if resp, errs[i] = service.UndoLastMatch(context.Background(), &pb.UndoLastMatchRequest{ClientId: "MOCKID"}); errs[i] == nil {
+			undone[i] = resp.Match.Id
+		}
+	})
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.ElementsMatch(t, []int64{7, 8}, undone)
+	assert.Empty(t, db.matches)
+	assert.Equal(t, int64(0), db.clients["MOCKID"].score)
+}
+
+// concurrently runs f(0) to f(n-1) at the same time, returning once they are all done
+func concurrently(n int, f func(i int)) {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			f(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+}
+
+// matchesDB is a fake database of clients and matches for the concurrency tests. As in InnoDB, the
+// locking reads and the writes lock the rows they touch until the end of the transaction, and
+// the plain reads don't wait for any lock. Every read pauses for matchesDBReadPause afterwards, so
+// transactions that don't lock their rows get to read them before the others write.
+type matchesDB struct {
+	mu       sync.Mutex
+	unlocked *sync.Cond
+	locks    map[string]*matchesConn
+	clients  map[string]*fakeClient
+	matches  map[int64]*fakeMatch
+	lastID   int64
+}
+
+const matchesDBReadPause = 20 * time.Millisecond
+
+type fakeClient struct {
+	status string
+	score  int64
+}
+
+type fakeMatch struct {
+	clientID       string
+	score          int64
+	matchType      string
+	idempotencyKey string
+	createdAt      time.Time
+}
+
+// row returns the matchColumns of the match
+func (m *fakeMatch) row(id int64) []driver.Value {
+	return []driver.Value{id, m.clientID, m.score, m.createdAt, nil, nil, m.createdAt, m.matchType}
+}
+
+func newMatchesDB() *matchesDB {
+	d := &matchesDB{
+		locks:   make(map[string]*matchesConn),
+		clients: make(map[string]*fakeClient),
+		matches: make(map[int64]*fakeMatch),
+	}
+	d.unlocked = sync.NewCond(&d.mu)
+	return d
+}
+
+// service returns a Service on the fake database
+func (d *matchesDB) service() *Service {
+	return &Service{db: sqlx.NewDb(sql.OpenDB(d), "mysql"), config: Config{}.withDefaults()}
+}
+
+func (d *matchesDB) Connect(context.Context) (driver.Conn, error) { return &matchesConn{db: d}, nil }
+func (d *matchesDB) Driver() driver.Driver                        { return nil }
+
+type matchesConn struct {
+	db   *matchesDB
+	held []string
+	undo []func()
+}
+
+func (c *matchesConn) Prepare(query string) (driver.Stmt, error) {
+	return &matchesStmt{conn: c, query: query}, nil
+}
+func (c *matchesConn) Close() error              { return nil }
+func (c *matchesConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *matchesConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.undo = nil
+	c.release()
+	return nil
+}
+
+func (c *matchesConn) Rollback() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	for i := len(c.undo) - 1; i >= 0; i-- {
+		c.undo[i]()
+	}
+	c.undo = nil
+	c.release()
+	return nil
+}
+
+// lock waits for the row key to be free and takes it until the end of the transaction, with db.mu held
+func (c *matchesConn) lock(key string) {
+	for c.db.locks[key] != nil && c.db.locks[key] != c {
+		c.db.unlocked.Wait()
+	}
+	if c.db.locks[key] == nil {
+		c.db.locks[key] = c
+		c.held = append(c.held, key)
+	}
+}
+
+func (c *matchesConn) release() {
+	for _, key := range c.held {
+		delete(c.db.locks, key)
+	}
+	c.held = nil
+	c.db.unlocked.Broadcast()
+}
+
+type matchesStmt struct {
+	conn  *matchesConn
+	query string
+}
+
+func (s *matchesStmt) Close() error  { return nil }
+func (s *matchesStmt) NumInput() int { return -1 }
+
+func (s *matchesStmt) Exec(args []driver.Value) (driver.Result, error) {
+	c := s.conn
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	switch s.query {
+	case "DELETE FROM client_matches WHERE id = ?":
+		id := args[0].(int64)
+		c.lock(fmt.Sprint("match ", id))
+		m, ok := c.db.matches[id]
+		if !ok {
+			return driver.RowsAffected(0), nil
+		}
+		delete(c.db.matches, id)
+		c.undo = append(c.undo, func() { c.db.matches[id] = m })
+		return driver.RowsAffected(1), nil
+	case "UPDATE client_matches SET score = ? WHERE id = ?":
+		id, score := args[1].(int64), args[0].(int64)
+		c.lock(fmt.Sprint("match ", id))
+		m, ok := c.db.matches[id]
+		if !ok {
+			return driver.RowsAffected(0), nil
+		}
+		old := m.score
+		m.score = score
+		c.undo = append(c.undo, func() { m.score = old })
+		return driver.RowsAffected(1), nil
+	case "UPDATE clients SET score = score + ?, updated_at = NOW() WHERE id = ?":
+		return c.addScore(args[1].(string), args[0].(int64)), nil
+	case "UPDATE clients SET score = score - ?, updated_at = NOW() WHERE id = ?":
+		return c.addScore(args[1].(string), -args[0].(int64)), nil
+	}
+	if strings.HasPrefix(s.query, "INSERT INTO client_matches (") {
+		return c.insertMatch(s.query, args)
+	}
+	return nil, fmt.Errorf("unexpected exec %q", s.query)
+}
+
+// insertMatch inserts a match from the columns of the query, with db.mu held. As uniq_idempotency_key
+// does, a key taken by another transaction waits for it and fails if that one commits.
+func (c *matchesConn) insertMatch(query string, args []driver.Value) (driver.Result, error) {
+	cols := strings.Split(strings.TrimPrefix(query[:strings.Index(query, ")")], "INSERT INTO client_matches ("), ",")
+	m := &fakeMatch{matchType: pb.MatchType_RANKED.String(), createdAt: time.Now()}
+	for i, col := range cols[:len(args)] {
+		switch col {
+		case "client_id":
+			m.clientID = args[i].(string)
+		case "score":
+			m.score = args[i].(int64)
+		case "match_type":
+			m.matchType = args[i].(string)
+		case "idempotency_key":
+			m.idempotencyKey = args[i].(string)
+		}
+	}
+	if m.idempotencyKey != "" {
+		c.lock("idempotency " + m.clientID + " " + m.idempotencyKey)
+		for _, other := range c.db.matches {
+			if other.clientID == m.clientID && other.idempotencyKey == m.idempotencyKey {
+				return nil, &mysql.MySQLError{Number: 1062,
+					Message: fmt.Sprintf("Duplicate entry '%s-%s' for key 'uniq_idempotency_key'", m.clientID, m.idempotencyKey)}
+			}
+		}
+	}
+	c.db.lastID++
+	id := c.db.lastID
+	c.lock(fmt.Sprint("match ", id))
+	c.db.matches[id] = m
+	c.undo = append(c.undo, func() { delete(c.db.matches, id) })
+	return insertedMatch(id), nil
+}
+
+type insertedMatch int64
+
+func (r insertedMatch) LastInsertId() (int64, error) { return int64(r), nil }
+func (r insertedMatch) RowsAffected() (int64, error) { return 1, nil }
+
+// addScore adds delta to the score of a client, with db.mu held
+func (c *matchesConn) addScore(id string, delta int64) driver.Result {
+	c.lock("client " + id)
+	client, ok := c.db.clients[id]
+	if !ok {
+		return driver.RowsAffected(0)
+	}
+	client.score += delta
+	c.undo = append(c.undo, func() { client.score -= delta })
+	return driver.RowsAffected(1)
+}
+
+// Query runs the reads, locking the rows they return if they end with FOR UPDATE
+func (s *matchesStmt) Query(args []driver.Value) (driver.Rows, error) {
+	defer time.Sleep(matchesDBReadPause)
+	c := s.conn
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	query := strings.TrimSuffix(s.query, " FOR UPDATE")
+	lock := func(key string) {
+		if query != s.query {
+			c.lock(key)
+		}
+	}
+	switch query {
+	case "SELECT " + strings.Join(matchColumns, ", ") + " FROM client_matches WHERE id = ?":
+		id := args[0].(int64)
+		lock(fmt.Sprint("match ", id))
+		rows := &lockingRows{cols: matchColumns}
+		if m, ok := c.db.matches[id]; ok {
+			rows.values = [][]driver.Value{m.row(id)}
+		}
+		return rows, nil
+	case "SELECT client_id, score, match_type FROM client_matches WHERE id = ?":
+		id := args[0].(int64)
+		lock(fmt.Sprint("match ", id))
+		rows := &lockingRows{cols: []string{"client_id", "score", "match_type"}}
+		if m, ok := c.db.matches[id]; ok {
+			rows.values = [][]driver.Value{{m.clientID, m.score, m.matchType}}
+		}
+		return rows, nil
+	case "SELECT status, score FROM clients WHERE id = ? AND deleted_at IS NULL":
+		id := args[0].(string)
+		lock("client " + id)
+		rows := &lockingRows{cols: []string{"status", "score"}}
+		if client, ok := c.db.clients[id]; ok {
+			rows.values = [][]driver.Value{{client.status, client.score}}
+		}
+		return rows, nil
+	case "SELECT id, score, created_at FROM client_matches WHERE client_id = ? AND idempotency_key = ?":
+		rows := &lockingRows{cols: []string{"id", "score", "created_at"}}
+		for id, m := range c.db.matches {
+			// the matches inserted by other transactions aren't visible until they commit
+			if owner := c.db.locks[fmt.Sprint("match ", id)]; owner != nil && owner != c {
+				continue
+			}
+			if m.clientID == args[0].(string) && m.idempotencyKey == args[1].(string) {
+				rows.values = [][]driver.Value{{id, m.score, m.createdAt}}
+			}
+		}
+		return rows, nil
+	case "SELECT created_at FROM client_matches WHERE id = ?":
+		rows := &lockingRows{cols: []string{"created_at"}}
+		if m, ok := c.db.matches[args[0].(int64)]; ok {
+			rows.values = [][]driver.Value{{m.createdAt}}
+		}
+		return rows, nil
+	case "SELECT id FROM clients WHERE id = ?":
+		id := args[0].(string)
+		lock("client " + id)
+		rows := &lockingRows{cols: []string{"id"}}
+		if _, ok := c.db.clients[id]; ok {
+			rows.values = [][]driver.Value{{id}}
+		}
+		return rows, nil
+	case "SELECT " + strings.Join(matchColumns, ", ") + " FROM client_matches WHERE client_id = ? ORDER BY id DESC LIMIT 1":
+		// like InnoDB, a locking read waiting on a match deleted meanwhile moves on to the next one
+		rows := &lockingRows{cols: matchColumns}
+		for {
+			id, m := c.db.lastMatch(args[0].(string))
+			if m == nil {
+				return rows, nil
+			}
+			lock(fmt.Sprint("match ", id))
+			if c.db.matches[id] == m {
+				rows.values = [][]driver.Value{m.row(id)}
+				return rows, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected query %q", s.query)
+}
+
+// lastMatch returns the match of the client with the highest id, with db.mu held
+func (d *matchesDB) lastMatch(clientID string) (int64, *fakeMatch) {
+	var lastID int64
+	var last *fakeMatch
+	for id, m := range d.matches {
+		if m.clientID == clientID && id > lastID {
+			lastID, last = id, m
+		}
+	}
+	return lastID, last
+}
@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestMergeClientsIntegration checks that MergeClients moves the tags and the matches against the
+// source to the target, and refuses clients with matches of the same idempotency key
+func TestMergeClientsIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("merge-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	newClient := func(name string, tags ...string) string {
+		resp, err := service.NewClient(ctx, &pb.NewClientRequest{Name: prefix + name})
+		require.NoError(t, err)
+		if len(tags) > 0 {
+			_, err = service.AddClientTags(ctx, &pb.AddClientTagsRequest{ClientId: resp.Id, Tags: tags})
+			require.NoError(t, err)
+		}
+		return resp.Id
+	}
+	newMatch := func(req *pb.NewMatchRequest) int64 {
+		resp, err := service.NewMatch(ctx, req)
+		require.NoError(t, err)
+		return resp.Id
+	}
+	opponent := func(id int64) string {
+		resp, err := service.GetMatch(ctx, &pb.GetMatchRequest{Id: id})
+		require.NoError(t, err)
+		return resp.Match.OpponentId
+	}
+
+	alice := newClient("alice", "a", "shared")
+	bob := newClient("bob", "shared", "b")
+	carol := newClient("carol")
+	newMatch(&pb.NewMatchRequest{ClientId: alice, Score: 1, IdempotencyKey: "K1"})
+	againstAlice := newMatch(&pb.NewMatchRequest{ClientId: carol, Score: 1, OpponentId: alice})
+	bobAgainstAlice := newMatch(&pb.NewMatchRequest{ClientId: bob, Score: 1, OpponentId: alice})
+
+	_, err = service.MergeClients(ctx, &pb.MergeClientsRequest{SourceId: alice, TargetId: bob, Force: true})
+	require.NoError(t, err)
+	client, err := service.GetClient(ctx, &pb.GetClientRequest{Id: bob})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "shared"}, client.Client.Tags)
+	assert.Equal(t, bob, opponent(againstAlice))
+	assert.Equal(t, "", opponent(bobAgainstAlice), "the target can't be its own opponent")
+
+	// both have a match with the key K2
+	dave := newClient("dave")
+	erin := newClient("erin")
+	newMatch(&pb.NewMatchRequest{ClientId: dave, Score: 1, IdempotencyKey: "K2"})
+	newMatch(&pb.NewMatchRequest{ClientId: erin, Score: 1, IdempotencyKey: "K2"})
+	_, err = service.MergeClients(ctx, &pb.MergeClientsRequest{SourceId: dave, TargetId: erin})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics are the Prometheus metrics of the service. The RPC ones are recorded by the interceptors of
+// ServerOptions, which have to be given to grpc.NewServer, and the ones of the database pool are refreshed
+// by New when it's given the Metrics in Config.Metrics.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	panics   *prometheus.CounterVec
+
+	dbOpen         prometheus.Gauge
+	dbInUse        prometheus.Gauge
+	dbIdle         prometheus.Gauge
+	dbWaitCount    prometheus.Gauge
+	dbWaitDuration prometheus.Gauge
+}
+
+// NewMetrics creates the metrics of the service, registered on reg so that the embedding binary decides how
+// they are exposed
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "clients_grpc_requests_total",
+			Help: "RPCs handled, by full method name and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "clients_grpc_request_duration_seconds",
+			Help:    "Time taken to handle the RPCs, by full method name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "clients_grpc_panics_total",
+			Help: "Panics recovered from the RPC handlers, by full method name.",
+		}, []string{"method"}),
+		dbOpen: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clients_db_open_connections",
+			Help: "Connections to the database, in use or idle.",
+		}),
+		dbInUse: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clients_db_in_use_connections",
+			Help: "Connections to the database in use.",
+		}),
+		dbIdle: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clients_db_idle_connections",
+			Help: "Idle connections to the database.",
+		}),
+		dbWaitCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clients_db_wait_count",
+			Help: "Total connections waited for since the start.",
+		}),
+		dbWaitDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "clients_db_wait_duration_seconds",
+			Help: "Total time waited for connections since the start.",
+		}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.panics, m.dbOpen, m.dbInUse, m.dbIdle, m.dbWaitCount, m.dbWaitDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ServerOptions are the grpc.NewServer options recording the RPC metrics
+func (m *Metrics) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(m.unaryInterceptor),
+		grpc.ChainStreamInterceptor(m.streamInterceptor),
+	}
+}
+
+func (m *Metrics) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+func (m *Metrics) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observe(info.FullMethod, start, err)
+	return err
+}
+
+func (m *Metrics) observe(method string, start time.Time, err error) {
+	m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// observeDBStats sets the metrics of the database pool from its current stats
+func (m *Metrics) observeDBStats(db *sqlx.DB) {
+	stats := db.Stats()
+	m.dbOpen.Set(float64(stats.OpenConnections))
+	m.dbInUse.Set(float64(stats.InUse))
+	m.dbIdle.Set(float64(stats.Idle))
+	m.dbWaitCount.Set(float64(stats.WaitCount))
+	m.dbWaitDuration.Set(stats.WaitDuration.Seconds())
+}
+
+// refreshDBStats updates the metrics of the database pool every Config.DBStatsInterval until ctx is done
+func (s *Service) refreshDBStats(ctx context.Context) {
+	ticker := time.NewTicker(s.config.DBStatsInterval)
+	defer ticker.Stop()
+	for {
+		s.config.Metrics.observeDBStats(s.db)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := NewMetrics(reg)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	unary := func(method string, err error) {
+		_, _ = metrics.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, err })
+	}
+	unary("/pb.ClientsService/GetClient", nil)
+	unary("/pb.ClientsService/GetClient", nil)
+	unary("/pb.ClientsService/GetClient", status.Error(codes.NotFound, "client not found"))
+	unary("/pb.ClientsService/Sort", status.Error(codes.InvalidArgument, "invalid mode 5"))
+	_ = metrics.streamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/pb.ClientsService/SortStream"},
+		func(srv interface{}, stream grpc.ServerStream) error { return context.Canceled })
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP clients_grpc_requests_total RPCs handled, by full method name and status code.
+# TYPE clients_grpc_requests_total counter
+clients_grpc_requests_total{code="InvalidArgument",method="/pb.ClientsService/Sort"} 1
+clients_grpc_requests_total{code="NotFound",method="/pb.ClientsService/GetClient"} 1
+clients_grpc_requests_total{code="OK",method="/pb.ClientsService/GetClient"} 2
+clients_grpc_requests_total{code="Unknown",method="/pb.ClientsService/SortStream"} 1
+`), "clients_grpc_requests_total"))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	observed := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "clients_grpc_request_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			observed[m.GetLabel()[0].GetValue()] = m.GetHistogram().GetSampleCount()
+		}
+	}
+	assert.Equal(t, map[string]uint64{
+		"/pb.ClientsService/GetClient":  3,
+		"/pb.ClientsService/Sort":       1,
+		"/pb.ClientsService/SortStream": 1,
+	}, observed)
+
+	// the metrics can't be registered twice on a registry
+	_, err = NewMetrics(reg)
+	assert.Error(t, err)
+}
+
+func TestMetricsDBStats(t *testing.T) {
+	service, mock := newTestService(t)
+	reg := prometheus.NewRegistry()
+	metrics, err := NewMetrics(reg)
+	require.NoError(t, err)
+
+	// a connection is opened for the ping, and left idle
+	require.NoError(t, service.db.Ping())
+	metrics.observeDBStats(service.db)
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP clients_db_idle_connections Idle connections to the database.
+# TYPE clients_db_idle_connections gauge
+clients_db_idle_connections 1
+# HELP clients_db_in_use_connections Connections to the database in use.
+# TYPE clients_db_in_use_connections gauge
+clients_db_in_use_connections 0
+# HELP clients_db_open_connections Connections to the database, in use or idle.
+# TYPE clients_db_open_connections gauge
+clients_db_open_connections 1
+# HELP clients_db_wait_count Total connections waited for since the start.
+# TYPE clients_db_wait_count gauge
+clients_db_wait_count 0
+`), "clients_db_idle_connections", "clients_db_in_use_connections", "clients_db_open_connections", "clients_db_wait_count"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkClientQuota refuses the creation of n clients if it would exceed the configured MaxClients.
+// Concurrent creations queue on the lock of the single client_quota row until tx finishes, so the
+// clients table itself isn't locked. The count is a plain read, so it has to be the first one of tx:
+// its snapshot is then taken with the lock held, and sees the clients of the previous holders.
+func (s *Service) checkClientQuota(ctx context.Context, tx *sqlx.Tx, n int) error {
+	if s.config.MaxClients <= 0 {
+		return nil
+	}
+	// creates the row on first use, locking it either way
+	if _, err := tx.ExecContext(ctx, "INSERT INTO client_quota (id) VALUES (1) ON DUPLICATE KEY UPDATE id = id"); err != nil {
+		return err
+	}
+	var count int
+	if err := tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL"); err != nil {
+		return err
+	}
+	if count+n > s.config.MaxClients {
+		return status.Errorf(codes.ResourceExhausted, "client limit reached: %d clients exist, the limit is %d", count, s.config.MaxClients)
+	}
+	return nil
+}
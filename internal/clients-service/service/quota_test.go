@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/pedidopago/trainingsvc-clients/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientQuota(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.MaxClients = 10
+	id := utils.SecureID().String()
+
+	mock.ExpectBegin()
+	expectClientQuota(mock, 10)
+	mock.ExpectRollback()
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "10 clients exist, the limit is 10")
+
+	mock.ExpectBegin()
+	expectClientQuota(mock, 9)
+	mock.ExpectRollback()
+	_, err = service.NewClients(context.Background(), &pb.NewClientsRequest{
+		Clients: []*pb.NewClientRequest{{Name: "A"}, {Name: "B"}},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// upserting a new client and restoring a deleted one count too
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")).
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+	expectClientQuota(mock, 10)
+	mock.ExpectRollback()
+	_, err = service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Test"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")).
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO clients").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	_, err = service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Test"})
+	assert.NoError(t, err, "updating an existing client doesn't count")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")).
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+	expectClientQuota(mock, 10)
+	mock.ExpectRollback()
+	_, err = service.RestoreClient(context.Background(), &pb.RestoreClientRequest{Id: id})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// expectClientQuota expects the quota row lock and the count of the clients
+func expectClientQuota(mock sqlmock.Sqlmock, count int) {
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_quota (id) VALUES (1) ON DUPLICATE KEY UPDATE id = id")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+}
+
+func TestClientQuotaConcurrent(t *testing.T) {
+	db := &lockingDB{unlocked: make(chan struct{}, 1)}
+	db.unlocked <- struct{}{}
+	service := &Service{
+		db:     sqlx.NewDb(sql.OpenDB(db), "mysql"),
+		config: Config{MaxClients: 5}.withDefaults(),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created, exhausted := 0, 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test"})
+			mu.Lock()
+			defer mu.Unlock()
+			switch status.Code(err) {
+			case codes.OK:
+				created++
+			case codes.ResourceExhausted:
+				exhausted++
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5, created)
+	assert.Equal(t, 45, exhausted)
+	assert.Equal(t, 5, db.clients)
+}
+
+// lockingDB is a fake database with a clients table and the client_quota row, whose lock blocks
+// other transactions from taking it until the transaction finishes.
+type lockingDB struct {
+	unlocked chan struct{} // holds a token while nobody has the lock
+	mu       sync.Mutex
+	clients  int
+}
+
+func (d *lockingDB) Connect(context.Context) (driver.Conn, error) { return &lockingConn{db: d}, nil }
+func (d *lockingDB) Driver() driver.Driver                        { return nil }
+
+type lockingConn struct {
+	db      *lockingDB
+	locked  bool
+	pending int
+}
+
+func (c *lockingConn) Prepare(query string) (driver.Stmt, error) {
+	return &lockingStmt{conn: c, query: query}, nil
+}
+func (c *lockingConn) Close() error              { return nil }
+func (c *lockingConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *lockingConn) Commit() error {
+	c.db.mu.Lock()
+	c.db.clients += c.pending
+	c.db.mu.Unlock()
+	return c.Rollback()
+}
+
+func (c *lockingConn) Rollback() error {
+	c.pending = 0
+	if c.locked {
+		c.locked = false
+		c.db.unlocked <- struct{}{}
+	}
+	return nil
+}
+
+type lockingStmt struct {
+	conn  *lockingConn
+	query string
+}
+
+func (s *lockingStmt) Close() error  { return nil }
+func (s *lockingStmt) NumInput() int { return -1 }
+
+func (s *lockingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(s.query, "INSERT INTO client_quota"):
+		if !s.conn.locked {
+			<-s.conn.db.unlocked
+			s.conn.locked = true
+		}
+	case strings.HasPrefix(s.query, "INSERT INTO clients"):
+		s.conn.pending++
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *lockingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	switch {
+	case strings.HasPrefix(s.query, "SELECT COUNT(*)"):
+		// gives the other transactions the time to count too, were the quota row not locked
+		defer time.Sleep(5 * time.Millisecond)
+		s.conn.db.mu.Lock()
+		defer s.conn.db.mu.Unlock()
+		return &lockingRows{cols: []string{"count"}, values: [][]driver.Value{{int64(s.conn.db.clients)}}}, nil
+	case strings.Contains(s.query, "FROM `clients`"):
+		return &lockingRows{cols: []string{"id"}, values: [][]driver.Value{{args[0]}}}, nil
+	}
+	return &lockingRows{cols: []string{"client_id", "tag"}}, nil
+}
+
+type lockingRows struct {
+	cols   []string
+	values [][]driver.Value
+}
+
+func (r *lockingRows) Columns() []string { return r.cols }
+func (r *lockingRows) Close() error      { return nil }
+
+func (r *lockingRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
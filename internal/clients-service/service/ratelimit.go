@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimiter limits the calls of each method of the clients service to its own token bucket. A nil
+// rateLimiter allows everything.
+type rateLimiter struct {
+	buckets map[string]*tokenBucket // by full method name
+	now     func() time.Time
+}
+
+// newRateLimiter limits every method to rate calls per second, but the ones of overrides, by method name.
+// A limit of 0 or less leaves a method unlimited, and it's nil when all of them are.
+func newRateLimiter(rate float64, overrides map[string]float64) (*rateLimiter, error) {
+	desc := pb.ClientsServiceDesc
+	limits := map[string]float64{}
+	for _, m := range desc.Methods {
+		limits[m.MethodName] = rate
+	}
+	for _, s := range desc.Streams {
+		limits[s.StreamName] = rate
+	}
+	for name, limit := range overrides {
+		if _, ok := limits[name]; !ok {
+			return nil, fmt.Errorf("rate limit of unknown method %q", name)
+		}
+		limits[name] = limit
+	}
+
+	l := &rateLimiter{buckets: map[string]*tokenBucket{}, now: time.Now}
+	for name, limit := range limits {
+		if limit > 0 {
+			l.buckets["/"+desc.ServiceName+"/"+name] = newTokenBucket(limit, l.now())
+		}
+	}
+	if len(l.buckets) == 0 {
+		return nil, nil
+	}
+	return l, nil
+}
+
+// allow takes a token for a call of method, or returns the ResourceExhausted error of the call with the
+// time until the next token in its RetryInfo
+func (l *rateLimiter) allow(method string) error {
+	if l == nil || l.buckets[method] == nil {
+		return nil
+	}
+	wait, ok := l.buckets[method].take(l.now())
+	if ok {
+		return nil
+	}
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit of %s exceeded", method)).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)})
+	if err != nil {
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded", method)
+	}
+	return st.Err()
+}
+
+// tokenBucket is refilled with rate tokens per second, up to a second of tokens (at least 1) so that the
+// bursts are bounded too
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	burst := math.Max(rate, 1)
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+// take takes a token at now if there's one, else it tells how long until there is
+func (b *tokenBucket) take(now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.rate * float64(time.Second))), false
+}
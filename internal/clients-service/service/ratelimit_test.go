@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryDelay is the RetryInfo delay of a rate limit error
+func retryDelay(t *testing.T, err error) time.Duration {
+	t.Helper()
+	st := status.Convert(err)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	delay, err := ptypes.Duration(st.Details()[0].(*errdetails.RetryInfo).RetryDelay)
+	require.NoError(t, err)
+	return delay
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	limiter, err := newRateLimiter(10, map[string]float64{
+		"DeleteAllClients": 0.5,
+		"GetClients":       0,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	service := &Service{config: Config{}.withDefaults(), limiter: limiter}
+
+	var calls int
+	call := func(method string) error {
+		_, err := service.unaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return nil, nil
+			})
+		return err
+	}
+
+	// a second of calls goes through at once, then the bucket is empty
+	for i := 0; i < 10; i++ {
+		require.NoError(t, call("/pb.ClientsService/NewClient"), i)
+	}
+	err = call("/pb.ClientsService/NewClient")
+	assert.Equal(t, "rate limit of /pb.ClientsService/NewClient exceeded", status.Convert(err).Message())
+	assert.Equal(t, 100*time.Millisecond, retryDelay(t, err))
+	assert.Equal(t, 10, calls, "the handler isn't called over the limit")
+
+	// the other methods have their own buckets, and the overrides their own limits
+	assert.NoError(t, call("/pb.ClientsService/GetClient"))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, call("/pb.ClientsService/GetClients"))
+	}
+	assert.NoError(t, call("/pb.ClientsService/DeleteAllClients"))
+	assert.Equal(t, 2*time.Second, retryDelay(t, call("/pb.ClientsService/DeleteAllClients")))
+
+	// the tokens come back with time, up to the burst
+	now = now.Add(250 * time.Millisecond)
+	assert.NoError(t, call("/pb.ClientsService/NewClient"))
+	assert.NoError(t, call("/pb.ClientsService/NewClient"))
+	assert.Equal(t, 50*time.Millisecond, retryDelay(t, call("/pb.ClientsService/NewClient")))
+	now = now.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, call("/pb.ClientsService/NewClient"), i)
+	}
+	assert.Error(t, call("/pb.ClientsService/NewClient"))
+	assert.NoError(t, call("/pb.ClientsService/DeleteAllClients"))
+	assert.Error(t, call("/pb.ClientsService/DeleteAllClients"))
+}
+
+func TestRateLimitConcurrent(t *testing.T) {
+	limiter, err := newRateLimiter(50, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	var allowed, refused int64
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.allow("/pb.ClientsService/NewClient") == nil {
+				atomic.AddInt64(&allowed, 1)
+			} else {
+				atomic.AddInt64(&refused, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(50), allowed)
+	assert.Equal(t, int64(150), refused)
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	limiter, err := newRateLimiter(0, nil)
+	require.NoError(t, err)
+	assert.Nil(t, limiter, "no limits, no limiter")
+	assert.NoError(t, limiter.allow("/pb.ClientsService/NewClient"))
+
+	limiter, err = newRateLimiter(0, map[string]float64{"NewClient": 5})
+	require.NoError(t, err)
+	assert.Len(t, limiter.buckets, 1)
+	assert.Contains(t, limiter.buckets, "/pb.ClientsService/NewClient")
+	assert.Equal(t, 5.0, limiter.buckets["/pb.ClientsService/NewClient"].burst)
+
+	_, err = newRateLimiter(10, map[string]float64{"GetClientz": 5})
+	assert.EqualError(t, err, `rate limit of unknown method "GetClientz"`)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recoveryUnaryInterceptor turns a panic of handler into an Internal error
+func (s *Service) recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp, err = nil, s.recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoveryStreamInterceptor turns a panic of handler into an Internal error
+func (s *Service) recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered logs the panic r of a call of method, with its stack, and returns the error of the call. The
+// logger of ctx already has the request id and method, as set by RequestLogging, else the global one is used.
+func (s *Service) recovered(ctx context.Context, method string, r interface{}) error {
+	logger := *zerolog.Ctx(ctx)
+	if logger.GetLevel() == zerolog.Disabled {
+		logger = log.With().Str("request_id", RequestID(ctx)).Str("method", method).Logger()
+	}
+	logger.Error().
+		Interface("panic", r).
+		Bytes("stack", debug.Stack()).
+		Msg("rpc panic")
+	if s.config.Metrics != nil {
+		s.config.Metrics.panics.WithLabelValues(method).Inc()
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRecovery(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := NewMetrics(reg)
+	require.NoError(t, err)
+	// without a database the handlers that query it panic
+	service := &Service{config: Config{Metrics: metrics}.withDefaults()}
+
+	var buf bytes.Buffer
+	logging := RequestLogging{Logger: zerolog.New(&buf)}
+	lis := bufconn.Listen(1 << 20)
+	sv := grpc.NewServer(append(metrics.ServerOptions(), logging.ServerOptions()...)...)
+	registerClientsService(sv, service)
+	go func() { _ = sv.Serve(lis) }()
+	defer sv.Stop()
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewClientsServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDHeader, "req-1")
+
+	_, err = client.GetClient(ctx, &pb.GetClientRequest{Id: "A"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal error", status.Convert(err).Message(), "the panic isn't sent to the caller")
+	// the panic is logged with its stack, then the RPC as any other
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "rpc panic", lines[0]["message"])
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Contains(t, lines[0]["stack"], "(*Service).GetClient")
+	assert.Equal(t, "rpc", lines[1]["message"])
+	assert.Equal(t, "Internal", lines[1]["code"])
+
+	stream, err := client.StreamMatches(ctx, &pb.StreamMatchesRequest{ClientId: "A"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// the connection survives the panics
+	resp, err := client.Sort(ctx, &pb.SortRequest{Items: []string{"b", "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, resp.Items)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP clients_grpc_panics_total Panics recovered from the RPC handlers, by full method name.
+# TYPE clients_grpc_panics_total counter
+clients_grpc_panics_total{method="/pb.ClientsService/GetClient"} 1
+clients_grpc_panics_total{method="/pb.ClientsService/StreamMatches"} 1
+# HELP clients_grpc_requests_total RPCs handled, by full method name and status code.
+# TYPE clients_grpc_requests_total counter
+clients_grpc_requests_total{code="Internal",method="/pb.ClientsService/GetClient"} 1
+clients_grpc_requests_total{code="Internal",method="/pb.ClientsService/StreamMatches"} 1
+clients_grpc_requests_total{code="OK",method="/pb.ClientsService/Sort"} 1
+`), "clients_grpc_panics_total", "clients_grpc_requests_total"))
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deleteMatchesBatchSize is the max number of matches removed by each DELETE of deleteMatchesOlderThan
+const deleteMatchesBatchSize = 1000
+
+// DeleteMatchesOlderThan removes the matches recorded before req.Before, returning how many were removed.
+// The client scores are cumulative, so they are left as they are; the scores of the removed matches move
+// to the score_baseline of their clients, for RecalculateScore.
+// If the request is canceled midway, the batches already deleted stay deleted and their count is
+// reported in the error.
+func (s *Service) DeleteMatchesOlderThan(ctx context.Context, req *pb.DeleteMatchesOlderThanRequest) (*pb.DeleteMatchesOlderThanResponse, error) {
+	if req.Before <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "before is required")
+	}
+	deleted, err := s.deleteMatchesOlderThan(ctx, time.Unix(0, req.Before))
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.Errorf(status.FromContextError(ctxErr).Code(), "stopped after deleting %d matches: %v", deleted, ctxErr)
+		}
+		return nil, err
+	}
+	return &pb.DeleteMatchesOlderThanResponse{Deleted: deleted}, nil
+}
+
+// deleteMatchesOlderThan deletes the matches created before t in batches of deleteMatchesBatchSize,
+// pausing for the configured MatchRetentionPause between them so the replicas can keep up.
+// It returns the number of matches deleted, even when it stops early.
+func (s *Service) deleteMatchesOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	var deleted int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		n, err := s.deleteMatchesBatch(ctx, t)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+		if n < deleteMatchesBatchSize {
+			return deleted, nil
+		}
+		select {
+		case <-ctx.Done():
+			return deleted, ctx.Err()
+		case <-time.After(s.config.MatchRetentionPause):
+		}
+	}
+}
+
+// expireMatches periodically removes the matches older than the configured MatchRetention
+func (s *Service) expireMatches(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.deleteMatchesOlderThan(ctx, time.Now().Add(-s.config.MatchRetention))
+		}
+	}
+}
+
+// deleteMatchesBatch deletes, in its own transaction, up to deleteMatchesBatchSize of the matches created
+// before t, adding the scores of the scoring ones to the score_baseline of their clients
+func (s *Service) deleteMatchesBatch(ctx context.Context, t time.Time) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	matches := []struct {
+		ID        int64  `db:"id"`
+		ClientID  string `db:"client_id"`
+		Score     int64  `db:"score"`
+		MatchType string `db:"match_type"`
+	}{}
+	if err := tx.SelectContext(ctx, &matches, "SELECT id, client_id, score, match_type FROM client_matches "+
+		"WHERE created_at < ? ORDER BY created_at, id LIMIT ? FOR UPDATE", t, deleteMatchesBatchSize); err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		return 0, nil
+	}
+	ids := make([]int64, 0, len(matches))
+	purged := make(map[string]int64)
+	for _, m := range matches {
+		ids = append(ids, m.ID)
+		if s.countsForScore(m.MatchType) {
+			purged[m.ClientID] += m.Score
+		}
+	}
+
+	if len(purged) > 0 {
+		clientIDs := make([]string, 0, len(purged))
+		for id := range purged {
+			clientIDs = append(clientIDs, id)
+		}
+		sort.Strings(clientIDs)
+		cases := make([]string, 0, len(clientIDs))
+		args := make([]interface{}, 0, 2*len(clientIDs))
+		for _, id := range clientIDs {
+			cases = append(cases, "WHEN ? THEN ?")
+			args = append(args, id, purged[id])
+		}
+		q, args, err := sq.Update("clients").
+			Set("score_baseline", sq.Expr("score_baseline + CASE id "+strings.Join(cases, " ")+" END", args...)).
+			Where(sq.Eq{"id": clientIDs}).ToSql()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return 0, err
+		}
+	}
+	q, args, err := sq.Delete("client_matches").Where(sq.Eq{"id": ids}).ToSql()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int64(len(matches)), nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// matchesBatchSQL selects the matches of a retention batch
+var matchesBatchSQL = regexp.QuoteMeta("SELECT id, client_id, score, match_type FROM client_matches " +
+	"WHERE created_at < ? ORDER BY created_at, id LIMIT ? FOR UPDATE")
+
+// expectMatchesBatch expects a retention batch of n RANKED matches of 1 point of MOCKID
+func expectMatchesBatch(mock sqlmock.Sqlmock, before time.Time, n int) {
+	rows := sqlmock.NewRows([]string{"id", "client_id", "score", "match_type"})
+	for i := 0; i < n; i++ {
+		rows.AddRow(i+1, "MOCKID", 1, "RANKED")
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(matchesBatchSQL).WithArgs(before, deleteMatchesBatchSize).WillReturnRows(rows)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score_baseline = score_baseline + CASE id WHEN ? THEN ? END WHERE id IN (?)")).
+		WithArgs("MOCKID", int64(n), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM client_matches WHERE id IN").WillReturnResult(sqlmock.NewResult(0, int64(n)))
+	mock.ExpectCommit()
+}
+
+func TestDeleteMatchesOlderThan(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.MatchRetentionPause = time.Millisecond
+	before := time.Unix(0, time.Now().AddDate(0, -18, 0).UnixNano())
+
+	// batches until one comes back short; the client scores are not touched
+	expectMatchesBatch(mock, before, deleteMatchesBatchSize)
+	expectMatchesBatch(mock, before, deleteMatchesBatchSize)
+	expectMatchesBatch(mock, before, 42)
+	resp, err := service.DeleteMatchesOlderThan(context.Background(), &pb.DeleteMatchesOlderThanRequest{Before: before.UnixNano()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*deleteMatchesBatchSize+42), resp.Deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// nothing left to delete
+	mock.ExpectBegin()
+	mock.ExpectQuery(matchesBatchSQL).WithArgs(before, deleteMatchesBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "score", "match_type"}))
+	mock.ExpectRollback()
+	resp, err = service.DeleteMatchesOlderThan(context.Background(), &pb.DeleteMatchesOlderThanRequest{Before: before.UnixNano()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// canceled during the pause after the first batch
+	service.config.MatchRetentionPause = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	expectMatchesBatch(mock, before, deleteMatchesBatchSize)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = service.DeleteMatchesOlderThan(ctx, &pb.DeleteMatchesOlderThanRequest{Before: before.UnixNano()})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "stopped after deleting 1000 matches")
+
+	_, err = service.DeleteMatchesOlderThan(context.Background(), &pb.DeleteMatchesOlderThanRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMatchesOlderThanBaseline(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.ExcludePracticeScore = true
+	before := time.Unix(0, time.Now().UnixNano())
+
+	// the scores of the deleted matches go to the baseline of their clients, except the practice ones
+	mock.ExpectBegin()
+	mock.ExpectQuery(matchesBatchSQL).WithArgs(before, deleteMatchesBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "score", "match_type"}).
+			AddRow(1, "BOB", 5, "RANKED").
+			AddRow(2, "ALICE", -3, "RANKED").
+			AddRow(3, "BOB", 2, "RANKED").
+			AddRow(4, "CAROL", 9, "PRACTICE"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score_baseline = score_baseline + CASE id WHEN ? THEN ? WHEN ? THEN ? END WHERE id IN (?,?)")).
+		WithArgs("ALICE", int64(-3), "BOB", int64(7), "ALICE", "BOB").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_matches WHERE id IN (?,?,?,?)")).
+		WithArgs(int64(1), int64(2), int64(3), int64(4)).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+	resp, err := service.DeleteMatchesOlderThan(context.Background(), &pb.DeleteMatchesOlderThanRequest{Before: before.UnixNano()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Deleted)
+
+	// only practice matches: no baseline changes
+	mock.ExpectBegin()
+	mock.ExpectQuery(matchesBatchSQL).WithArgs(before, deleteMatchesBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "client_id", "score", "match_type"}).AddRow(5, "CAROL", 9, "PRACTICE"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_matches WHERE id IN (?)")).
+		WithArgs(int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = service.DeleteMatchesOlderThan(context.Background(), &pb.DeleteMatchesOlderThanRequest{Before: before.UnixNano()})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSampleClients is the largest sample SampleClients draws
+const maxSampleClients = 1000
+
+// sampleSortLimit is the largest number of matching clients SampleClients sorts with ORDER BY RAND()
+const sampleSortLimit = 10000
+
+// SampleClients returns up to req.N ids of clients matching req.Filter (all of them without it), drawn
+// at random without repetition.
+// Small matching sets are shuffled whole with ORDER BY RAND(). Larger ones are read in a single pass
+// without sorting, keeping each client with the same probability, tuned to keep about twice req.N
+// clients; the kept ones are then shuffled and cut to req.N. The sample is uniform unless a pass keeps
+// more than 4*req.N+64 clients (the rest of the scan is skipped, favoring the clients read first) or
+// fewer than req.N (a shorter sample is returned), both vanishingly unlikely.
+func (s *Service) SampleClients(ctx context.Context, req *pb.SampleClientsRequest) (*pb.SampleClientsResponse, error) {
+	if req.N <= 0 || req.N > maxSampleClients {
+		return nil, status.Errorf(codes.InvalidArgument, "n must be between 1 and %d", maxSampleClients)
+	}
+	filter := req.Filter
+	if filter == nil {
+		filter = &pb.QueryClientsRequest{}
+	}
+	filtered, _, err := s.filteredClients(filter)
+	if err != nil {
+		return nil, err
+	}
+	q, args, err := filtered.Columns("COUNT(*)").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var count int64
+	if err := s.db.GetContext(ctx, &count, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.SampleClientsResponse{Ids: make([]string, 0)}
+	if count == 0 {
+		return resp, nil
+	}
+
+	if count <= sampleSortLimit {
+		q, args, err = filtered.Columns("id").OrderBy("RAND()").Limit(uint64(req.N)).ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.SelectContext(ctx, &resp.Ids, q, args...); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	// the margin over 2*n makes a short sample unlikely even for a small n
+	p := float64(2*req.N+32) / float64(count)
+	q, args, err = filtered.Columns("id").Where("RAND() < ?", p).Limit(uint64(4*req.N + 64)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.SelectContext(ctx, &resp.Ids, q, args...); err != nil {
+		return nil, err
+	}
+	// the ids come in the order of the scan
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rnd.Shuffle(len(resp.Ids), func(i, j int) {
+		resp.Ids[i], resp.Ids[j] = resp.Ids[j], resp.Ids[i]
+	})
+	if int64(len(resp.Ids)) > req.N {
+		resp.Ids = resp.Ids[:req.N]
+	}
+	return resp, nil
+}
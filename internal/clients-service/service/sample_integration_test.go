@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleBenchmarkClients is the size of the table BenchmarkSampleClients samples from
+const sampleBenchmarkClients = 300000
+
+// BenchmarkSampleClients samples a table with a few hundred thousand clients, created for the run:
+//
+//	TEST_DBCS="user:password@tcp(host:port)/ms_training_test?parseTime=true" go test -tags integration -run '^$' -bench SampleClients ./...
+func BenchmarkSampleClients(b *testing.B) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		b.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(b, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("sample-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	batch := make([]*pb.NewClientRequest, 0, newClientsBatchSize)
+	for i := 0; i < sampleBenchmarkClients; i++ {
+		batch = append(batch, &pb.NewClientRequest{Name: fmt.Sprintf("%s%d", prefix, i), Score: int64(i % 1000)})
+		if len(batch) == cap(batch) || i == sampleBenchmarkClients-1 {
+			_, err := service.NewClients(ctx, &pb.NewClientsRequest{Clients: batch})
+			require.NoError(b, err)
+			batch = batch[:0]
+		}
+	}
+
+	for _, bb := range []struct {
+		name   string
+		filter *pb.QueryClientsRequest
+	}{
+		{"all", nil},
+		{"filtered", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 900, Op: ">="}}},
+		{"small", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 7}}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				resp, err := service.SampleClients(ctx, &pb.SampleClientsRequest{Filter: bb.filter, N: maxSampleClients})
+				require.NoError(b, err)
+				require.NotEmpty(b, resp.Ids)
+			}
+		})
+	}
+}
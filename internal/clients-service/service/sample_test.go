@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSampleClients(t *testing.T) {
+	service, mock := newTestService(t)
+	filter := &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: ">"}}
+
+	// a small matching set is sorted at random
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL AND score > ?")).WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(50))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE deleted_at IS NULL AND score > ? ORDER BY RAND() LIMIT 3")).
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("C").AddRow("A").AddRow("B"))
+	resp, err := service.SampleClients(context.Background(), &pb.SampleClientsRequest{Filter: filter, N: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "A", "B"}, resp.Ids)
+
+	// a large one is read once, keeping each client with the same probability
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(380000))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE deleted_at IS NULL AND RAND() < ? LIMIT 72")).
+		WithArgs(float64(36) / 380000).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A").AddRow("B").AddRow("C").AddRow("D").AddRow("E"))
+	resp, err = service.SampleClients(context.Background(), &pb.SampleClientsRequest{N: 2})
+	require.NoError(t, err)
+	assert.Len(t, resp.Ids, 2)
+	assert.Subset(t, []string{"A", "B", "C", "D", "E"}, resp.Ids)
+	assert.NotEqual(t, resp.Ids[0], resp.Ids[1])
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	resp, err = service.SampleClients(context.Background(), &pb.SampleClientsRequest{N: 2})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Ids)
+
+	for _, n := range []int64{0, -1, maxSampleClients + 1} {
+		_, err = service.SampleClients(context.Background(), &pb.SampleClientsRequest{N: n})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScoreFloorMode is what NewMatch does with a match that would take a client below Config.ScoreFloor
+type ScoreFloorMode string
+
+const (
+	// ScoreFloorOff lets matches take clients below the floor
+	ScoreFloorOff ScoreFloorMode = ""
+	// ScoreFloorClamp records the match with the score that takes the client exactly to the floor
+	ScoreFloorClamp ScoreFloorMode = "clamp"
+	// ScoreFloorReject refuses the match with FailedPrecondition
+	ScoreFloorReject ScoreFloorMode = "reject"
+)
+
+// checkMatchScore validates the score of a single match against the configured range
+func (s *Service) checkMatchScore(field string, score int64) error {
+	min, max := s.config.MinMatchScore, s.config.MaxMatchScore
+	if min == 0 && max == 0 {
+		return nil
+	}
+	if score < min || score > max {
+		return status.Errorf(codes.InvalidArgument, "%s must be between %d and %d", field, min, max)
+	}
+	return nil
+}
+
+// floorScore returns the score a match of delta can add to a client currently at score, according
+// to the configured floor. The clamped score is what gets recorded, so the client score is still
+// the sum of its matches. Clients already below the floor don't go any lower.
+func (s *Service) floorScore(clientID string, score, delta int64) (int64, error) {
+	if s.config.ScoreFloorMode == ScoreFloorOff || delta >= 0 || score+delta >= s.config.ScoreFloor {
+		return delta, nil
+	}
+	if s.config.ScoreFloorMode == ScoreFloorReject {
+		return 0, status.Errorf(codes.FailedPrecondition, "client %s has %d points, a match of %d would take it below %d",
+			clientID, score, delta, s.config.ScoreFloor)
+	}
+	if score <= s.config.ScoreFloor {
+		return 0, nil
+	}
+	return s.config.ScoreFloor - score, nil
+}
+
+// scoreBaseline is the clients.score_baseline of a client set to score: the part of the score its scoring
+// matches don't make up. RecalculateScore adds the matches back to it.
+func (s *Service) scoreBaseline(clientID string, score int64) sq.Sqlizer {
+	matchScore := sq.Select("COALESCE(SUM(score), 0)").From("client_matches").
+		Where(sq.Eq{"client_id": clientID}).Where(s.scoringMatches(""))
+	return sq.Expr("? - (?)", score, matchScore)
+}
+
+// recalculateScoreBatchSize is how many clients each RecalculateScore transaction locks
+const recalculateScoreBatchSize = 500
+
+type scoreCheckRow struct {
+	ID       string        `db:"id"`
+	Score    sql.NullInt64 `db:"score"`
+	NewScore int64         `db:"new_score"`
+}
+
+// RecalculateScore rebuilds clients.score as its score_baseline (the initial score and the matches deleted by the
+// retention) plus the sum of the client matches, for a single client or, in batches of recalculateScoreBatchSize,
+// for all of them
+func (s *Service) RecalculateScore(ctx context.Context, req *pb.RecalculateScoreRequest) (*pb.RecalculateScoreResponse, error) {
+	resp := &pb.RecalculateScoreResponse{Drifts: make([]*pb.ScoreDrift, 0)}
+	if req.ClientId != "" {
+		rows, err := s.recalculateScores(ctx, sq.Eq{"c.id": req.ClientId}, 1, req.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, status.Errorf(codes.NotFound, "client %s not found", req.ClientId)
+		}
+		resp.Checked = 1
+		resp.Drifts = append(resp.Drifts, scoreDrift(rows[0]))
+		return resp, nil
+	}
+	lastID := ""
+	for {
+		rows, err := s.recalculateScores(ctx, sq.Gt{"c.id": lastID}, recalculateScoreBatchSize, req.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if row.Score.Int64 != row.NewScore {
+				resp.Drifts = append(resp.Drifts, scoreDrift(row))
+			}
+		}
+		resp.Checked += int64(len(rows))
+		if len(rows) < recalculateScoreBatchSize {
+			return resp, nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+func scoreDrift(row scoreCheckRow) *pb.ScoreDrift {
+	return &pb.ScoreDrift{
+		ClientId: row.ID,
+		OldScore: row.Score.Int64,
+		NewScore: row.NewScore,
+		Drift:    row.Score.Int64 - row.NewScore,
+	}
+}
+
+// recalculateScores checks, in its own transaction, up to limit clients matching pred in id order,
+// fixing the scores that differ from their baseline and matches unless dryRun is set.
+// The clients are locked, so no match is recorded between the check and the fix.
+func (s *Service) recalculateScores(ctx context.Context, pred sq.Sqlizer, limit uint64, dryRun bool) ([]scoreCheckRow, error) {
+	matchScore := sq.Select("COALESCE(SUM(m.score), 0)").From("client_matches m").
+		Where("m.client_id = c.id").Where(s.scoringMatches("m."))
+	q, args, err := sq.Select("c.id", "c.score").Column(sq.Alias(sq.Expr("c.score_baseline + (?)", matchScore), "new_score")).
+		From("clients c").Where(pred).Where("c.deleted_at IS NULL").
+		OrderBy("c.id").Limit(limit).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows := []scoreCheckRow{}
+	if err := tx.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		for _, row := range rows {
+			if row.Score.Valid && row.Score.Int64 == row.NewScore {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE clients SET score = ?, updated_at = NOW() WHERE id = ?", row.NewScore, row.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecalculateScoreIntegration checks that RecalculateScore keeps the initial score of a client and the
+// score of its matches deleted by the retention, and only fixes what drifted from them
+func TestRecalculateScoreIntegration(t *testing.T) {
+	dbcs := os.Getenv("TEST_DBCS")
+	if dbcs == "" {
+		t.Skip("TEST_DBCS is not set")
+	}
+	db, err := sqlx.Connect("mysql", dbcs)
+	require.NoError(t, err)
+	defer db.Close()
+	service := &Service{db: db, config: Config{}.withDefaults()}
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("recalc-%d-", time.Now().UnixNano())
+	defer db.Exec("DELETE FROM clients WHERE name LIKE ?", prefix+"%")
+	newClient := func(name string, score int64) string {
+		resp, err := service.NewClient(ctx, &pb.NewClientRequest{Name: prefix + name, Score: score})
+		require.NoError(t, err)
+		return resp.Id
+	}
+	newMatch := func(clientID string, score int64) int64 {
+		resp, err := service.NewMatch(ctx, &pb.NewMatchRequest{ClientId: clientID, Score: score})
+		require.NoError(t, err)
+		return resp.Id
+	}
+	recalculate := func(clientID string, dryRun bool) *pb.ScoreDrift {
+		resp, err := service.RecalculateScore(ctx, &pb.RecalculateScoreRequest{ClientId: clientID, DryRun: dryRun})
+		require.NoError(t, err)
+		require.Len(t, resp.Drifts, 1)
+		return resp.Drifts[0]
+	}
+
+	// an initial score and no matches
+	alice := newClient("alice", 50)
+	assert.Equal(t, &pb.ScoreDrift{ClientId: alice, OldScore: 50, NewScore: 50}, recalculate(alice, true))
+
+	// an initial score, a match deleted by the retention and a recent one
+	bob := newClient("bob", 20)
+	old := newMatch(bob, 10)
+	_, err = db.Exec("UPDATE client_matches SET created_at = ? WHERE id = ?", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), old)
+	require.NoError(t, err)
+	_, err = service.DeleteMatchesOlderThan(ctx, &pb.DeleteMatchesOlderThanRequest{Before: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()})
+	require.NoError(t, err)
+	newMatch(bob, 5)
+	assert.Equal(t, &pb.ScoreDrift{ClientId: bob, OldScore: 35, NewScore: 35}, recalculate(bob, true))
+
+	// a score set by hand becomes the new baseline
+	_, err = service.UpdateClient(ctx, &pb.UpdateClientRequest{Id: bob, Score: &pb.OptInt64{Value: 100}})
+	require.NoError(t, err)
+	newMatch(bob, 1)
+	assert.Equal(t, &pb.ScoreDrift{ClientId: bob, OldScore: 101, NewScore: 101}, recalculate(bob, true))
+
+	// a drift is fixed back to the baseline plus the matches
+	_, err = db.Exec("UPDATE clients SET score = 0 WHERE id = ?", bob)
+	require.NoError(t, err)
+	assert.Equal(t, &pb.ScoreDrift{ClientId: bob, OldScore: 0, NewScore: 101, Drift: -101}, recalculate(bob, false))
+	client, err := service.GetClient(ctx, &pb.GetClientRequest{Id: bob})
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), client.Client.Score)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const scoreCheckSQL = "SELECT c.id, c.score, (c.score_baseline + (SELECT COALESCE(SUM(m.score), 0) FROM client_matches m WHERE m.client_id = c.id)) AS new_score " +
+	"FROM clients c WHERE "
+
+func TestRecalculateScore(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"id", "score", "new_score"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(scoreCheckSQL + "c.id = ? AND c.deleted_at IS NULL ORDER BY c.id LIMIT 1 FOR UPDATE")).
+		WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows(cols).AddRow("MOCKID", 50, 42))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(42), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.RecalculateScore(context.Background(), &pb.RecalculateScoreRequest{ClientId: "MOCKID"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Checked)
+	assert.Equal(t, []*pb.ScoreDrift{{ClientId: "MOCKID", OldScore: 50, NewScore: 42, Drift: 8}}, resp.Drifts)
+
+	// dry run: nothing is written
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(scoreCheckSQL)).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("MOCKID", 50, 42))
+	mock.ExpectCommit()
+	resp, err = service.RecalculateScore(context.Background(), &pb.RecalculateScoreRequest{ClientId: "MOCKID", DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), resp.Drifts[0].Drift)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(scoreCheckSQL)).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows(cols))
+	mock.ExpectCommit()
+	_, err = service.RecalculateScore(context.Background(), &pb.RecalculateScoreRequest{ClientId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecalculateScoreAll(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"id", "score", "new_score"}
+	batchSQL := regexp.QuoteMeta(scoreCheckSQL + fmt.Sprintf("c.id > ? AND c.deleted_at IS NULL ORDER BY c.id LIMIT %d FOR UPDATE", recalculateScoreBatchSize))
+
+	// a full batch, then the remaining clients in their own transaction
+	first := sqlmock.NewRows(cols)
+	for i := 0; i < recalculateScoreBatchSize; i++ {
+		first.AddRow(fmt.Sprintf("C%04d", i), 10, 10)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(batchSQL).WithArgs("").WillReturnRows(first)
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery(batchSQL).WithArgs(fmt.Sprintf("C%04d", recalculateScoreBatchSize-1)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("D0001", 7, 3).AddRow("D0002", nil, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(3), "D0001").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(0), "D0002").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.RecalculateScore(context.Background(), &pb.RecalculateScoreRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(recalculateScoreBatchSize+2), resp.Checked)
+	assert.Equal(t, []*pb.ScoreDrift{{ClientId: "D0001", OldScore: 7, NewScore: 3, Drift: 4}}, resp.Drifts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFloorScore(t *testing.T) {
+	tests := []struct {
+		mode         ScoreFloorMode
+		score, delta int64
+		want         int64
+		code         codes.Code
+	}{
+		{ScoreFloorOff, 10, -30, -30, codes.OK},
+		{ScoreFloorClamp, 10, 5, 5, codes.OK},
+		{ScoreFloorClamp, 10, -10, -10, codes.OK},
+		{ScoreFloorClamp, 10, -30, -10, codes.OK},
+		{ScoreFloorClamp, -5, -3, 0, codes.OK},
+		{ScoreFloorReject, 10, -10, -10, codes.OK},
+		{ScoreFloorReject, 10, -11, 0, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		service := &Service{config: Config{ScoreFloorMode: tt.mode}}
+		got, err := service.floorScore("MOCKID", tt.score, tt.delta)
+		assert.Equal(t, tt.code, status.Code(err), "%+v", tt)
+		assert.Equal(t, tt.want, got, "%+v", tt)
+	}
+}
+
+func TestMatchScoreBounds(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.MinMatchScore, service.config.MaxMatchScore = -10, 100
+
+	_, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 101})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: []*pb.NewMatchRequest{
+		{ClientId: "MOCKID", Score: 5},
+		{ClientId: "MOCKID", Score: -11},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "matches[1].score")
+
+	// clamped: the match is recorded with the score that takes the client to the floor
+	service.config.ScoreFloorMode = ScoreFloorClamp
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "score"}).AddRow("ACTIVE", 4))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_matches (client_id,score,season_id) VALUES (?,?,(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1))")).
+		WithArgs("MOCKID", int64(-4)).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("UPDATE clients SET score = score").WithArgs(int64(-4), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT created_at FROM client_matches").WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+	mock.ExpectCommit()
+	resp, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: -10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-4), resp.Score)
+
+	// within a batch, later matches see the score left by the earlier ones
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, status, score FROM clients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "score"}).AddRow("MOCKID", "ACTIVE", 12))
+	mock.ExpectExec("INSERT INTO client_matches").WithArgs("MOCKID", int64(-10), nil, nil, "RANKED").
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec("INSERT INTO client_matches").WithArgs("MOCKID", int64(-2), nil, nil, "RANKED").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("UPDATE clients SET score = score \\+ CASE").WithArgs("MOCKID", int64(-12), "MOCKID").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: []*pb.NewMatchRequest{
+		{ClientId: "MOCKID", Score: -10},
+		{ClientId: "MOCKID", Score: -10},
+	}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScoreFloorConcurrent(t *testing.T) {
+	db := newMatchesDB()
+	db.clients["MOCKID"] = &fakeClient{status: "ACTIVE", score: 40}
+	service := db.service()
+	service.config.ScoreFloorMode = ScoreFloorReject
+
+	// two matches of -30 on a client with 40 points: the row lock makes the second one see the 10
+	// points left by the first, so it is refused instead of both going through
+	errs := make([]error, 2)
+	concurrently(len(errs), func(i int) {
+		_, errs[i] = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: -30})
+	})
+	assert.ElementsMatch(t, []codes.Code{codes.OK, codes.FailedPrecondition}, []codes.Code{status.Code(errs[0]), status.Code(errs[1])})
+	assert.Len(t, db.matches, 1)
+	assert.Equal(t, int64(10), db.clients["MOCKID"].score)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSeasonIDLength is the size of the seasons.id column
+const maxSeasonIDLength = 64
+
+// currentSeason is the season new matches accrue to: the last one started, or none before the first
+var currentSeason = sq.Expr("(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1)")
+
+// StartSeason starts a new season, so the matches recorded from now on accrue to it.
+// The all-time client scores are kept; the season totals come from the matches of the season.
+func (s *Service) StartSeason(ctx context.Context, req *pb.StartSeasonRequest) (*pb.StartSeasonResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if len(req.Id) > maxSeasonIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "id is longer than %d", maxSeasonIDLength)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO seasons (id) VALUES (?)", req.Id); err != nil {
+		if isDuplicateKey(err, "PRIMARY") {
+			return nil, status.Errorf(codes.AlreadyExists, "season %s already exists", req.Id)
+		}
+		return nil, err
+	}
+	var startedAt time.Time
+	if err := tx.GetContext(ctx, &startedAt, "SELECT started_at FROM seasons WHERE id = ?", req.Id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.StartSeasonResponse{Season: &pb.Season{Id: req.Id, StartedAt: startedAt.UnixNano()}}, nil
+}
+
+// checkSeason returns NotFound if a season filter names a season that was never started, instead of
+// letting it match no matches at all. An empty id is no filter.
+func checkSeason(ctx context.Context, db sqlx.QueryerContext, id string) error {
+	if id == "" {
+		return nil
+	}
+	var startedAt time.Time
+	if err := sqlx.GetContext(ctx, db, &startedAt, "SELECT started_at FROM seasons WHERE id = ?", id); err != nil {
+		if err == sql.ErrNoRows {
+			return status.Errorf(codes.NotFound, "season %s not found", id)
+		}
+		return err
+	}
+	return nil
+}
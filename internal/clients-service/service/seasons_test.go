@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStartSeason(t *testing.T) {
+	service, mock := newTestService(t)
+	startedAt := time.Unix(0, time.Now().UnixNano())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO seasons (id) VALUES (?)")).WithArgs("2026-Q4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT started_at FROM seasons WHERE id = ?")).WithArgs("2026-Q4").
+		WillReturnRows(sqlmock.NewRows([]string{"started_at"}).AddRow(startedAt))
+	mock.ExpectCommit()
+	resp, err := service.StartSeason(context.Background(), &pb.StartSeasonRequest{Id: "2026-Q4"})
+	require.NoError(t, err)
+	assert.Equal(t, &pb.Season{Id: "2026-Q4", StartedAt: startedAt.UnixNano()}, resp.Season)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO seasons").WithArgs("2026-Q4").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '2026-Q4' for key 'PRIMARY'"})
+	mock.ExpectRollback()
+	_, err = service.StartSeason(context.Background(), &pb.StartSeasonRequest{Id: "2026-Q4"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = service.StartSeason(context.Background(), &pb.StartSeasonRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.StartSeason(context.Background(), &pb.StartSeasonRequest{Id: strings.Repeat("s", 65)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectSeason(mock sqlmock.Sqlmock, id string, exists bool) {
+	rows := sqlmock.NewRows([]string{"started_at"})
+	if exists {
+		rows.AddRow(time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT started_at FROM seasons WHERE id = ?")).WithArgs(id).WillReturnRows(rows)
+}
+
+func TestSeasonFilters(t *testing.T) {
+	service, mock := newTestService(t)
+
+	expectSeason(mock, "2026-Q4", true)
+	mock.ExpectQuery(regexp.QuoteMeta(", s.season_score FROM `clients` " +
+		"JOIN (SELECT client_id, SUM(score) AS season_score FROM client_matches WHERE season_id = ? GROUP BY client_id) s " +
+		"ON s.client_id = `clients`.id WHERE deleted_at IS NULL " +
+		"ORDER BY s.season_score DESC, created_at ASC, id ASC LIMIT 10 OFFSET 0")).
+		WithArgs("2026-Q4").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "season_score"}).
+			AddRow("BOB", "Bob", 10, 25).
+			AddRow("ALICE", "Alice", 90, 12))
+	expectClientTags(mock)
+	resp, err := service.GetLeaderboard(context.Background(), &pb.GetLeaderboardRequest{SeasonId: "2026-Q4", IncludeRank: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	assert.Equal(t, "BOB", resp.Entries[0].Client.Id)
+	assert.Equal(t, int64(25), resp.Entries[0].SeasonScore)
+	assert.Equal(t, int64(10), resp.Entries[0].Client.Score)
+	assert.Equal(t, int64(2), resp.Entries[1].Rank)
+
+	expectSeason(mock, "2026-Q4", true)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE m.played_at >= ? AND m.played_at < ? AND m.season_id = ? AND c.deleted_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "2026-Q4").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "period_score"}))
+	_, err = service.GetLeaderboardForPeriod(context.Background(), &pb.GetLeaderboardForPeriodRequest{SeasonId: "2026-Q4"})
+	require.NoError(t, err)
+
+	expectSeason(mock, "2026-Q4", true)
+	mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN client_matches m ON m.client_id = c.id AND m.match_type IN (?) AND m.season_id = ? "+
+		"WHERE c.id IN (?,?)")).
+		WithArgs("RANKED", "2026-Q4", "ALICE", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "match_count", "total_score"}).
+			AddRow("ALICE", 2, 12).AddRow("BOB", 0, 0))
+	stats, err := service.GetClientsStats(context.Background(), &pb.GetClientsStatsRequest{
+		ClientIds:  []string{"ALICE", "BOB"},
+		MatchTypes: []pb.MatchType{pb.MatchType_RANKED},
+		SeasonId:   "2026-Q4",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), stats.Stats["ALICE"].TotalScore)
+
+	// an unknown season is an error, not an empty leaderboard
+	expectSeason(mock, "2016-Q4", false)
+	_, err = service.GetLeaderboard(context.Background(), &pb.GetLeaderboardRequest{SeasonId: "2016-Q4"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	expectSeason(mock, "2016-Q4", false)
+	_, err = service.GetClientStats(context.Background(), &pb.GetClientStatsRequest{ClientId: "ALICE", SeasonId: "2016-Q4"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,41 +3,227 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/protobuf/proto"
 	"github.com/jmoiron/sqlx"
 	"github.com/pedidopago/trainingsvc-clients/protos/pb"
 	"github.com/pedidopago/trainingsvc-clients/utils"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
 	DBCS string
+	// IdempotencyKeyTTL is how long a NewClient idempotency key is remembered (default 24h)
+	IdempotencyKeyTTL time.Duration
+	// UniqueNames makes NewClient, NewClients and UpdateClient refuse names already used by another client
+	UniqueNames bool
+	// PhoneCountryCode is prefixed to phone numbers given without a country code (default "55")
+	PhoneCountryCode string
+	// MetadataMaxKeys is the max number of metadata entries of a client (default 32)
+	MetadataMaxKeys int
+	// MetadataMaxBytes is the max total size of the metadata keys and values of a client (default 4096)
+	MetadataMaxBytes int
+	// NotesMaxLength is the max size in bytes of the notes of a client (default 2048)
+	NotesMaxLength int
+	// MaxClients is the max number of (not deleted) clients, 0 means unlimited
+	MaxClients int
+	// ArchiveRetention is how long copies of deleted clients are kept in clients_archive (default 12 months)
+	ArchiveRetention time.Duration
+	// MinMatchScore and MaxMatchScore bound the score of a single match; both 0 means unbounded
+	MinMatchScore int64
+	MaxMatchScore int64
+	// ScoreFloor is the lowest score a match can take a client to, enforced as set by ScoreFloorMode
+	ScoreFloor     int64
+	ScoreFloorMode ScoreFloorMode
+	// ExcludePracticeScore keeps the PRACTICE matches out of clients.score; they are still recorded
+	ExcludePracticeScore bool
+	// MatchRetention is how long matches are kept; older ones are deleted every hour. 0 keeps them forever
+	MatchRetention time.Duration
+	// MatchRetentionPause is the pause between the batches of a matches deletion (default 100ms)
+	MatchRetentionPause time.Duration
+	// QueryClientsMaxLimit is the largest page of ids QueryClients returns (default 1000)
+	QueryClientsMaxLimit int64
+	// FullTextSearch makes the QueryClients search use the FULLTEXT index of clients.name; without it
+	// (for backends with no fulltext support) every word of the search is matched with LIKE
+	FullTextSearch bool
+	// GetClientsChunkSize is the max number of ids read by each query of GetClients (default 1000)
+	GetClientsChunkSize int
+	// GetClientsMaxIDs is the max number of ids of a GetClients request (default 100000)
+	GetClientsMaxIDs int
+	// RequireQueryFilters refuses the QueryClients requests without filters, unless they set allow_unfiltered
+	RequireQueryFilters bool
+	// SortStreamMaxBytes is about the most memory the items of a SortStream call can take (default 256MiB)
+	SortStreamMaxBytes int
+	// HealthCheckInterval is how often the database is pinged for the gRPC health service (default 10s)
+	HealthCheckInterval time.Duration
+	// Metrics, when set, get the stats of the database pool every DBStatsInterval (default 15s). Their
+	// ServerOptions must also be given to the server for the RPC metrics.
+	Metrics         *Metrics
+	DBStatsInterval time.Duration
+	// TracerProvider gets the spans of the SQL statements, the global provider being used when it's nil.
+	// The spans of the RPCs need the TracingServerOptions given to the server.
+	TracerProvider trace.TracerProvider
+	// RateLimit is the max calls per second of each method, in bursts of up to a second of calls, 0 meaning
+	// unlimited. RateLimits overrides it for some methods by name ("DeleteAllClients"), 0 or less taking
+	// them off any limit. The calls over the limits get a ResourceExhausted error.
+	RateLimit  float64
+	RateLimits map[string]float64
+	// APIKeys, when set, are required in the authorization metadata of every call of the clients service,
+	// the calls without one of them getting an Unauthenticated error. The health and reflection services
+	// stay open.
+	APIKeys *APIKeys
 }
 
-func New(ctx context.Context, sv *grpc.Server, config Config) error {
+func (c Config) withDefaults() Config {
+	if c.IdempotencyKeyTTL <= 0 {
+		c.IdempotencyKeyTTL = 24 * time.Hour
+	}
+	if c.PhoneCountryCode == "" {
+		c.PhoneCountryCode = "55"
+	}
+	if c.MetadataMaxKeys <= 0 {
+		c.MetadataMaxKeys = 32
+	}
+	if c.MetadataMaxBytes <= 0 {
+		c.MetadataMaxBytes = 4096
+	}
+	if c.NotesMaxLength <= 0 {
+		c.NotesMaxLength = 2048
+	}
+	if c.ArchiveRetention <= 0 {
+		c.ArchiveRetention = 365 * 24 * time.Hour
+	}
+	if c.QueryClientsMaxLimit <= 0 {
+		c.QueryClientsMaxLimit = 1000
+	}
+	if c.GetClientsChunkSize <= 0 {
+		c.GetClientsChunkSize = 1000
+	}
+	if c.GetClientsMaxIDs <= 0 {
+		c.GetClientsMaxIDs = 100000
+	}
+	if c.SortStreamMaxBytes <= 0 {
+		c.SortStreamMaxBytes = 256 << 20
+	}
+	if c.HealthCheckInterval <= 0 {
+		c.HealthCheckInterval = 10 * time.Second
+	}
+	if c.DBStatsInterval <= 0 {
+		c.DBStatsInterval = 15 * time.Second
+	}
+	if c.MatchRetentionPause <= 0 {
+		c.MatchRetentionPause = 100 * time.Millisecond
+	}
+	return c
+}
+
+// Option sets up New beyond its Config, for what it registers on the server besides the clients service
+type Option func(*options)
+
+type options struct {
+	reflection bool
+	setServing *func(serving bool)
+}
+
+// WithReflection registers the gRPC server reflection service when enabled, so that tools as grpcurl work
+// without the proto files. It's meant for debugging, and off by default.
+func WithReflection(enabled bool) Option {
+	return func(o *options) {
+		o.reflection = enabled
+	}
+}
+
+// WithServingSetter has New set *setServing to the Service.SetClientsServing of the service it starts, for
+// the caller to take pb.ClientsService out of service on the health service (as before a shutdown).
+func WithServingSetter(setServing *func(serving bool)) Option {
+	return func(o *options) {
+		o.setServing = setServing
+	}
+}
+
+func New(ctx context.Context, sv *grpc.Server, config Config, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch config.ScoreFloorMode {
+	case ScoreFloorOff, ScoreFloorClamp, ScoreFloorReject:
+	default:
+		return fmt.Errorf("invalid score floor mode %q", config.ScoreFloorMode)
+	}
+	if config.MinMatchScore > config.MaxMatchScore {
+		return fmt.Errorf("min match score %d is greater than the max %d", config.MinMatchScore, config.MaxMatchScore)
+	}
+
+	limiter, err := newRateLimiter(config.RateLimit, config.RateLimits)
+	if err != nil {
+		return err
+	}
 
-	svc := &Service{}
+	svc := &Service{
+		config:  config.withDefaults(),
+		health:  newServiceHealth(),
+		limiter: limiter,
+	}
 
-	// database connection
-	db, err := sqlx.Open("mysql", config.DBCS)
+	// database connection, tracing every statement
+	connector, err := mysql.MySQLDriver{}.OpenConnector(config.DBCS)
 	if err != nil {
 		return err
 	}
-	svc.db = db
+	tracer := tracerProvider(config.TracerProvider).Tracer(tracerName)
+	svc.db = sqlx.NewDb(sql.OpenDB(tracedConnector{Connector: connector, tracer: tracer}), "mysql")
 
 	go svc.cleanup(ctx) // executa antes de fechar o app
+	go svc.expireIdempotencyKeys(ctx)
+	go svc.expireArchivedClients(ctx)
+	if svc.config.MatchRetention > 0 {
+		go svc.expireMatches(ctx)
+	}
+	go svc.pingDB(ctx)
+	if svc.config.Metrics != nil {
+		go svc.refreshDBStats(ctx)
+	}
 
-	pb.RegisterClientsServiceServer(sv, svc)
+	registerClientsService(sv, svc)
+	healthpb.RegisterHealthServer(sv, svc.health.server)
+	if o.reflection {
+		reflection.Register(sv)
+	}
+	if o.setServing != nil {
+		*o.setServing = svc.SetClientsServing
+	}
 
 	return nil
 }
 
 type Service struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	config  Config
+	health  *serviceHealth
+	limiter *rateLimiter
 }
 
 func (s *Service) cleanup(ctx context.Context) {
@@ -45,11 +231,248 @@ func (s *Service) cleanup(ctx context.Context) {
 	s.db.Close()
 }
 
+// expireIdempotencyKeys periodically removes the idempotency keys older than the configured TTL
+func (s *Service) expireIdempotencyKeys(ctx context.Context) {
+	ticker := time.NewTicker(s.config.IdempotencyKeyTTL / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.db.ExecContext(ctx, "DELETE FROM client_idempotency_keys WHERE created_at < ?",
+				time.Now().Add(-s.config.IdempotencyKeyTTL))
+		}
+	}
+}
+
 var _ pb.ClientsServiceServer = (*Service)(nil) // compile time check if we support the public proto interface
 
-// NewClient creates a new client on the database
+// clientColumns are the columns scanned into a clientRow
+var clientColumns = []string{"id", "name", "birthday", "score", "created_at", "email", "phone", "metadata", "version", "updated_at", "status", "notes", "external_id", "anonymized_at", "last_seen_at"}
+
+// clientRow is a row of the clients table
+type clientRow struct {
+	ID         string         `db:"id"`
+	Name       string         `db:"name"`
+	Birthday   sql.NullTime   `db:"birthday"`
+	Score      sql.NullInt64  `db:"score"`
+	CreatedAt  sql.NullTime   `db:"created_at"`
+	Email      sql.NullString `db:"email"`
+	Phone      sql.NullString `db:"phone"`
+	Metadata   sql.NullString `db:"metadata"`
+	Version    int64          `db:"version"`
+	UpdatedAt  sql.NullTime   `db:"updated_at"`
+	Status     string         `db:"status"`
+	Notes      sql.NullString `db:"notes"`
+	ExternalID sql.NullString `db:"external_id"`
+	Anonymized sql.NullTime   `db:"anonymized_at"`
+	LastSeenAt sql.NullTime   `db:"last_seen_at"`
+}
+
+func (r clientRow) toPB() *pb.Client {
+	var metadata map[string]string
+	if r.Metadata.Valid {
+		_ = json.Unmarshal([]byte(r.Metadata.String), &metadata)
+	}
+	return &pb.Client{
+		Id:         r.ID,
+		Name:       r.Name,
+		Birthday:   unixNano(r.Birthday),
+		Score:      r.Score.Int64,
+		CreatedAt:  unixNano(r.CreatedAt),
+		Email:      r.Email.String,
+		Phone:      r.Phone.String,
+		Metadata:   metadata,
+		Version:    r.Version,
+		UpdatedAt:  unixNano(r.UpdatedAt),
+		Status:     pb.ClientStatus(pb.ClientStatus_value[r.Status]),
+		Notes:      r.Notes.String,
+		ExternalId: r.ExternalID.String,
+		Anonymized: r.Anonymized.Valid,
+		LastSeenAt: unixNano(r.LastSeenAt),
+	}
+}
+
+// unixNano returns t as unixnano, or 0 for NULL (or a column that wasn't selected)
+func unixNano(t sql.NullTime) int64 {
+	if !t.Valid {
+		return 0
+	}
+	return t.Time.UnixNano()
+}
+
+// maybeDeletedClientRow is a clientRow read with its deleted_at, for the requests that include deleted clients
+type maybeDeletedClientRow struct {
+	clientRow
+	DeletedAt sql.NullTime `db:"deleted_at"`
+}
+
+func (r maybeDeletedClientRow) toPB() *pb.Client {
+	client := r.clientRow.toPB()
+	client.DeletedAt = unixNano(r.DeletedAt)
+	return client
+}
+
+// clientExistsError returns an AlreadyExists status carrying the id of the conflicting client
+func clientExistsError(id, format string, a ...interface{}) error {
+	st, err := status.New(codes.AlreadyExists, fmt.Sprintf(format, a...)).WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "client",
+		ResourceName: id,
+	})
+	if err != nil {
+		return status.Errorf(codes.AlreadyExists, format, a...)
+	}
+	return st.Err()
+}
+
+// checkNameAvailable returns an AlreadyExists status if a client other than id is named name.
+// The matching index range is locked, so concurrent transactions can't take the name until tx ends.
+func checkNameAvailable(ctx context.Context, tx *sqlx.Tx, name, id string) error {
+	var existingID string
+	err := tx.GetContext(ctx, &existingID, "SELECT id FROM clients WHERE name = ? AND id != ? AND deleted_at IS NULL LIMIT 1 FOR UPDATE", name, id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return clientExistsError(existingID, "name %q is already used by client %s", name, existingID)
+}
+
+// isDuplicateKey checks if err is a mysql duplicate entry error on the unique key named key
+func isDuplicateKey(err error, key string) bool {
+	var merr *mysql.MySQLError
+	if !errors.As(err, &merr) || merr.Number != 1062 {
+		return false
+	}
+	return strings.Contains(merr.Message, key+"'")
+}
+
+// normalizePhone converts v to E.164, returning an InvalidArgument status naming field if it isn't a phone number
+func (s *Service) normalizePhone(field, v string) (string, error) {
+	phone, err := utils.NormalizePhone(v, s.config.PhoneCountryCode)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return phone, nil
+}
+
+// metadataValue validates the size of m and encodes it for the metadata column, as NULL if it is empty
+func (s *Service) metadataValue(field string, m map[string]string) (interface{}, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	if len(m) > s.config.MetadataMaxKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "%s has more than %d keys", field, s.config.MetadataMaxKeys)
+	}
+	size := 0
+	for k, v := range m {
+		size += len(k) + len(v)
+	}
+	if size > s.config.MetadataMaxBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is larger than %d bytes", field, s.config.MetadataMaxBytes)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// notesValue validates the size of notes, returning NULL for empty notes
+func (s *Service) notesValue(field, notes string) (interface{}, error) {
+	if notes == "" {
+		return nil, nil
+	}
+	if len(notes) > s.config.NotesMaxLength {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is larger than %d bytes", field, s.config.NotesMaxLength)
+	}
+	return notes, nil
+}
+
+// valueInUseError returns an AlreadyExists status carrying the id of the client using value
+// in the unique column
+func valueInUseError(ctx context.Context, db sqlx.QueryerContext, column, value string) error {
+	var existingID string
+	if err := sqlx.GetContext(ctx, db, &existingID, "SELECT id FROM clients WHERE "+column+" = ?", value); err != nil {
+		return status.Errorf(codes.AlreadyExists, "%s %q is already in use", column, value)
+	}
+	return clientExistsError(existingID, "%s %q is already used by client %s", column, value, existingID)
+}
+
+// getClient reads a single client, returning a NotFound status if it does not exist
+func getClient(ctx context.Context, db sqlx.QueryerContext, id string) (*pb.Client, error) {
+	return findClient(ctx, db, sq.Eq{"id": id}, "client "+id+" not found")
+}
+
+// findClient reads the client matching pred, returning a NotFound status with notFoundMsg if there is none
+func findClient(ctx context.Context, db sqlx.QueryerContext, pred sq.Eq, notFoundMsg string) (*pb.Client, error) {
+	q, args, err := sq.Select(clientColumns...).From("`clients`").
+		Where(pred).Where("deleted_at IS NULL").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	row := clientRow{}
+	if err := sqlx.GetContext(ctx, db, &row, q, args...); err != nil {
+		return nil, notFoundOr(err, notFoundMsg)
+	}
+	client := row.toPB()
+	if err := loadClientTags(ctx, db, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// notFoundOr converts sql.ErrNoRows into a NotFound status with msg, returning other errors as they are
+func notFoundOr(err error, msg string) error {
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, msg)
+	}
+	return err
+}
+
+// maxIdempotencyKeyLength is the size of the client_idempotency_keys.idem_key column
+const maxIdempotencyKeyLength = 64
+
+// maxExternalIDLength is the size of the clients.external_id column
+const maxExternalIDLength = 64
+
+// NewClient creates a new client on the database, with req.Id if set or a new SecureID otherwise.
+// If req.IdempotencyKey was already used within the configured TTL, the client created by
+// that request is returned instead.
 func (s *Service) NewClient(ctx context.Context, req *pb.NewClientRequest) (*pb.NewClientResponse, error) {
-	id := utils.SecureID().String()
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency_key is longer than %d", maxIdempotencyKeyLength)
+	}
+	if req.Email != "" && !utils.IsEmailValid(req.Email) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid email %q", req.Email)
+	}
+	if len(req.ExternalId) > maxExternalIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "external_id is longer than %d", maxExternalIDLength)
+	}
+	if req.Id != "" && !utils.IsIDValid(req.Id) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %q", req.Id)
+	}
+	var phone string
+	if req.Phone != "" {
+		var err error
+		if phone, err = s.normalizePhone("phone", req.Phone); err != nil {
+			return nil, err
+		}
+	}
+	metadata, err := s.metadataValue("metadata", req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.notesValue("notes", req.Notes)
+	if err != nil {
+		return nil, err
+	}
+	id := req.Id
+	if id == "" {
+		id = utils.SecureID().String()
+	}
 
 	cols := make([]string, 0)
 	vals := make([]interface{}, 0)
@@ -60,143 +483,2137 @@ func (s *Service) NewClient(ctx context.Context, req *pb.NewClientRequest) (*pb.
 		cols, vals = append(cols, "birthday"), append(vals, time.Unix(0, req.Birthday))
 	}
 	cols, vals = append(cols, "score"), append(vals, req.Score)
+	cols, vals = append(cols, "score_baseline"), append(vals, req.Score)
+	if req.Email != "" {
+		cols, vals = append(cols, "email"), append(vals, req.Email)
+	}
+	if phone != "" {
+		cols, vals = append(cols, "phone"), append(vals, phone)
+	}
+	if metadata != nil {
+		cols, vals = append(cols, "metadata"), append(vals, metadata)
+	}
+	if notes != nil {
+		cols, vals = append(cols, "notes"), append(vals, notes)
+	}
+	if req.ExternalId != "" {
+		cols, vals = append(cols, "external_id"), append(vals, req.ExternalId)
+	}
+	cols, vals = append(cols, "updated_at"), append(vals, sq.Expr("NOW()"))
 
 	q, args, err := sq.Insert("clients").Columns(cols...).Values(vals...).ToSql()
 	if err != nil {
 		return nil, err
 	}
-	_, err = s.db.ExecContext(ctx, q, args...)
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	return &pb.NewClientResponse{
-		Id: id,
-	}, nil
-}
-
-func (s *Service) QueryClients(ctx context.Context, req *pb.QueryClientsRequest) (*pb.QueryClientsResponse, error) {
-	rq := sq.Select("id").From("clients")
-	if req.Id != nil {
-		rq = rq.Where("id", req.Id.Value)
-	}
-	if req.Name != nil {
-		rq = rq.Where("name LIKE ?", req.Name.Value)
+	if req.IdempotencyKey != "" {
+		existingID, err := claimIdempotencyKey(ctx, tx, req.IdempotencyKey, id, s.config.IdempotencyKeyTTL)
+		if err != nil {
+			return nil, err
+		}
+		if existingID != "" {
+			client, err := getClient(ctx, tx, existingID)
+			if err != nil {
+				return nil, err
+			}
+			if err := tx.Commit(); err != nil {
+				return nil, err
+			}
+			return &pb.NewClientResponse{
+				Id:     existingID,
+				Client: client,
+			}, nil
+		}
 	}
-	if req.Birthday != nil {
-		rq = req.Birthday.Where("birthday", rq)
+
+	if s.config.UniqueNames {
+		if err := checkNameAvailable(ctx, tx, req.Name, id); err != nil {
+			return nil, err
+		}
 	}
-	if req.Score != nil {
-		rq = req.Score.Where("score", rq)
+	if err := s.checkClientQuota(ctx, tx, 1); err != nil {
+		return nil, err
 	}
 
-	if req.CreatedAt != nil {
-		rq = req.CreatedAt.Where("created_at", rq)
+	_, err = tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		if isDuplicateKey(err, "uniq_email") {
+			return nil, valueInUseError(ctx, tx, "email", req.Email)
+		}
+		if isDuplicateKey(err, "uniq_external_id") {
+			return nil, valueInUseError(ctx, tx, "external_id", req.ExternalId)
+		}
+		if isDuplicateKey(err, "PRIMARY") {
+			return nil, clientExistsError(id, "client %s already exists", id)
+		}
+		return nil, err
 	}
-
-	rq = rq.OrderBy("score DESC")
-
-	q, args, err := rq.ToSql()
+	// read back what was persisted (created_at default, birthday truncated by the column type)
+	client, err := getClient(ctx, tx, id)
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]string, 0)
-	if err := s.db.SelectContext(ctx, &ids, q, args...); err != nil {
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	return &pb.QueryClientsResponse{
-		Ids: ids,
+	return &pb.NewClientResponse{
+		Id:     id,
+		Client: client,
 	}, nil
 }
 
-func (s *Service) GetClients(ctx context.Context, req *pb.GetClientsRequest) (*pb.GetClientsResponse, error) {
-	ifids := make([]interface{}, 0, len(req.Ids))
-	for _, v := range req.Ids {
-		ifids = append(ifids, v)
+// claimIdempotencyKey stores key for the client id inside tx. If the key is already taken by a
+// non expired entry, the id of the client it was stored for is returned.
+// The key is the primary key, so a concurrent claim blocks until tx finishes and then sees its id.
+func claimIdempotencyKey(ctx context.Context, tx *sqlx.Tx, key, id string, ttl time.Duration) (string, error) {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM client_idempotency_keys WHERE idem_key = ? AND created_at < ?",
+		key, time.Now().Add(-ttl)); err != nil {
+		return "", err
 	}
-	q, args, err := sq.Select("id", "name", "birthday", "score", "created_at").From("`clients`").
-		Where(fmt.Sprintf("id IN (%s)", sq.Placeholders(len(ifids))), ifids...).ToSql()
+	result, err := tx.ExecContext(ctx, "INSERT INTO client_idempotency_keys (idem_key, client_id) VALUES (?, ?) "+
+		"ON DUPLICATE KEY UPDATE idem_key = idem_key", key, id)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	rawclients := []struct {
-		ID        string        `db:"id"`
-		Name      string        `db:"name"`
-		Birthday  sql.NullTime  `db:"birthday"`
-		Score     sql.NullInt64 `db:"score"`
-		CreatedAt sql.NullTime  `db:"created_at"`
-	}{}
-	if err := s.db.SelectContext(ctx, &rawclients, q, args...); err != nil {
-		return nil, err
+	if n, err := result.RowsAffected(); err != nil {
+		return "", err
+	} else if n > 0 {
+		return "", nil
 	}
-	resp := &pb.GetClientsResponse{
-		Clients: make([]*pb.Client, 0, len(rawclients)),
+	var existingID string
+	if err := tx.GetContext(ctx, &existingID, "SELECT client_id FROM client_idempotency_keys WHERE idem_key = ? FOR UPDATE", key); err != nil {
+		return "", err
+	}
+	return existingID, nil
+}
+
+// newClientsBatchSize is the max number of rows inserted by a single statement in NewClients
+const newClientsBatchSize = 500
+
+// NewClients creates many clients using multi-row inserts of up to newClientsBatchSize rows.
+// Each batch is a single statement, so a batch failing on a duplicate email, external id or id
+// leaves none of its rows behind; the indexes of its clients are reported in FailedIndexes and
+// the other batches proceed. Any other error fails the whole call, keeping the batches inserted before it.
+// With UniqueNames, the clients named like an existing client or an earlier client of the request
+// are left out of their batch and reported in FailedIndexes too.
+// A batch that would exceed MaxClients fails the whole call too.
+func (s *Service) NewClients(ctx context.Context, req *pb.NewClientsRequest) (*pb.NewClientsResponse, error) {
+	phones := make([]interface{}, len(req.Clients))
+	metadata := make([]interface{}, len(req.Clients))
+	notes := make([]interface{}, len(req.Clients))
+	for i, c := range req.Clients {
+		if c.Id != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "clients[%d]: id is only supported by NewClient", i)
+		}
+		if c.IdempotencyKey != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "clients[%d]: idempotency_key is only supported by NewClient", i)
+		}
+		if c.Email != "" && !utils.IsEmailValid(c.Email) {
+			return nil, status.Errorf(codes.InvalidArgument, "clients[%d]: invalid email %q", i, c.Email)
+		}
+		if len(c.ExternalId) > maxExternalIDLength {
+			return nil, status.Errorf(codes.InvalidArgument, "clients[%d]: external_id is longer than %d", i, maxExternalIDLength)
+		}
+		if c.Phone != "" {
+			phone, err := s.normalizePhone(fmt.Sprintf("clients[%d].phone", i), c.Phone)
+			if err != nil {
+				return nil, err
+			}
+			phones[i] = phone
+		}
+		var err error
+		if metadata[i], err = s.metadataValue(fmt.Sprintf("clients[%d].metadata", i), c.Metadata); err != nil {
+			return nil, err
+		}
+		if notes[i], err = s.notesValue(fmt.Sprintf("clients[%d].notes", i), c.Notes); err != nil {
+			return nil, err
+		}
+	}
+	resp := &pb.NewClientsResponse{
+		Ids:           make([]string, len(req.Clients)),
+		FailedIndexes: make([]int64, 0),
 	}
-	for _, v := range rawclients {
-		resp.Clients = append(resp.Clients, &pb.Client{
-			Id:        v.ID,
-			Name:      v.Name,
-			Birthday:  v.Birthday.Time.UnixNano(),
-			Score:     v.Score.Int64,
-			CreatedAt: v.CreatedAt.Time.UnixNano(),
-		})
+	// names taken by the clients inserted so far, so later duplicates within the request are refused
+	names := make(map[string]bool)
+	for start := 0; start < len(req.Clients); start += newClientsBatchSize {
+		end := start + newClientsBatchSize
+		if end > len(req.Clients) {
+			end = len(req.Clients)
+		}
+		ids := make([]string, 0, end-start)
+		rows := make([][]interface{}, 0, end-start)
+		for i, c := range req.Clients[start:end] {
+			id := utils.SecureID().String()
+			ids = append(ids, id)
+			var birthday, email, externalID interface{}
+			if c.Birthday != 0 {
+				birthday = time.Unix(0, c.Birthday)
+			}
+			if c.Email != "" {
+				email = c.Email
+			}
+			if c.ExternalId != "" {
+				externalID = c.ExternalId
+			}
+			rows = append(rows, []interface{}{id, c.Name, birthday, c.Score, c.Score, email, phones[start+i], metadata[start+i], notes[start+i], externalID, sq.Expr("NOW()")})
+		}
+		skipped, err := s.insertClientsBatch(ctx, req.Clients[start:end], rows, names)
+		if err != nil {
+			if !isDuplicateKey(err, "uniq_email") && !isDuplicateKey(err, "uniq_external_id") && !isDuplicateKey(err, "PRIMARY") {
+				return nil, err
+			}
+			for i := start; i < end; i++ {
+				resp.FailedIndexes = append(resp.FailedIndexes, int64(i))
+			}
+			continue
+		}
+		for i, id := range ids {
+			if skipped[i] {
+				resp.FailedIndexes = append(resp.FailedIndexes, int64(start+i))
+			} else {
+				resp.Ids[start+i] = id
+			}
+		}
 	}
 	return resp, nil
 }
 
-func (s *Service) NewMatch(ctx context.Context, req *pb.NewMatchRequest) (*pb.NewMatchResponse, error) {
+// insertClientsBatch inserts the rows of the clients of a NewClients batch in a single statement.
+// With UniqueNames, the clients whose name is used by an existing client or is in names are left
+// out, and their positions returned; names gets the names of the inserted clients.
+// The name and quota checks lock in the same transaction as the insert.
+func (s *Service) insertClientsBatch(ctx context.Context, clients []*pb.NewClientRequest, rows [][]interface{},
+	names map[string]bool) (map[int]bool, error) {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Commit()
+	defer tx.Rollback()
 
-	var matchId int64
-	if result, err := tx.Exec("INSERT INTO client_matches (client_id, score) VALUES (?, ?)", req.ClientId, req.Score); err != nil {
-		_ = tx.Rollback()
+	skipped := make(map[int]bool)
+	batchNames := make(map[string]bool)
+	iq := sq.Insert("clients").Columns("id", "name", "birthday", "score", "score_baseline", "email", "phone", "metadata", "notes", "external_id", "updated_at")
+	for i, c := range clients {
+		if s.config.UniqueNames {
+			if names[c.Name] || batchNames[c.Name] {
+				skipped[i] = true
+				continue
+			}
+			if err := checkNameAvailable(ctx, tx, c.Name, ""); err != nil {
+				if status.Code(err) != codes.AlreadyExists {
+					return nil, err
+				}
+				skipped[i] = true
+				continue
+			}
+			batchNames[c.Name] = true
+		}
+		iq = iq.Values(rows[i]...)
+	}
+	if len(skipped) == len(clients) {
+		return skipped, nil
+	}
+	if err := s.checkClientQuota(ctx, tx, len(clients)-len(skipped)); err != nil {
+		return nil, err
+	}
+	q, args, err := iq.ToSql()
+	if err != nil {
 		return nil, err
-	} else {
-		matchId, _ = result.LastInsertId()
 	}
-	if _, err := tx.Exec("UPDATE clients SET score = score + ? WHERE id = ?", req.Score, req.ClientId); err != nil {
-		_ = tx.Rollback()
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
 		return nil, err
 	}
-	return &pb.NewMatchResponse{Id: matchId}, nil
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for name := range batchNames {
+		names[name] = true
+	}
+	return skipped, nil
 }
 
-func (s *Service) DeleteClient(ctx context.Context, req *pb.DeleteClientRequest) (*pb.DeleteClientResponse, error) {
-	if _, err := s.db.ExecContext(ctx, "DELETE FROM clients WHERE id = ?", req.Id); err != nil {
+// clientFilters returns the predicates for the filters set in req
+func (s *Service) clientFilters(req *pb.QueryClientsRequest) ([]sq.Sqlizer, error) {
+	preds := make([]sq.Sqlizer, 0)
+	if req.Id != nil {
+		if len(req.Ids) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "id and ids can't be used together")
+		}
+		preds = append(preds, sq.Eq{"id": req.Id.Value})
+	}
+	if len(req.Ids) > 0 {
+		preds = append(preds, idsFilter(req.Ids))
+	}
+	if req.Name != nil {
+		switch req.NameMatch {
+		case pb.NameMatch_PATTERN:
+			preds = append(preds, sq.Expr("name LIKE ?", req.Name.Value))
+		case pb.NameMatch_EXACT:
+			preds = append(preds, sq.Eq{"name": req.Name.Value})
+		case pb.NameMatch_PREFIX:
+			preds = append(preds, sq.Expr("name LIKE ?", utils.EscapeLike(req.Name.Value)+"%"))
+		case pb.NameMatch_CONTAINS:
+			preds = append(preds, sq.Expr("name LIKE ?", "%"+utils.EscapeLike(req.Name.Value)+"%"))
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid name_match %d", req.NameMatch)
+		}
+	}
+	if req.Search != "" {
+		words := searchWords(req.Search)
+		if len(words) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "search has no words")
+		}
+		if s.config.FullTextSearch {
+			preds = append(preds, sq.Expr(matchName, fullTextQuery(words)))
+		} else {
+			for _, word := range words {
+				preds = append(preds, sq.Expr("name LIKE ?", "%"+utils.EscapeLike(word)+"%"))
+			}
+		}
+	}
+	if req.Birthday != nil {
+		preds = append(preds, req.Birthday.TimePred("birthday"))
+	}
+	if req.BirthdayIsNull != nil {
+		preds = append(preds, req.BirthdayIsNull.NullPred("birthday"))
+	}
+	switch from, to := req.BirthdayFrom, req.BirthdayTo; {
+	case from != nil && to != nil:
+		if from.Value > to.Value {
+			return nil, status.Error(codes.InvalidArgument, "birthday_from is after birthday_to")
+		}
+		preds = append(preds, sq.Expr("birthday BETWEEN ? AND ?", time.Unix(0, from.Value), time.Unix(0, to.Value)))
+	case from != nil:
+		preds = append(preds, sq.GtOrEq{"birthday": time.Unix(0, from.Value)})
+	case to != nil:
+		preds = append(preds, sq.LtOrEq{"birthday": time.Unix(0, to.Value)})
+	}
+	if req.Score != nil {
+		preds = append(preds, req.Score.Pred("score"))
+	}
+	agePreds, err := ageFilter(req.AgeMin, req.AgeMax)
+	if err != nil {
 		return nil, err
 	}
-	return &pb.DeleteClientResponse{}, nil
+	preds = append(preds, agePreds...)
+	if req.ScoreMin != nil && req.ScoreMax != nil && req.ScoreMin.Value > req.ScoreMax.Value {
+		return nil, status.Error(codes.InvalidArgument, "score_min is greater than score_max")
+	}
+	if req.ScoreMin != nil {
+		preds = append(preds, sq.GtOrEq{"score": req.ScoreMin.Value})
+	}
+	if req.ScoreMax != nil {
+		preds = append(preds, sq.LtOrEq{"score": req.ScoreMax.Value})
+	}
+	if req.CreatedAt != nil {
+		if req.CreatedWithin != 0 {
+			return nil, status.Error(codes.InvalidArgument, "created_at and created_within can't be used together")
+		}
+		preds = append(preds, req.CreatedAt.TimePred("created_at"))
+	}
+	if req.CreatedWithin < 0 {
+		return nil, status.Error(codes.InvalidArgument, "created_within can't be negative")
+	}
+	if req.CreatedWithin > 0 {
+		// resolved by the database, so the cutoff follows the clock that filled created_at
+		preds = append(preds, sq.Expr("created_at >= NOW() - INTERVAL ? SECOND", req.CreatedWithin))
+	}
+	if req.UpdatedAt != nil {
+		preds = append(preds, req.UpdatedAt.TimePred("updated_at"))
+	}
+	if req.LastSeenAt != nil {
+		preds = append(preds, req.LastSeenAt.TimePred("last_seen_at"))
+	}
+	if req.Email != nil {
+		preds = append(preds, sq.Eq{"email": req.Email.Value})
+	}
+	if req.ExternalId != nil {
+		preds = append(preds, sq.Eq{"external_id": req.ExternalId.Value})
+	}
+	if req.Phone != nil {
+		phone, err := s.normalizePhone("phone", req.Phone.Value)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, sq.Eq{"phone": phone})
+	}
+	if len(req.Status) > 0 {
+		statuses := make([]string, 0, len(req.Status))
+		for _, st := range req.Status {
+			statuses = append(statuses, st.String())
+		}
+		preds = append(preds, sq.Eq{"status": statuses})
+	}
+	if len(req.Tags) > 0 {
+		pred, err := tagsFilter(req.Tags, req.TagsMatchAll)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	if len(req.FilterGroups) > 0 {
+		pred, err := s.filterGroups(req.FilterGroups)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	return preds, nil
 }
 
-func (s *Service) DeleteAllClients(ctx context.Context, req *pb.DeleteAllClientsRequest) (*pb.DeleteAllClientsResponse, error) {
-	if _, err := s.db.ExecContext(ctx, "DELETE FROM clients"); err != nil {
-		return nil, err
+// maxFilterGroups is the max number of filter_groups of a QueryClients request
+const maxFilterGroups = 16
+
+// filterGroups ORs the filters of each group, which are ANDed within the group
+func (s *Service) filterGroups(groups []*pb.QueryClientsRequest) (sq.Sqlizer, error) {
+	if len(groups) > maxFilterGroups {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d filter groups are allowed", maxFilterGroups)
+	}
+	or := make(sq.Or, 0, len(groups))
+	for i, group := range groups {
+		if len(group.FilterGroups) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "filter group %d can't have filter groups", i)
+		}
+		if group.Limit != 0 || group.Offset != 0 || group.NoLimit || group.PageToken != "" || group.IncludeTotalCount ||
+			group.OrderBy != pb.ClientOrderBy_SCORE || group.Ascending || len(group.Sort) > 0 || group.IncludeDeleted ||
+			group.ReturnClients || group.AllowUnfiltered {
+			return nil, status.Errorf(codes.InvalidArgument, "filter group %d can only set filters", i)
+		}
+		preds, err := s.clientFilters(group)
+		if err != nil {
+			return nil, err
+		}
+		// an empty group would match every client
+		if len(preds) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "filter group %d has no filters", i)
+		}
+		or = append(or, sq.And(preds))
 	}
-	return &pb.DeleteAllClientsResponse{}, nil
+	return or, nil
 }
 
-func (s *Service) Sort(ctx context.Context, req *pb.SortRequest) (*pb.SortResponse, error) {
-	var items = make([]string, len(req.Items))
-	copy(items, req.Items)
-	sort.Strings(items)
+// ageFilter translates an age range into a birthday range, keeping the column bare so its index is
+// usable. The bounds are on the day after the birth date, so a birthday with a time still counts.
+func ageFilter(min, max *pb.OptInt64) ([]sq.Sqlizer, error) {
+	if (min != nil && min.Value < 0) || (max != nil && max.Value < 0) {
+		return nil, status.Error(codes.InvalidArgument, "age_min and age_max can't be negative")
+	}
+	if min != nil && max != nil && min.Value > max.Value {
+		return nil, status.Error(codes.InvalidArgument, "age_min is greater than age_max")
+	}
+	preds := make([]sq.Sqlizer, 0, 2)
+	if min != nil {
+		// born at least min years ago
+		preds = append(preds, sq.Expr("birthday < CURDATE() - INTERVAL ? YEAR + INTERVAL 1 DAY", min.Value))
+	}
+	if max != nil {
+		// not yet max+1 years old
+		preds = append(preds, sq.Expr("birthday >= CURDATE() - INTERVAL ? YEAR + INTERVAL 1 DAY", max.Value+1))
+	}
+	return preds, nil
+}
+
+// queryClientsIDsChunkSize is the max number of ids in each IN list of the ids filter
+const queryClientsIDsChunkSize = 1000
 
-	if !req.RemoveDuplicates {
-		return &pb.SortResponse{Items: items}, nil
+// idsFilter matches the given ids (none if empty, as sq.Eq renders an empty list as 1=0), splitting
+// a long list into ORed IN lists of up to queryClientsIDsChunkSize ids, so the query (and its paging)
+// stays a single statement
+func idsFilter(ids []string) sq.Sqlizer {
+	if len(ids) <= queryClientsIDsChunkSize {
+		return sq.Eq{"id": ids}
 	}
+	preds := sq.Or{}
+	for _, chunk := range utils.ChunkStrings(ids, queryClientsIDsChunkSize) {
+		preds = append(preds, sq.Eq{"id": chunk})
+	}
+	return preds
+}
 
-	pivot := items[0]
-	uniqueItems := []string{pivot}
-	for _, item := range items {
-		if item != pivot {
-			uniqueItems = append(uniqueItems, item)
-			pivot = item
-		}
+// matchName is the relevance of a client name to a fulltext search
+const matchName = "MATCH(name) AGAINST (? IN BOOLEAN MODE)"
+
+// searchWords splits a search into its words, dropping the operators of the fulltext boolean mode
+func searchWords(search string) []string {
+	return strings.FieldsFunc(search, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`+-<>()~*"@,`, r)
+	})
+}
+
+// fullTextQuery requires every word, matching it as a prefix like the LIKE fallback matches part of a name
+func fullTextQuery(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, "+"+word+"*")
+	}
+	return strings.Join(terms, " ")
+}
+
+// defaultQueryClientsLimit is the number of ids QueryClients returns when no limit is given
+const defaultQueryClientsLimit = 100
+
+// filteredClients starts a select (without columns) of the clients (not deleted, unless req.IncludeDeleted) matching the filters
+// of req, shared by QueryClients and CountClients. It also tells whether req has any filter.
+func (s *Service) filteredClients(req *pb.QueryClientsRequest) (sq.SelectBuilder, bool, error) {
+	preds, err := s.clientFilters(req)
+	if err != nil {
+		return sq.SelectBuilder{}, false, err
+	}
+	filtered := sq.Select().From("clients")
+	if !req.IncludeDeleted {
+		filtered = filtered.Where("deleted_at IS NULL")
+	}
+	for _, pred := range preds {
+		filtered = filtered.Where(pred)
 	}
-	return &pb.SortResponse{Items: uniqueItems}, nil
+	return filtered, len(preds) > 0, nil
+}
+
+// queryClientsFilterHash identifies the filters of a QueryClients request, so a page token can't be
+// used with other filters than the ones of the page it came from
+func queryClientsFilterHash(req *pb.QueryClientsRequest) (uint64, error) {
+	filters := proto.Clone(req).(*pb.QueryClientsRequest)
+	filters.Limit, filters.Offset, filters.NoLimit, filters.PageToken, filters.IncludeTotalCount = 0, 0, false, "", false
+	filters.ReturnClients, filters.AllowUnfiltered = false, false
+	raw, err := proto.Marshal(filters)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(raw)
+	return h.Sum64(), nil
+}
+
+// clientOrderColumns are the columns QueryClients can order by. Only these names ever get into the
+// ORDER BY, whatever the request holds.
+var clientOrderColumns = map[pb.ClientOrderBy]string{
+	pb.ClientOrderBy_SCORE:      "score",
+	pb.ClientOrderBy_NAME:       "name",
+	pb.ClientOrderBy_CREATED_AT: "created_at",
+	pb.ClientOrderBy_BIRTHDAY:   "birthday",
+}
 
-}
\ No newline at end of file
+// allowedClientOrders lists the ClientOrderBy values, for the errors about invalid ones
+func allowedClientOrders() string {
+	names := make([]string, 0, len(pb.ClientOrderBy_name))
+	for i := int32(0); i < int32(len(pb.ClientOrderBy_name)); i++ {
+		names = append(names, pb.ClientOrderBy_name[i])
+	}
+	return strings.Join(names, ", ")
+}
+
+// clientSortKey is a column QueryClients orders by
+type clientSortKey struct {
+	column    string
+	ascending bool
+}
+
+func (k clientSortKey) String() string {
+	if k.ascending {
+		return k.column + " ASC"
+	}
+	return k.column + " DESC"
+}
+
+// clientSortKeys returns the sort keys of req: req.Sort or, without it, req.OrderBy alone
+func clientSortKeys(req *pb.QueryClientsRequest) ([]clientSortKey, error) {
+	if len(req.Sort) == 0 {
+		column, ok := clientOrderColumns[req.OrderBy]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by %d, allowed: %s", req.OrderBy, allowedClientOrders())
+		}
+		return []clientSortKey{{column: column, ascending: req.Ascending}}, nil
+	}
+	keys := make([]clientSortKey, 0, len(req.Sort))
+	seen := make(map[string]bool, len(req.Sort))
+	for i, key := range req.Sort {
+		column, ok := clientOrderColumns[key.Column]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "sort[%d]: invalid column %d, allowed: %s", i, key.Column, allowedClientOrders())
+		}
+		if seen[column] {
+			return nil, status.Errorf(codes.InvalidArgument, "sort[%d]: %s is already sorted by, allowed once each: %s",
+				i, key.Column, allowedClientOrders())
+		}
+		seen[column] = true
+		keys = append(keys, clientSortKey{column: column, ascending: key.Ascending})
+	}
+	return keys, nil
+}
+
+// encodeQueryClientsPageToken returns the page token resuming a query ordered by keys after the client last
+func encodeQueryClientsPageToken(last clientRow, keys []clientSortKey, filterHash uint64) (string, error) {
+	token := &pb.QueryClientsPageToken{Id: last.ID, FilterHash: filterHash}
+	for _, key := range keys {
+		value := &pb.QueryClientsPageToken_Value{}
+		switch key.column {
+		case "score":
+			value.IntValue, value.Null = last.Score.Int64, !last.Score.Valid
+		case "name":
+			value.StringValue = last.Name
+		case "created_at":
+			value.IntValue = last.CreatedAt.Time.UnixNano()
+		case "birthday":
+			value.IntValue, value.Null = last.Birthday.Time.UnixNano(), !last.Birthday.Valid
+		}
+		token.Values = append(token.Values, value)
+	}
+	raw, err := proto.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeQueryClientsPageToken returns the keyset predicate selecting the clients after the last client
+// of the previous page, in the order of keys and then id ASC. NULLs come first in ascending order and
+// last in descending order, as MySQL sorts them.
+func decodeQueryClientsPageToken(token string, keys []clientSortKey, filterHash uint64) (sq.Sqlizer, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	last := &pb.QueryClientsPageToken{}
+	if err := proto.Unmarshal(raw, last); err != nil || last.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if last.FilterHash != filterHash || len(last.Values) != len(keys) {
+		return nil, status.Error(codes.InvalidArgument, "page_token was issued for different filters")
+	}
+
+	// a client comes after the last one if it ties on the first keys and comes after it on the next,
+	// or ties on every key and has a greater id
+	after, ties := sq.Or{}, sq.And{}
+	tiedAnd := func(pred sq.Sqlizer) sq.Sqlizer {
+		if len(ties) == 0 {
+			return pred
+		}
+		return append(append(sq.And{}, ties...), pred)
+	}
+	for i, key := range keys {
+		v := last.Values[i]
+		var value interface{}
+		switch key.column {
+		case "score":
+			value = v.IntValue
+		case "name":
+			value = v.StringValue
+		default:
+			value = time.Unix(0, v.IntValue)
+		}
+		switch {
+		case key.ascending && v.Null:
+			after = append(after, tiedAnd(sq.Expr(key.column+" IS NOT NULL")))
+		case key.ascending:
+			after = append(after, tiedAnd(sq.Expr(key.column+" > ?", value)))
+		case !v.Null:
+			after = append(after, tiedAnd(sq.Expr("("+key.column+" < ? OR "+key.column+" IS NULL)", value)))
+		}
+		// in descending order, only the ties come after a NULL
+		if v.Null {
+			ties = append(ties, sq.Expr(key.column+" IS NULL"))
+		} else {
+			ties = append(ties, sq.Expr(key.column+" = ?", value))
+		}
+	}
+	after = append(after, tiedAnd(sq.Expr("id > ?", last.Id)))
+	if len(after) == 1 {
+		return after[0], nil
+	}
+	return after, nil
+}
+
+// QueryClients returns the ids of the clients matching the filters, a page at a time, in the order
+// of req.Sort or req.OrderBy (highest score first by default). With req.NoLimit every matching id is returned,
+// as before paging was supported, but only if there is a filter: the whole table never comes in a single page.
+// With Config.RequireQueryFilters, a request without filters also needs req.AllowUnfiltered.
+// Pages are read either by offset or, with req.PageToken, from where the previous page stopped.
+// A fulltext req.Search orders by relevance first and is paged by offset only.
+// With req.ReturnClients, the same query also reads the clients, saving a GetClients round trip.
+func (s *Service) QueryClients(ctx context.Context, req *pb.QueryClientsRequest) (*pb.QueryClientsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset can't be negative")
+	}
+	keys, err := clientSortKeys(req)
+	if err != nil {
+		return nil, err
+	}
+	filtered, hasFilters, err := s.filteredClients(req)
+	if err != nil {
+		return nil, err
+	}
+	if !hasFilters && s.config.RequireQueryFilters && !req.AllowUnfiltered {
+		return nil, status.Error(codes.InvalidArgument,
+			"QueryClients requires at least one filter; set allow_unfiltered to page through every client")
+	}
+	noLimit := req.NoLimit && hasFilters
+	filterHash, err := queryClientsFilterHash(req)
+	if err != nil {
+		return nil, err
+	}
+	// the sort key columns are client columns, so a page token is the same in both modes
+	rq := filtered.Columns("id")
+	if req.ReturnClients {
+		rq = filtered.Columns(clientColumns...)
+		if req.IncludeDeleted {
+			rq = rq.Column("deleted_at")
+		}
+	}
+	orderBy := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		if !req.ReturnClients {
+			rq = rq.Columns(key.column)
+		}
+		orderBy = append(orderBy, key.String())
+	}
+	// the relevance of a fulltext search can't be carried by a page token
+	relevance := req.Search != "" && s.config.FullTextSearch
+	if req.PageToken != "" {
+		if req.Offset != 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_token can't be used with offset")
+		}
+		if relevance {
+			return nil, status.Error(codes.InvalidArgument, "page_token can't be used with search")
+		}
+		after, err := decodeQueryClientsPageToken(req.PageToken, keys, filterHash)
+		if err != nil {
+			return nil, err
+		}
+		rq = rq.Where(after)
+	}
+
+	if relevance {
+		rq = rq.OrderByClause(matchName+" DESC", fullTextQuery(searchWords(req.Search)))
+	}
+	// id breaks the ties, so the order is deterministic and the pages don't overlap
+	rq = rq.OrderBy(append(orderBy, "id ASC")...)
+	limit := req.Limit
+	if !noLimit {
+		if limit == 0 {
+			limit = defaultQueryClientsLimit
+		}
+		if limit > s.config.QueryClientsMaxLimit {
+			limit = s.config.QueryClientsMaxLimit
+		}
+		rq = rq.Limit(uint64(limit)).Offset(uint64(req.Offset))
+	}
+
+	q, args, err := rq.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []maybeDeletedClientRow{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.QueryClientsResponse{Ids: make([]string, 0, len(rows))}
+	for _, row := range rows {
+		resp.Ids = append(resp.Ids, row.ID)
+	}
+	if req.ReturnClients {
+		resp.Clients = make([]*pb.Client, 0, len(rows))
+		for _, row := range rows {
+			resp.Clients = append(resp.Clients, row.toPB())
+		}
+		if err := loadClientTags(ctx, s.db, resp.Clients...); err != nil {
+			return nil, err
+		}
+	}
+	if !noLimit && !relevance && int64(len(rows)) == limit {
+		if resp.NextPageToken, err = encodeQueryClientsPageToken(rows[len(rows)-1].clientRow, keys, filterHash); err != nil {
+			return nil, err
+		}
+	}
+	if req.IncludeTotalCount {
+		// same filters as the page, without the paging
+		q, args, err := filtered.Columns("COUNT(*)").ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.GetContext(ctx, &resp.TotalCount, q, args...); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// CountClients counts the clients matching req.Filter (all of them without it); its paging fields are ignored
+func (s *Service) CountClients(ctx context.Context, req *pb.CountClientsRequest) (*pb.CountClientsResponse, error) {
+	filter := req.Filter
+	if filter == nil {
+		filter = &pb.QueryClientsRequest{}
+	}
+	filtered, _, err := s.filteredClients(filter)
+	if err != nil {
+		return nil, err
+	}
+	q, args, err := filtered.Columns("COUNT(*)").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.CountClientsResponse{}
+	if err := s.db.GetContext(ctx, &resp.Count, q, args...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetClients returns the clients with the given ids, in the order of req.Ids; the ids not found are left
+// out, listed in the missing ids (or failing the call with req.RequireAll), and a repeated id returns its
+// client once. With req.IncludeDeleted, the soft deleted clients are
+// returned too, with their deleted_at.
+// The ids are read Config.GetClientsChunkSize at a time, up to Config.GetClientsMaxIDs per request.
+func (s *Service) GetClients(ctx context.Context, req *pb.GetClientsRequest) (*pb.GetClientsResponse, error) {
+	columns, withTags, err := clientReadColumns(req.ReadMask, req.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	// no ids, no clients (and no query: MySQL rejects an empty IN list)
+	if len(req.Ids) == 0 {
+		return &pb.GetClientsResponse{Clients: []*pb.Client{}}, nil
+	}
+	if len(req.Ids) > s.config.GetClientsMaxIDs {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d ids can be requested at once, got %d",
+			s.config.GetClientsMaxIDs, len(req.Ids))
+	}
+	ids := make([]string, 0, len(req.Ids))
+	seen := make(map[string]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	byID := make(map[string]*pb.Client, len(ids))
+	for _, chunk := range utils.ChunkStrings(ids, s.config.GetClientsChunkSize) {
+		lq := sq.Select(columns...).From("`clients`").Where(sq.Eq{"id": chunk})
+		if !req.IncludeDeleted {
+			lq = lq.Where("deleted_at IS NULL")
+		}
+		q, args, err := lq.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		rawclients := []maybeDeletedClientRow{}
+		if err := s.db.SelectContext(ctx, &rawclients, q, args...); err != nil {
+			return nil, err
+		}
+		for _, v := range rawclients {
+			byID[v.ID] = v.toPB()
+		}
+	}
+	resp := &pb.GetClientsResponse{
+		Clients: make([]*pb.Client, 0, len(byID)),
+	}
+	for _, id := range ids {
+		if c, ok := byID[id]; ok {
+			resp.Clients = append(resp.Clients, c)
+		} else {
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+	if req.RequireAll && len(resp.MissingIds) > 0 {
+		return nil, status.Errorf(codes.NotFound, "clients not found: %s", strings.Join(resp.MissingIds, ", "))
+	}
+	if withTags {
+		for start := 0; start < len(resp.Clients); start += s.config.GetClientsChunkSize {
+			end := start + s.config.GetClientsChunkSize
+			if end > len(resp.Clients) {
+				end = len(resp.Clients)
+			}
+			if err := loadClientTags(ctx, s.db, resp.Clients[start:end]...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
+
+// readableClientFields maps the read_mask paths of GetClients to the columns they are read from;
+// tags come from client_tags and deleted_at is only read along with the deleted clients
+var readableClientFields = map[string]string{
+	"id": "id", "name": "name", "birthday": "birthday", "score": "score", "created_at": "created_at",
+	"email": "email", "phone": "phone", "tags": "", "metadata": "metadata", "version": "version",
+	"updated_at": "updated_at", "status": "status", "notes": "notes", "external_id": "external_id",
+	"anonymized": "anonymized_at", "last_seen_at": "last_seen_at", "deleted_at": "deleted_at",
+}
+
+// clientReadColumns returns the columns to select for the fields of mask (every field when it's nil)
+// and whether the tags are to be read
+func clientReadColumns(mask *field_mask.FieldMask, includeDeleted bool) ([]string, bool, error) {
+	selected := make(map[string]bool, len(readableClientFields))
+	tags := mask == nil
+	if mask == nil {
+		for _, column := range readableClientFields {
+			selected[column] = true
+		}
+	} else {
+		unknown := make([]string, 0)
+		for _, path := range mask.Paths {
+			column, ok := readableClientFields[path]
+			if !ok {
+				unknown = append(unknown, path)
+				continue
+			}
+			tags = tags || path == "tags"
+			selected[column] = true
+		}
+		if len(unknown) > 0 {
+			allowed := make([]string, 0, len(readableClientFields))
+			for path := range readableClientFields {
+				allowed = append(allowed, path)
+			}
+			sort.Strings(allowed)
+			return nil, false, status.Errorf(codes.InvalidArgument, "invalid read_mask paths: %s (allowed: %s)",
+				strings.Join(unknown, ", "), strings.Join(allowed, ", "))
+		}
+		selected["id"] = true
+	}
+	columns := make([]string, 0, len(clientColumns)+1)
+	for _, column := range clientColumns {
+		if selected[column] {
+			columns = append(columns, column)
+		}
+	}
+	if includeDeleted && selected["deleted_at"] {
+		columns = append(columns, "deleted_at")
+	}
+	return columns, tags, nil
+}
+
+// GetClient returns a single client by id
+func (s *Service) GetClient(ctx context.Context, req *pb.GetClientRequest) (*pb.GetClientResponse, error) {
+	client, err := getClient(ctx, s.db, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetClientResponse{Client: client}, nil
+}
+
+// ClientExists checks if a (not deleted) client exists without reading the whole row
+func (s *Service) ClientExists(ctx context.Context, req *pb.ClientExistsRequest) (*pb.ClientExistsResponse, error) {
+	var one int
+	err := s.db.GetContext(ctx, &one, "SELECT 1 FROM clients WHERE id = ? AND deleted_at IS NULL LIMIT 1", req.Id)
+	if err == sql.ErrNoRows {
+		return &pb.ClientExistsResponse{Exists: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ClientExistsResponse{Exists: true}, nil
+}
+
+// ClientsExist returns which of req.Ids are (not deleted) clients
+func (s *Service) ClientsExist(ctx context.Context, req *pb.ClientsExistRequest) (*pb.ClientsExistResponse, error) {
+	resp := &pb.ClientsExistResponse{
+		Ids: make([]string, 0, len(req.Ids)),
+	}
+	if len(req.Ids) == 0 {
+		return resp, nil
+	}
+	q, args, err := sq.Select("id").From("clients").Where(sq.Eq{"id": req.Ids}).Where("deleted_at IS NULL").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(req.Ids))
+	if err := s.db.SelectContext(ctx, &found, q, args...); err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+	for _, id := range req.Ids {
+		if exists[id] {
+			resp.Ids = append(resp.Ids, id)
+		}
+	}
+	return resp, nil
+}
+
+// GetClientByEmail returns the client with the given email
+func (s *Service) GetClientByEmail(ctx context.Context, req *pb.GetClientByEmailRequest) (*pb.GetClientByEmailResponse, error) {
+	client, err := findClient(ctx, s.db, sq.Eq{"email": req.Email}, "no client with email "+req.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetClientByEmailResponse{Client: client}, nil
+}
+
+// GetClientByExternalId returns the client with the given external (CRM) id
+func (s *Service) GetClientByExternalId(ctx context.Context, req *pb.GetClientByExternalIdRequest) (*pb.GetClientByExternalIdResponse, error) {
+	client, err := findClient(ctx, s.db, sq.Eq{"external_id": req.ExternalId}, "no client with external_id "+req.ExternalId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetClientByExternalIdResponse{Client: client}, nil
+}
+
+// TouchClient sets the last_seen_at of a client to now, with a single statement
+func (s *Service) TouchClient(ctx context.Context, req *pb.TouchClientRequest) (*pb.TouchClientResponse, error) {
+	// last_seen_at has microsecond precision, so a touch always changes the row and counts as affected
+	result, err := s.db.ExecContext(ctx, "UPDATE clients SET last_seen_at = NOW(6) WHERE id = ? AND deleted_at IS NULL", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, status.Errorf(codes.NotFound, "client %s not found", req.Id)
+	}
+	return &pb.TouchClientResponse{}, nil
+}
+
+// updatableClientFields are the fields (and update_mask paths) UpdateClient can change
+var updatableClientFields = []string{"name", "birthday", "score", "email", "phone", "metadata", "notes"}
+
+// clientFieldValue returns the column value of field in c, using NULL for the empty values of
+// the nullable fields
+func (s *Service) clientFieldValue(field string, c *pb.Client) (interface{}, error) {
+	switch field {
+	case "name":
+		return c.Name, nil
+	case "birthday":
+		if c.Birthday == 0 {
+			return nil, nil
+		}
+		return time.Unix(0, c.Birthday), nil
+	case "score":
+		return c.Score, nil
+	case "email":
+		if c.Email == "" {
+			return nil, nil
+		}
+		if !utils.IsEmailValid(c.Email) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid email %q", c.Email)
+		}
+		return c.Email, nil
+	case "phone":
+		if c.Phone == "" {
+			return nil, nil
+		}
+		return s.normalizePhone("phone", c.Phone)
+	case "metadata":
+		return s.metadataValue("metadata", c.Metadata)
+	case "notes":
+		return s.notesValue("notes", c.Notes)
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "field %q can't be updated", field)
+}
+
+// updateClientFields returns the new values of req in a Client, along with the fields to be set
+func updateClientFields(req *pb.UpdateClientRequest) (*pb.Client, []string, error) {
+	c := &pb.Client{}
+	fields := make([]string, 0)
+	if req.Name != nil {
+		c.Name, fields = req.Name.Value, append(fields, "name")
+	}
+	if req.Birthday != nil {
+		c.Birthday, fields = req.Birthday.Value, append(fields, "birthday")
+	}
+	if req.Score != nil {
+		c.Score, fields = req.Score.Value, append(fields, "score")
+	}
+	if req.Email != nil {
+		c.Email, fields = req.Email.Value, append(fields, "email")
+	}
+	if req.Phone != nil {
+		c.Phone, fields = req.Phone.Value, append(fields, "phone")
+	}
+	if req.Metadata != nil {
+		c.Metadata, fields = req.Metadata.Value, append(fields, "metadata")
+	}
+	if req.Notes != nil {
+		c.Notes, fields = req.Notes.Value, append(fields, "notes")
+	}
+	if req.UpdateMask == nil {
+		return c, fields, nil
+	}
+
+	if len(fields) > 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "update_mask can't be combined with the individual fields")
+	}
+	unknown := make([]string, 0)
+	for _, path := range req.UpdateMask.Paths {
+		known := false
+		for _, f := range updatableClientFields {
+			if path == f {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, path)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid update_mask paths: %s (allowed: %s)",
+			strings.Join(unknown, ", "), strings.Join(updatableClientFields, ", "))
+	}
+	if req.Client == nil {
+		return &pb.Client{}, req.UpdateMask.Paths, nil
+	}
+	return req.Client, req.UpdateMask.Paths, nil
+}
+
+// UpdateClient changes the provided fields of an existing client and returns the updated row.
+// Every update increments the client version; if req.ExpectedVersion is set and doesn't match
+// the current version, nothing is changed and an Aborted status is returned.
+func (s *Service) UpdateClient(ctx context.Context, req *pb.UpdateClientRequest) (*pb.UpdateClientResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	values, fields, err := updateClientFields(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+	}
+	sets := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if sets[field], err = s.clientFieldValue(field, values); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := sets["score"]; ok {
+		sets["score_baseline"] = s.scoreBaseline(req.Id, values.Score)
+	}
+
+	uq := sq.Update("clients").SetMap(sets).Set("version", sq.Expr("version + 1")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": req.Id}).Where("deleted_at IS NULL")
+	if req.ExpectedVersion != 0 {
+		uq = uq.Where(sq.Eq{"version": req.ExpectedVersion})
+	}
+	q, args, err := uq.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, ok := sets["name"]; ok && s.config.UniqueNames {
+		if err := checkNameAvailable(ctx, tx, values.Name, req.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		if isDuplicateKey(err, "uniq_email") {
+			return nil, valueInUseError(ctx, tx, "email", values.Email)
+		}
+		return nil, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	// the version always changes, so no affected rows means a missing client or a stale version
+	client, err := getClient(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, status.Errorf(codes.Aborted, "client %s is at version %d, not %d", req.Id, client.Version, req.ExpectedVersion)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateClientResponse{Client: client}, nil
+}
+
+// CloneClient inserts a copy of a client with a new id, along with its tags and, if req.CopyMatches
+// is set, its match history. The unique email and external_id are left empty in the copy.
+// Without req.CopyScore the score of the copy is the sum of its copied matches, if any.
+func (s *Service) CloneClient(ctx context.Context, req *pb.CloneClientRequest) (*pb.CloneClientResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q, args, err := sq.Select(clientColumns...).From("clients").
+		Where(sq.Eq{"id": req.SourceId}).Where("deleted_at IS NULL").Suffix("LOCK IN SHARE MODE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	src := clientRow{}
+	if err := tx.GetContext(ctx, &src, q, args...); err != nil {
+		return nil, notFoundOr(err, "client "+req.SourceId+" not found")
+	}
+	// before the plain reads below, see checkClientQuota
+	if err := s.checkClientQuota(ctx, tx, 1); err != nil {
+		return nil, err
+	}
+	name := src.Name
+	if req.Name != nil {
+		name = req.Name.Value
+	}
+	// the baseline is the part of the score the copied matches don't make up
+	var score, baseline int64
+	switch {
+	case req.CopyScore:
+		score, baseline = src.Score.Int64, src.Score.Int64
+		if req.CopyMatches {
+			if err := tx.GetContext(ctx, &baseline, "SELECT score_baseline FROM clients WHERE id = ?", req.SourceId); err != nil {
+				return nil, err
+			}
+		}
+	case req.CopyMatches:
+		// the copied matches make up the whole score of the clone
+		q, args, err := sq.Select("COALESCE(SUM(score), 0)").From("client_matches").
+			Where(sq.Eq{"client_id": req.SourceId}).Where(s.scoringMatches("")).ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.GetContext(ctx, &score, q, args...); err != nil {
+			return nil, err
+		}
+	}
+	if s.config.UniqueNames {
+		if err := checkNameAvailable(ctx, tx, name, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	id := utils.SecureID().String()
+	q, args, err = sq.Insert("clients").
+		Columns("id", "name", "birthday", "score", "score_baseline", "phone", "metadata", "notes", "status", "updated_at").
+		Values(id, name, src.Birthday, score, baseline, src.Phone, src.Metadata, src.Notes, src.Status, sq.Expr("NOW()")).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO client_tags (client_id, tag) SELECT ?, tag FROM client_tags WHERE client_id = ?",
+		id, req.SourceId); err != nil {
+		return nil, err
+	}
+	if req.CopyMatches {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO client_matches (client_id, score, opponent_id, result, played_at, match_type, created_at, season_id) "+
+			"SELECT ?, score, opponent_id, result, played_at, match_type, created_at, season_id FROM client_matches WHERE client_id = ?", id, req.SourceId); err != nil {
+			return nil, err
+		}
+	}
+	client, err := getClient(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.CloneClientResponse{Client: client}, nil
+}
+
+// UpsertClient creates the client with the given id, or overwrites its fields if it already exists.
+// created_at is kept on the update path. A soft deleted client is refused, as it has to be restored first.
+func (s *Service) UpsertClient(ctx context.Context, req *pb.UpsertClientRequest) (*pb.UpsertClientResponse, error) {
+	if !utils.IsIDValid(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "a valid id is required")
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// the lock keeps the client from being deleted, or created, until the upsert is done
+	var deleted bool
+	err = tx.GetContext(ctx, &deleted, "SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE", req.Id)
+	exists := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "client %s is deleted, restore it first", req.Id)
+	}
+	if s.config.UniqueNames {
+		if err := checkNameAvailable(ctx, tx, req.Name, req.Id); err != nil {
+			return nil, err
+		}
+	}
+	if !exists {
+		if err := s.checkClientQuota(ctx, tx, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	var birthday interface{}
+	if req.Birthday != 0 {
+		birthday = time.Unix(0, req.Birthday)
+	}
+	q, args, err := sq.Insert("clients").Columns("id", "name", "birthday", "score", "score_baseline", "updated_at").
+		Values(req.Id, req.Name, birthday, req.Score, s.scoreBaseline(req.Id, req.Score), sq.Expr("NOW()")).
+		Suffix("ON DUPLICATE KEY UPDATE name = VALUES(name), birthday = VALUES(birthday), score = VALUES(score), " +
+			"score_baseline = VALUES(score_baseline), version = version + 1, updated_at = VALUES(updated_at)").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.UpsertClientResponse{Created: !exists}, nil
+}
+
+// NewMatch records a match of an active client and adds its score to the client score.
+// With a score floor configured, the score may be clamped (or the match refused) so the client
+// doesn't go below it.
+// If req.IdempotencyKey was already used for the client, the match recorded by that request is
+// returned and the score isn't added again.
+func (s *Service) NewMatch(ctx context.Context, req *pb.NewMatchRequest) (*pb.NewMatchResponse, error) {
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency_key is longer than %d", maxIdempotencyKeyLength)
+	}
+	if req.OpponentId != "" && req.OpponentId == req.ClientId {
+		return nil, status.Error(codes.InvalidArgument, "a client can't be its own opponent")
+	}
+	if _, ok := pb.MatchResult_name[int32(req.Result)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid result %d", req.Result)
+	}
+	if req.PlayedAt > time.Now().UnixNano() {
+		return nil, status.Error(codes.InvalidArgument, "played_at is in the future")
+	}
+	if _, ok := pb.MatchType_name[int32(req.MatchType)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid match type %d", req.MatchType)
+	}
+	if err := s.checkMatchScore("score", req.Score); err != nil {
+		return nil, err
+	}
+	counts := s.countsForScore(req.MatchType.String())
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// soft deleted clients still pass the foreign key check, so deleted_at refuses them here.
+	// The lock also keeps concurrent matches from racing past the score floor.
+	client := struct {
+		Status string        `db:"status"`
+		Score  sql.NullInt64 `db:"score"`
+	}{}
+	if err := tx.GetContext(ctx, &client, "SELECT status, score FROM clients WHERE id = ? AND deleted_at IS NULL FOR UPDATE", req.ClientId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "client %s not found", req.ClientId)
+		}
+		return nil, err
+	}
+	// retries wait on the client lock above, so they always see the match of the first request
+	if req.IdempotencyKey != "" {
+		prev := struct {
+			ID        int64     `db:"id"`
+			Score     int64     `db:"score"`
+			CreatedAt time.Time `db:"created_at"`
+		}{}
+		err := tx.GetContext(ctx, &prev, "SELECT id, score, created_at FROM client_matches WHERE client_id = ? AND idempotency_key = ?",
+			req.ClientId, req.IdempotencyKey)
+		if err == nil {
+			if err := tx.Commit(); err != nil {
+				return nil, err
+			}
+			return &pb.NewMatchResponse{Id: prev.ID, CreatedAt: prev.CreatedAt.UnixNano(), Score: prev.Score}, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+	if client.Status != pb.ClientStatus_ACTIVE.String() {
+		return nil, status.Errorf(codes.FailedPrecondition, "client %s is %s", req.ClientId, client.Status)
+	}
+	score := req.Score
+	if counts {
+		if score, err = s.floorScore(req.ClientId, client.Score.Int64, req.Score); err != nil {
+			return nil, err
+		}
+	}
+	if req.OpponentId != "" {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM clients WHERE id = ? AND deleted_at IS NULL)", req.OpponentId); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, status.Errorf(codes.NotFound, "opponent %s not found", req.OpponentId)
+		}
+	}
+
+	cols := []string{"client_id", "score"}
+	vals := []interface{}{req.ClientId, score}
+	if req.OpponentId != "" {
+		cols, vals = append(cols, "opponent_id"), append(vals, req.OpponentId)
+	}
+	if req.Result != pb.MatchResult_NO_RESULT {
+		cols, vals = append(cols, "result"), append(vals, req.Result.String())
+	}
+	if req.PlayedAt != 0 {
+		cols, vals = append(cols, "played_at"), append(vals, time.Unix(0, req.PlayedAt))
+	}
+	if req.MatchType != pb.MatchType_RANKED {
+		cols, vals = append(cols, "match_type"), append(vals, req.MatchType.String())
+	}
+	if req.IdempotencyKey != "" {
+		cols, vals = append(cols, "idempotency_key"), append(vals, req.IdempotencyKey)
+	}
+	cols, vals = append(cols, "season_id"), append(vals, currentSeason)
+	q, args, err := sq.Insert("client_matches").Columns(cols...).Values(vals...).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	matchId, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	if counts {
+		updated, err := tx.ExecContext(ctx, "UPDATE clients SET score = score + ?, updated_at = NOW() WHERE id = ?", score, req.ClientId)
+		if err != nil {
+			return nil, err
+		}
+		// the row lock above should keep the client around, but never commit a match whose score went nowhere
+		if n, err := updated.RowsAffected(); err != nil {
+			return nil, err
+		} else if n == 0 {
+			return nil, status.Errorf(codes.NotFound, "client %s not found", req.ClientId)
+		}
+	}
+	var createdAt time.Time
+	if err := tx.GetContext(ctx, &createdAt, "SELECT created_at FROM client_matches WHERE id = ?", matchId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.NewMatchResponse{Id: matchId, CreatedAt: createdAt.UnixNano(), Score: score}, nil
+}
+
+// anonymizedName replaces the name of anonymized clients
+const anonymizedName = "[anonymized]"
+
+// AnonymizeClient erases the personal data of a client (and of its archived copy), keeping its id,
+// score, tags and matches for the aggregate stats. Anonymizing a client again is a no-op.
+func (s *Service) AnonymizeClient(ctx context.Context, req *pb.AnonymizeClientRequest) (*pb.AnonymizeClientResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// soft deleted clients are anonymized as well
+	var anonymizedAt sql.NullTime
+	if err := tx.GetContext(ctx, &anonymizedAt, "SELECT anonymized_at FROM clients WHERE id = ? FOR UPDATE", req.Id); err != nil {
+		return nil, notFoundOr(err, "client "+req.Id+" not found")
+	}
+	if anonymizedAt.Valid {
+		return &pb.AnonymizeClientResponse{}, nil
+	}
+	erase := map[string]interface{}{
+		"name":          anonymizedName,
+		"birthday":      nil,
+		"email":         nil,
+		"phone":         nil,
+		"metadata":      nil,
+		"notes":         nil,
+		"external_id":   nil,
+		"anonymized_at": sq.Expr("NOW()"),
+	}
+	for _, uq := range []sq.UpdateBuilder{
+		sq.Update("clients").SetMap(erase).Set("version", sq.Expr("version + 1")).Set("updated_at", sq.Expr("NOW()")),
+		sq.Update("clients_archive").SetMap(erase),
+	} {
+		q, args, err := uq.Where(sq.Eq{"id": req.Id}).ToSql()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.AnonymizeClientResponse{}, nil
+}
+
+// MergeClients moves the matches, tags and score of the source client to the target client and
+// deletes the source (soft deleted unless req.Force is set), all in a single transaction.
+// The matches against the source become matches against the target, except the ones between the two
+// clients, which lose their opponent. A copy of the source is kept in clients_archive.
+// If both clients have a match with the same idempotency key, AlreadyExists is returned.
+func (s *Service) MergeClients(ctx context.Context, req *pb.MergeClientsRequest) (*pb.MergeClientsResponse, error) {
+	if req.SourceId == "" || req.TargetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "source_id and target_id are required")
+	}
+	if req.SourceId == req.TargetId {
+		return nil, status.Error(codes.InvalidArgument, "cannot merge a client into itself")
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows := make([]struct {
+		ID       string        `db:"id"`
+		Score    sql.NullInt64 `db:"score"`
+		Baseline int64         `db:"score_baseline"`
+	}, 0, 2)
+	if err := tx.SelectContext(ctx, &rows, "SELECT id, score, score_baseline FROM clients WHERE id IN (?, ?) AND deleted_at IS NULL FOR UPDATE",
+		req.SourceId, req.TargetId); err != nil {
+		return nil, err
+	}
+	scores := make(map[string]int64, len(rows))
+	baselines := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		scores[r.ID] = r.Score.Int64
+		baselines[r.ID] = r.Baseline
+	}
+	for _, id := range []string{req.SourceId, req.TargetId} {
+		if _, ok := scores[id]; !ok {
+			return nil, status.Errorf(codes.NotFound, "client %s not found", id)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE client_matches SET client_id = ? WHERE client_id = ?", req.TargetId, req.SourceId)
+	if err != nil {
+		if isDuplicateKey(err, "uniq_idempotency_key") {
+			return nil, status.Errorf(codes.AlreadyExists, "clients %s and %s have matches with the same idempotency key",
+				req.SourceId, req.TargetId)
+		}
+		return nil, err
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	// the target can't be its own opponent
+	if _, err := tx.ExecContext(ctx, "UPDATE client_matches SET opponent_id = IF(client_id = ?, NULL, ?) WHERE opponent_id = ?",
+		req.TargetId, req.TargetId, req.SourceId); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO client_tags (client_id, tag) SELECT ?, t.tag FROM client_tags t WHERE t.client_id = ? "+
+		"ON DUPLICATE KEY UPDATE tag = VALUES(tag)", req.TargetId, req.SourceId); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM client_tags WHERE client_id = ?", req.SourceId); err != nil {
+		return nil, err
+	}
+	score := scores[req.TargetId] + scores[req.SourceId]
+	baseline := baselines[req.TargetId] + baselines[req.SourceId]
+	if _, err := tx.ExecContext(ctx, "UPDATE clients SET score = ?, score_baseline = ?, version = version + 1, updated_at = NOW() WHERE id = ?",
+		score, baseline, req.TargetId); err != nil {
+		return nil, err
+	}
+	if err := archiveClients(ctx, tx, sq.Eq{"id": req.SourceId}); err != nil {
+		return nil, err
+	}
+	if req.Force {
+		_, err = tx.ExecContext(ctx, "DELETE FROM clients WHERE id = ?", req.SourceId)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE clients SET deleted_at = NOW() WHERE id = ?", req.SourceId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.MergeClientsResponse{
+		MovedMatches: moved,
+		Score:        score,
+	}, nil
+}
+
+// DeleteClient soft deletes a client by setting deleted_at, or removes the row if req.Force is set.
+// A copy of the client is kept in clients_archive.
+// Removing a client removes its matches too, while soft deleted clients keep them so they can be restored.
+// With req.FailIfMatches set, clients with matches are refused instead.
+func (s *Service) DeleteClient(ctx context.Context, req *pb.DeleteClientRequest) (*pb.DeleteClientResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var matches int64
+	if req.Force || req.FailIfMatches {
+		// the locking read also keeps new matches of the client from being inserted until we are done
+		if err := tx.GetContext(ctx, &matches, "SELECT COUNT(*) FROM client_matches WHERE client_id = ? FOR UPDATE", req.Id); err != nil {
+			return nil, err
+		}
+		if matches > 0 && req.FailIfMatches {
+			return nil, status.Errorf(codes.FailedPrecondition, "client %s has %d matches", req.Id, matches)
+		}
+	}
+	pred := sq.And{sq.Eq{"id": req.Id}}
+	if !req.Force {
+		pred = append(pred, sq.Expr("deleted_at IS NULL"))
+	}
+	if err := archiveClients(ctx, tx, pred); err != nil {
+		return nil, err
+	}
+	resp := &pb.DeleteClientResponse{}
+	if req.Force {
+		if matches > 0 {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM client_matches WHERE client_id = ?", req.Id); err != nil {
+				return nil, err
+			}
+			resp.DeletedMatches = matches
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM clients WHERE id = ?", req.Id)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE clients SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// deleteClientsBatchSize is the max number of ids in a single statement of DeleteClients
+const deleteClientsBatchSize = 1000
+
+// DeleteClients soft deletes (or removes, if req.Force is set) many clients, archiving them like DeleteClient.
+// Ids that don't exist (or are already soft deleted, unless forced) are returned in NotFoundIds.
+func (s *Service) DeleteClients(ctx context.Context, req *pb.DeleteClientsRequest) (*pb.DeleteClientsResponse, error) {
+	if len(req.Ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids is empty")
+	}
+	ids := make([]string, 0, len(req.Ids))
+	seen := make(map[string]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	resp := &pb.DeleteClientsResponse{
+		NotFoundIds: make([]string, 0),
+	}
+	for _, chunk := range utils.ChunkStrings(ids, deleteClientsBatchSize) {
+		deleted, err := s.deleteClientsChunk(ctx, chunk, req.Force)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range chunk {
+			if !deleted[id] {
+				resp.NotFoundIds = append(resp.NotFoundIds, id)
+			}
+		}
+		resp.Deleted += int64(len(deleted))
+	}
+	return resp, nil
+}
+
+func (s *Service) deleteClientsChunk(ctx context.Context, ids []string, force bool) (map[string]bool, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	sel := sq.Select("id").From("clients").Where(sq.Eq{"id": ids})
+	if !force {
+		sel = sel.Where("deleted_at IS NULL")
+	}
+	q, args, err := sel.Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(ids))
+	if err := tx.SelectContext(ctx, &found, q, args...); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	if err := archiveClients(ctx, tx, sq.Eq{"id": found}); err != nil {
+		return nil, err
+	}
+	if force {
+		q, args, err = sq.Delete("clients").Where(sq.Eq{"id": found}).ToSql()
+	} else {
+		q, args, err = sq.Update("clients").Set("deleted_at", sq.Expr("NOW()")).Where(sq.Eq{"id": found}).ToSql()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[string]bool, len(found))
+	for _, id := range found {
+		deleted[id] = true
+	}
+	return deleted, nil
+}
+
+// DeleteClientsByQuery soft deletes (or removes, if req.Force is set) every client matching req.Filter,
+// keeping a copy of them in clients_archive. With req.DryRun set, it only counts them.
+func (s *Service) DeleteClientsByQuery(ctx context.Context, req *pb.DeleteClientsByQueryRequest) (*pb.DeleteClientsByQueryResponse, error) {
+	if req.Filter == nil {
+		return nil, status.Error(codes.InvalidArgument, "filter is required")
+	}
+	preds, err := s.clientFilters(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(preds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "filter is empty, use DeleteAllClients instead")
+	}
+	if !req.Force {
+		preds = append(preds, sq.Expr("deleted_at IS NULL"))
+	}
+
+	if req.DryRun {
+		cq := sq.Select("COUNT(*)").From("clients")
+		for _, pred := range preds {
+			cq = cq.Where(pred)
+		}
+		q, args, err := cq.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		var count int64
+		if err := s.db.GetContext(ctx, &count, q, args...); err != nil {
+			return nil, err
+		}
+		return &pb.DeleteClientsByQueryResponse{Deleted: count}, nil
+	}
+
+	var (
+		q    string
+		args []interface{}
+	)
+	if req.Force {
+		dq := sq.Delete("clients")
+		for _, pred := range preds {
+			dq = dq.Where(pred)
+		}
+		q, args, err = dq.ToSql()
+	} else {
+		uq := sq.Update("clients").Set("deleted_at", sq.Expr("NOW()"))
+		for _, pred := range preds {
+			uq = uq.Where(pred)
+		}
+		q, args, err = uq.ToSql()
+	}
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := archiveClients(ctx, tx, sq.And(preds)); err != nil {
+		return nil, err
+	}
+	result, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteClientsByQueryResponse{Deleted: n}, nil
+}
+
+// RestoreClient clears the deleted_at flag of a soft deleted client, within the MaxClients quota
+func (s *Service) RestoreClient(ctx context.Context, req *pb.RestoreClientRequest) (*pb.RestoreClientResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deleted bool
+	if err := tx.GetContext(ctx, &deleted, "SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE", req.Id); err != nil {
+		return nil, notFoundOr(err, "client "+req.Id+" not found")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "client %s is not deleted", req.Id)
+	}
+	if err := s.checkClientQuota(ctx, tx, 1); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE clients SET deleted_at = NULL WHERE id = ?", req.Id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.RestoreClientResponse{}, nil
+}
+
+// purgeDeletedClientsBatchSize is the max number of clients removed by each transaction of PurgeDeletedClients
+const purgeDeletedClientsBatchSize = 1000
+
+// PurgeDeletedClients permanently removes the clients soft deleted before req.OlderThan, along with their matches.
+// Clients are removed in batches, each in its own transaction, so a large purge doesn't hold long locks.
+// With req.DryRun set, it only counts them.
+func (s *Service) PurgeDeletedClients(ctx context.Context, req *pb.PurgeDeletedClientsRequest) (*pb.PurgeDeletedClientsResponse, error) {
+	if req.OlderThan <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "older_than is required")
+	}
+	olderThan := time.Unix(0, req.OlderThan)
+	if req.DryRun {
+		var count int64
+		if err := s.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM clients WHERE deleted_at < ?", olderThan); err != nil {
+			return nil, err
+		}
+		return &pb.PurgeDeletedClientsResponse{Purged: count}, nil
+	}
+
+	resp := &pb.PurgeDeletedClientsResponse{}
+	for {
+		n, err := s.purgeDeletedClientsBatch(ctx, olderThan)
+		if err != nil {
+			return nil, err
+		}
+		resp.Purged += n
+		if n < purgeDeletedClientsBatchSize {
+			return resp, nil
+		}
+	}
+}
+
+func (s *Service) purgeDeletedClientsBatch(ctx context.Context, olderThan time.Time) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, 0, purgeDeletedClientsBatchSize)
+	if err := tx.SelectContext(ctx, &ids, "SELECT id FROM clients WHERE deleted_at < ? LIMIT ? FOR UPDATE",
+		olderThan, purgeDeletedClientsBatchSize); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := archiveClients(ctx, tx, sq.Eq{"id": ids}); err != nil {
+		return 0, err
+	}
+	for _, dq := range []sq.DeleteBuilder{
+		sq.Delete("client_matches").Where(sq.Eq{"client_id": ids}),
+		sq.Delete("clients").Where(sq.Eq{"id": ids}),
+	} {
+		q, args, err := dq.ToSql()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// DeleteAllClients permanently removes every client, including soft deleted ones, and their matches,
+// keeping a copy of the clients in clients_archive.
+// As a safety interlock, req.ConfirmCount must be the current number of clients, which is what
+// a call with req.DryRun set returns. With req.FailIfMatches set, nothing is deleted if there are matches.
+func (s *Service) DeleteAllClients(ctx context.Context, req *pb.DeleteAllClientsRequest) (*pb.DeleteAllClientsResponse, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var count, matches int64
+	if err := tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM clients FOR UPDATE"); err != nil {
+		return nil, err
+	}
+	if err := tx.GetContext(ctx, &matches, "SELECT COUNT(*) FROM client_matches FOR UPDATE"); err != nil {
+		return nil, err
+	}
+	resp := &pb.DeleteAllClientsResponse{Deleted: count, DeletedMatches: matches}
+	if req.DryRun {
+		return resp, nil
+	}
+	if req.ConfirmCount != count {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"confirm_count %d doesn't match the %d existing clients, use dry_run to get it", req.ConfirmCount, count)
+	}
+	if matches > 0 && req.FailIfMatches {
+		return nil, status.Errorf(codes.FailedPrecondition, "there are %d matches", matches)
+	}
+	if err := archiveClients(ctx, tx, sq.And{}); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM client_matches"); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM clients"); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Sort returns req.Items sorted by req.Mode, or in their order with req.PreserveOrder, keeping only the first
+// of the items the mode finds equal (the same value in NUMERIC mode, say) with req.RemoveDuplicates, in
+// descending order with req.Descending
+func (s *Service) Sort(ctx context.Context, req *pb.SortRequest) (*pb.SortResponse, error) {
+	items := make([]string, len(req.Items))
+	copy(items, req.Items)
+	items, counts, err := sortedItems(items, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SortResponse{Items: items}
+	if req.ReturnCounts {
+		resp.Counts = counts
+	}
+	return resp, nil
+}
+
+// sortedItems sorts items in place as Sort does with the options of req, returning them without the duplicates
+// if asked, along with how many of the given items each of them stands for
+func sortedItems(items []string, req *pb.SortRequest) ([]string, []int64, error) {
+	if req.PreserveOrder && req.Descending {
+		return nil, nil, status.Error(codes.InvalidArgument, "preserve_order and descending are exclusive")
+	}
+	order, err := sortOrder(items, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !req.PreserveOrder {
+		sort.Slice(items, func(i, j int) bool { return order.less(items[i], items[j]) })
+	}
+
+	counts := make([]int64, 0, len(items))
+	if req.RemoveDuplicates {
+		unique := make([]string, 0, len(items))
+		index := make(map[string]int, len(items))
+		for _, item := range items {
+			key := order.key(item)
+			if i, ok := index[key]; ok {
+				counts[i]++
+				continue
+			}
+			index[key] = len(unique)
+			unique = append(unique, item)
+			counts = append(counts, 1)
+		}
+		items = unique
+	} else {
+		for range items {
+			counts = append(counts, 1)
+		}
+	}
+	if req.Descending {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+			counts[i], counts[j] = counts[j], counts[i]
+		}
+	}
+	return items, counts, nil
+}
+
+// itemOrder is how a Sort mode compares items: less orders them and key is the same for the items it finds
+// equal
+type itemOrder struct {
+	less func(a, b string) bool
+	key  func(item string) string
+}
+
+// ignoreCase tells if req asks to ignore the case, case_insensitive being a deprecated alias of ignore_case
+func ignoreCase(req *pb.SortRequest) bool {
+	return req.IgnoreCase || req.CaseInsensitive
+}
+
+// sortOrder returns the order req asks for the items
+func sortOrder(items []string, req *pb.SortRequest) (itemOrder, error) {
+	if req.Mode != pb.SortMode_STRING && (req.Locale != "" || ignoreCase(req) || req.IgnoreDiacritics) {
+		return itemOrder{}, status.Error(codes.InvalidArgument, "locale, ignore_case and ignore_diacritics only apply to the STRING mode")
+	}
+	switch req.Mode {
+	case pb.SortMode_STRING:
+		if req.Locale != "" {
+			return collateOrder(items, req)
+		}
+		if req.IgnoreDiacritics {
+			return itemOrder{}, status.Error(codes.InvalidArgument, "ignore_diacritics needs a locale")
+		}
+		if ignoreCase(req) {
+			fold := cases.Fold()
+			folded := make(map[string]string, len(items))
+			for _, item := range items {
+				folded[item] = fold.String(item)
+			}
+			return keyOrder(folded), nil
+		}
+		return itemOrder{
+			less: func(a, b string) bool { return a < b },
+			key:  func(item string) string { return item },
+		}, nil
+	case pb.SortMode_NUMERIC:
+		values := make(map[string]int64, len(items))
+		for i, item := range items {
+			value, err := strconv.ParseInt(item, 10, 64)
+			if err != nil {
+				return itemOrder{}, status.Errorf(codes.InvalidArgument, "items[%d]: %q is not an integer", i, item)
+			}
+			values[item] = value
+		}
+		return itemOrder{
+			less: func(a, b string) bool {
+				x, y := values[a], values[b]
+				return x < y || (x == y && a < b)
+			},
+			key: func(item string) string { return strconv.FormatInt(values[item], 10) },
+		}, nil
+	case pb.SortMode_NATURAL:
+		return itemOrder{
+			less: func(a, b string) bool {
+				c := naturalCompare(a, b)
+				return c < 0 || (c == 0 && a < b)
+			},
+			key: naturalKey,
+		}, nil
+	}
+	return itemOrder{}, status.Errorf(codes.InvalidArgument, "invalid mode %d", req.Mode)
+}
+
+// keyOrder orders the items by their keys, and bytewise those of the same key
+func keyOrder(keys map[string]string) itemOrder {
+	return itemOrder{
+		less: func(a, b string) bool {
+			x, y := keys[a], keys[b]
+			return x < y || (x == y && a < b)
+		},
+		key: func(item string) string { return keys[item] },
+	}
+}
+
+// naturalCompare compares a and b as the NATURAL mode orders them, returning 0 when they differ only by the
+// leading zeros of their numbers
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		var x, y string
+		x, a = naturalSegment(a)
+		y, b = naturalSegment(b)
+		if isASCIIDigit(x[0]) && isASCIIDigit(y[0]) {
+			// numbers of any size compare by their digits once the leading zeros are gone
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				if len(x) < len(y) {
+					return -1
+				}
+				return 1
+			}
+		}
+		if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+// naturalKey is item without the leading zeros of its numbers, which is the same for the items naturalCompare
+// finds equal
+func naturalKey(item string) string {
+	var key strings.Builder
+	for item != "" {
+		var segment string
+		segment, item = naturalSegment(item)
+		if isASCIIDigit(segment[0]) {
+			if trimmed := strings.TrimLeft(segment, "0"); trimmed != "" {
+				segment = trimmed
+			} else {
+				segment = "0"
+			}
+		}
+		key.WriteString(segment)
+	}
+	return key.String()
+}
+
+// naturalSegment splits s, which is not empty, after its leading run of ASCII digits or of anything else
+func naturalSegment(s string) (string, string) {
+	digits := isASCIIDigit(s[0])
+	i := 1
+	for i < len(s) && isASCIIDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// collateOrder orders the items by the collation of req.Locale, and bytewise those it collates as equal
+func collateOrder(items []string, req *pb.SortRequest) (itemOrder, error) {
+	// languages without a tailoring of their own, as pt, collate by the CLDR root collation
+	tag, err := language.Parse(req.Locale)
+	if err != nil {
+		return itemOrder{}, status.Errorf(codes.InvalidArgument, "invalid locale %q: %v", req.Locale, err)
+	}
+	var options []collate.Option
+	if ignoreCase(req) {
+		options = append(options, collate.IgnoreCase)
+	}
+	if req.IgnoreDiacritics {
+		options = append(options, collate.IgnoreDiacritics)
+	}
+	collator := collate.New(tag, options...)
+
+	var buf collate.Buffer
+	collationKeys := make(map[string]string, len(items))
+	for _, item := range items {
+		if _, ok := collationKeys[item]; !ok {
+			collationKeys[item] = string(collator.KeyFromString(&buf, item))
+		}
+	}
+	return keyOrder(collationKeys), nil
+}
@@ -2,31 +2,82 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/pedidopago/trainingsvc-clients/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// selectClientsSQL is the beginning of the queries reading clientRow values
+var selectClientsSQL = "SELECT " + strings.Join(clientColumns, ", ") + " FROM `clients`"
+
+// expectClientTags expects the query loading the tags of the clients just read
+func expectClientTags(mock sqlmock.Sqlmock, rows ...[2]string) {
+	r := sqlmock.NewRows([]string{"client_id", "tag"})
+	for _, row := range rows {
+		r.AddRow(row[0], row[1])
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT client_id, tag FROM client_tags WHERE client_id IN")).WillReturnRows(r)
+}
+
 func newTestService(t *testing.T) (*Service, sqlmock.Sqlmock) {
 	rdb, mock, err := sqlmock.New()
 	require.NoError(t, err)
 
 	db := sqlx.NewDb(rdb, "sqlmock")
 	service := &Service{
-		db: db,
+		db:     db,
+		config: Config{}.withDefaults(),
 	}
 	return service, mock
 }
 
+func TestNewServices(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	services := func(opts ...Option) []string {
+		sv := grpc.NewServer()
+		require.NoError(t, New(ctx, sv, Config{}, opts...))
+		var names []string
+		for name := range sv.GetServiceInfo() {
+			names = append(names, name)
+		}
+		return names
+	}
+	assert.ElementsMatch(t, []string{"pb.ClientsService", "grpc.health.v1.Health"}, services())
+	assert.ElementsMatch(t, []string{"pb.ClientsService", "grpc.health.v1.Health"}, services(WithReflection(false)))
+	assert.ElementsMatch(t, []string{"pb.ClientsService", "grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"},
+		services(WithReflection(true)))
+}
+
 func TestNewClient(t *testing.T) {
 	service, mock := newTestService(t)
 
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO clients.*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}).
+			AddRow("MOCKID", "Test", time.Now().Truncate(time.Second), 0, time.Now()))
+	expectClientTags(mock)
+	mock.ExpectCommit()
 	resp, err := service.NewClient(context.Background(), &pb.NewClientRequest{
 		Name:     "Test",
 		Birthday: time.Now().UnixNano(),
@@ -34,12 +85,13 @@ func TestNewClient(t *testing.T) {
 	})
 	assert.NotNil(t, resp)
 	assert.NoError(t, err)
+	assert.Equal(t, "Test", resp.Client.Name)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetClients(t *testing.T) {
 	service, mock := newTestService(t)
-	mock.ExpectQuery("SELECT id, name, birthday, score, created_at FROM `clients` WHERE id IN (?)").
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id IN (?)")).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}))
 	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{
 		Ids: []string{"MOCKID"},
@@ -48,3 +100,1617 @@ func TestGetClients(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestIncludeDeleted(t *testing.T) {
+	service, mock := newTestService(t)
+	deletedAt := time.Unix(0, time.Now().UnixNano())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+strings.Join(clientColumns, ", ")+", deleted_at FROM `clients` WHERE id IN (?,?)")+"$").
+		WithArgs("ALICE", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "deleted_at"}).
+			AddRow("ALICE", "Alice", nil).
+			AddRow("BOB", "Bob", deletedAt))
+	expectClientTags(mock)
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{Ids: []string{"ALICE", "BOB"}, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Clients, 2)
+	assert.Zero(t, resp.Clients[0].DeletedAt)
+	assert.Equal(t, deletedAt.UnixNano(), resp.Clients[1].DeletedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE score > ? ORDER BY score DESC, id ASC")).
+		WithArgs(int64(10)).WillReturnRows(sqlmock.NewRows([]string{"id", "score"}).AddRow("BOB", 20))
+	ids, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: ">"}, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BOB"}, ids.Ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsEmpty(t *testing.T) {
+	service, mock := newTestService(t)
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{RequireAll: true})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Clients)
+	assert.Empty(t, resp.MissingIds)
+
+	_, err = service.DeleteClients(context.Background(), &pb.DeleteClientsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsOrder(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL+" WHERE id IN (?,?,?,?)")).
+		WithArgs("CAROL", "ALICE", "MISSING", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow("ALICE", "Alice").
+			AddRow("BOB", "Bob").
+			AddRow("CAROL", "Carol"))
+	expectClientTags(mock)
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{
+		Ids: []string{"CAROL", "ALICE", "MISSING", "CAROL", "BOB"},
+	})
+	require.NoError(t, err)
+	ids := make([]string, 0, len(resp.Clients))
+	for _, c := range resp.Clients {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{"CAROL", "ALICE", "BOB"}, ids)
+	assert.Equal(t, []string{"MISSING"}, resp.MissingIds)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL+" WHERE id IN (?,?,?)")).
+		WithArgs("ALICE", "GONE", "NOPE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("ALICE", "Alice"))
+	_, err = service.GetClients(context.Background(), &pb.GetClientsRequest{
+		Ids:        []string{"ALICE", "GONE", "NOPE", "GONE"},
+		RequireAll: true,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "clients not found: GONE, NOPE", status.Convert(err).Message())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsChunks(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.GetClientsChunkSize = 2
+	service.config.GetClientsMaxIDs = 6
+
+	// chunks of distinct ids; the results are merged in the order requested
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL+" WHERE id IN (?,?)")).WithArgs("E", "D").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("D").AddRow("E"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL+" WHERE id IN (?,?)")).WithArgs("C", "B").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("C"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id IN (?)")).WithArgs("A").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT client_id, tag FROM client_tags WHERE client_id IN (?,?)")).WithArgs("E", "D").
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "tag"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT client_id, tag FROM client_tags WHERE client_id IN (?,?)")).WithArgs("C", "A").
+		WillReturnRows(sqlmock.NewRows([]string{"client_id", "tag"}).AddRow("A", "vip"))
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{Ids: []string{"E", "D", "E", "C", "B", "A"}})
+	require.NoError(t, err)
+	ids := make([]string, 0, len(resp.Clients))
+	for _, c := range resp.Clients {
+		ids = append(ids, c.Id)
+	}
+	assert.Equal(t, []string{"E", "D", "C", "A"}, ids)
+	assert.Equal(t, []string{"B"}, resp.MissingIds)
+	assert.Equal(t, []string{"vip"}, resp.Clients[3].Tags)
+
+	_, err = service.GetClients(context.Background(), &pb.GetClientsRequest{Ids: []string{"A", "B", "C", "D", "E", "F", "G"}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsReadMask(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM `clients` WHERE id IN (?,?) AND deleted_at IS NULL")).
+		WithArgs("ALICE", "BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("ALICE", "Alice").AddRow("BOB", "Bob"))
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{
+		Ids:      []string{"ALICE", "BOB"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.Client{{Id: "ALICE", Name: "Alice"}, {Id: "BOB", Name: "Bob"}}, resp.Clients)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score, anonymized_at, deleted_at FROM `clients` WHERE id IN (?)") + "$").
+		WithArgs("BOB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "anonymized_at", "deleted_at"}).AddRow("BOB", 20, nil, time.Now()))
+	expectClientTags(mock, [2]string{"BOB", "vip"})
+	resp, err = service.GetClients(context.Background(), &pb.GetClientsRequest{
+		Ids:            []string{"BOB"},
+		IncludeDeleted: true,
+		ReadMask:       &field_mask.FieldMask{Paths: []string{"tags", "deleted_at", "anonymized", "score", "id"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Clients, 1)
+	assert.Equal(t, []string{"vip"}, resp.Clients[0].Tags)
+	assert.Equal(t, int64(20), resp.Clients[0].Score)
+	assert.NotZero(t, resp.Clients[0].DeletedAt)
+
+	_, err = service.GetClients(context.Background(), &pb.GetClientsRequest{
+		Ids:      []string{"BOB"},
+		ReadMask: &field_mask.FieldMask{Paths: []string{"name", "password"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "password")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTouchClient(t *testing.T) {
+	service, mock := newTestService(t)
+	touchSQL := regexp.QuoteMeta("UPDATE clients SET last_seen_at = NOW(6) WHERE id = ? AND deleted_at IS NULL")
+
+	mock.ExpectExec(touchSQL).WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := service.TouchClient(context.Background(), &pb.TouchClientRequest{Id: "MOCKID"})
+	assert.NoError(t, err)
+
+	mock.ExpectExec(touchSQL).WithArgs("MISSING").WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = service.TouchClient(context.Background(), &pb.TouchClientRequest{Id: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	inactiveSince := time.Now().Add(-90 * 24 * time.Hour).UnixNano()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND last_seen_at < ?")).
+		WithArgs(time.Unix(0, inactiveSince)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	resp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{
+		LastSeenAt: &pb.Int64Comp{Value: inactiveSince, Op: "<"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MOCKID"}, resp.Ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientFilters(t *testing.T) {
+	service, _ := newTestService(t)
+	at := time.Date(2020, 5, 17, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name string
+		req  *pb.QueryClientsRequest
+		sql  string
+		args []interface{}
+	}{
+		{"none", &pb.QueryClientsRequest{}, "", nil},
+		{"id", &pb.QueryClientsRequest{Id: &pb.OptString{Value: "MOCKID"}}, " AND id = ?", []interface{}{"MOCKID"}},
+		{"ids", &pb.QueryClientsRequest{Ids: []string{"A", "B"}, Score: &pb.Int64Comp{Value: 100, Op: ">"}},
+			" AND id IN (?,?) AND score > ?", []interface{}{"A", "B", int64(100)}},
+		{"name", &pb.QueryClientsRequest{Name: &pb.OptString{Value: "Ali%"}}, " AND name LIKE ?", []interface{}{"Ali%"}},
+		{"name exact", &pb.QueryClientsRequest{Name: &pb.OptString{Value: "50% Ali_ce"}, NameMatch: pb.NameMatch_EXACT},
+			" AND name = ?", []interface{}{"50% Ali_ce"}},
+		{"name prefix", &pb.QueryClientsRequest{Name: &pb.OptString{Value: "50% Ali_"}, NameMatch: pb.NameMatch_PREFIX},
+			" AND name LIKE ?", []interface{}{`50\% Ali\_%`}},
+		{"name contains", &pb.QueryClientsRequest{Name: &pb.OptString{Value: `a_b\c`}, NameMatch: pb.NameMatch_CONTAINS},
+			" AND name LIKE ?", []interface{}{`%a\_b\\c%`}},
+		{"search", &pb.QueryClientsRequest{Search: "  Silva, 50%"}, " AND name LIKE ? AND name LIKE ?", []interface{}{"%Silva%", `%50\%%`}},
+		{"birthday", &pb.QueryClientsRequest{Birthday: &pb.Int64Comp{Value: at.UnixNano(), Op: "<"}},
+			" AND birthday < ?", []interface{}{at}},
+		{"birthday range", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: at.UnixNano()}, BirthdayTo: &pb.OptInt64{Value: at.UnixNano()}},
+			" AND birthday BETWEEN ? AND ?", []interface{}{at, at}},
+		{"birthday is null", &pb.QueryClientsRequest{BirthdayIsNull: &pb.OptBool{Value: true}}, " AND birthday IS NULL", []interface{}(nil)},
+		{"birthday is not null", &pb.QueryClientsRequest{BirthdayIsNull: &pb.OptBool{}, Score: &pb.Int64Comp{Value: 1}},
+			" AND birthday IS NOT NULL AND score = ?", []interface{}{int64(1)}},
+		{"birthday from", &pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: at.UnixNano()}}, " AND birthday >= ?", []interface{}{at}},
+		{"birthday to", &pb.QueryClientsRequest{BirthdayTo: &pb.OptInt64{Value: at.UnixNano()}}, " AND birthday <= ?", []interface{}{at}},
+		{"score", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: ">="}}, " AND score >= ?", []interface{}{int64(10)}},
+		{"score without op", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10}}, " AND score = ?", []interface{}{int64(10)}},
+		{"score with an unknown op", &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: "; DROP"}}, " AND score = ?", []interface{}{int64(10)}},
+		{"age range", &pb.QueryClientsRequest{AgeMin: &pb.OptInt64{Value: 18}, AgeMax: &pb.OptInt64{Value: 25}},
+			" AND birthday < CURDATE() - INTERVAL ? YEAR + INTERVAL 1 DAY AND birthday >= CURDATE() - INTERVAL ? YEAR + INTERVAL 1 DAY",
+			[]interface{}{int64(18), int64(26)}},
+		{"age max", &pb.QueryClientsRequest{AgeMax: &pb.OptInt64{Value: 0}},
+			" AND birthday >= CURDATE() - INTERVAL ? YEAR + INTERVAL 1 DAY", []interface{}{int64(1)}},
+		{"score range", &pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: 100}, ScoreMax: &pb.OptInt64{Value: 500}},
+			" AND score >= ? AND score <= ?", []interface{}{int64(100), int64(500)}},
+		{"score single value range", &pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: 7}, ScoreMax: &pb.OptInt64{Value: 7}},
+			" AND score >= ? AND score <= ?", []interface{}{int64(7), int64(7)}},
+		{"score min", &pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: -5}}, " AND score >= ?", []interface{}{int64(-5)}},
+		{"created_at", &pb.QueryClientsRequest{CreatedAt: &pb.Int64Comp{Value: at.UnixNano(), Op: ">"}},
+			" AND created_at > ?", []interface{}{at}},
+		{"created_within", &pb.QueryClientsRequest{CreatedWithin: 86400},
+			" AND created_at >= NOW() - INTERVAL ? SECOND", []interface{}{int64(86400)}},
+		{"updated_at", &pb.QueryClientsRequest{UpdatedAt: &pb.Int64Comp{Value: at.UnixNano(), Op: "<="}},
+			" AND updated_at <= ?", []interface{}{at}},
+		{"last_seen_at", &pb.QueryClientsRequest{LastSeenAt: &pb.Int64Comp{Value: at.UnixNano(), Op: "!="}},
+			" AND last_seen_at != ?", []interface{}{at}},
+		{"email", &pb.QueryClientsRequest{Email: &pb.OptString{Value: "a@b.com"}}, " AND email = ?", []interface{}{"a@b.com"}},
+		{"external_id", &pb.QueryClientsRequest{ExternalId: &pb.OptString{Value: "CRM-1"}}, " AND external_id = ?", []interface{}{"CRM-1"}},
+		{"phone", &pb.QueryClientsRequest{Phone: &pb.OptString{Value: "11 91234-5678"}}, " AND phone = ?", []interface{}{"+5511912345678"}},
+		{"status", &pb.QueryClientsRequest{Status: []pb.ClientStatus{pb.ClientStatus_ACTIVE, pb.ClientStatus_BANNED}},
+			" AND status IN (?,?)", []interface{}{"ACTIVE", "BANNED"}},
+		{"tags", &pb.QueryClientsRequest{Tags: []string{"vip"}},
+			" AND id IN (SELECT client_id FROM client_tags WHERE tag IN (?))", []interface{}{"vip"}},
+		{"filter groups", &pb.QueryClientsRequest{
+			Status: []pb.ClientStatus{pb.ClientStatus_ACTIVE},
+			FilterGroups: []*pb.QueryClientsRequest{
+				{Name: &pb.OptString{Value: "a%"}},
+				{Score: &pb.Int64Comp{Value: 1000, Op: ">"}, Tags: []string{"vip"}},
+			},
+		},
+			" AND status IN (?) AND ((name LIKE ?) OR (score > ? AND id IN (SELECT client_id FROM client_tags WHERE tag IN (?))))",
+			[]interface{}{"ACTIVE", "a%", int64(1000), "vip"}},
+		{"all", &pb.QueryClientsRequest{
+			Id:         &pb.OptString{Value: "MOCKID"},
+			Name:       &pb.OptString{Value: "Ali%"},
+			Birthday:   &pb.Int64Comp{Value: at.UnixNano(), Op: "<"},
+			Score:      &pb.Int64Comp{Value: 10, Op: ">="},
+			CreatedAt:  &pb.Int64Comp{Value: at.UnixNano(), Op: ">"},
+			UpdatedAt:  &pb.Int64Comp{Value: at.UnixNano(), Op: "<="},
+			LastSeenAt: &pb.Int64Comp{Value: at.UnixNano(), Op: "!="},
+			Email:      &pb.OptString{Value: "a@b.com"},
+			ExternalId: &pb.OptString{Value: "CRM-1"},
+			Phone:      &pb.OptString{Value: "11 91234-5678"},
+			Status:     []pb.ClientStatus{pb.ClientStatus_SUSPENDED},
+			Tags:       []string{"vip", "trial"},
+		},
+			" AND id = ? AND name LIKE ? AND birthday < ? AND score >= ? AND created_at > ? AND updated_at <= ? " +
+				"AND last_seen_at != ? AND email = ? AND external_id = ? AND phone = ? AND status IN (?) " +
+				"AND id IN (SELECT client_id FROM client_tags WHERE tag IN (?,?))",
+			[]interface{}{"MOCKID", "Ali%", at, int64(10), at, at, at, "a@b.com", "CRM-1", "+5511912345678", "SUSPENDED", "vip", "trial"}},
+	}
+	_, _, err := service.filteredClients(&pb.QueryClientsRequest{Name: &pb.OptString{Value: "x"}, NameMatch: pb.NameMatch(7)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{Id: &pb.OptString{Value: "A"}, Ids: []string{"A"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{CreatedAt: &pb.Int64Comp{Value: 1}, CreatedWithin: 60})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{CreatedWithin: -60})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	for _, groups := range [][]*pb.QueryClientsRequest{
+		{{Score: &pb.Int64Comp{Value: 1}}, {}},
+		{{NameMatch: pb.NameMatch_EXACT}},
+		{{Score: &pb.Int64Comp{Value: 1}, Limit: 10}},
+		{{Score: &pb.Int64Comp{Value: 1}, IncludeDeleted: true}},
+		{{FilterGroups: []*pb.QueryClientsRequest{{Score: &pb.Int64Comp{Value: 1}}}}},
+		make([]*pb.QueryClientsRequest, maxFilterGroups+1),
+	} {
+		_, _, err = service.filteredClients(&pb.QueryClientsRequest{FilterGroups: groups})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{Search: "+-*"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{BirthdayFrom: &pb.OptInt64{Value: 2}, BirthdayTo: &pb.OptInt64{Value: 1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{AgeMin: &pb.OptInt64{Value: 30}, AgeMax: &pb.OptInt64{Value: 20}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{AgeMin: &pb.OptInt64{Value: -1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = service.filteredClients(&pb.QueryClientsRequest{ScoreMin: &pb.OptInt64{Value: 500}, ScoreMax: &pb.OptInt64{Value: 100}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, hasFilters, err := service.filteredClients(tt.req)
+			require.NoError(t, err)
+			q, args, err := filtered.Columns("id").ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, "SELECT id FROM clients WHERE deleted_at IS NULL"+tt.sql, q)
+			assert.Equal(t, tt.args, args)
+			assert.Equal(t, tt.sql != "", hasFilters)
+		})
+	}
+}
+
+func TestClientIDsFilter(t *testing.T) {
+	ids := make([]string, 2*queryClientsIDsChunkSize+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	q, args, err := idsFilter(ids).ToSql()
+	require.NoError(t, err)
+	list := "(?" + strings.Repeat(",?", queryClientsIDsChunkSize-1) + ")"
+	assert.Equal(t, "(id IN "+list+" OR id IN "+list+" OR id IN (?))", q)
+	assert.Len(t, args, len(ids))
+	assert.Equal(t, "2000", args[len(args)-1])
+
+	// an empty list matches nothing, instead of being invalid SQL
+	q, args, err = idsFilter(nil).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "(1=0)", q)
+	assert.Empty(t, args)
+}
+
+func TestQueryClientsPage(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.QueryClientsMaxLimit = 500
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL ORDER BY score DESC, id ASC LIMIT 100 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	_, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY score DESC, id ASC LIMIT 500 OFFSET 20")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Limit: 5000, Offset: 20})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND score > ? ORDER BY score DESC, id ASC") + "$").
+		WithArgs(int64(0)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{NoLimit: true, Limit: 10, Score: &pb.Int64Comp{Op: ">"}})
+	require.NoError(t, err)
+
+	// without filters, no_limit is ignored
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL ORDER BY score DESC, id ASC LIMIT 10 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{NoLimit: true, Limit: 10})
+	require.NoError(t, err)
+
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Offset: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsRequireFilters(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.RequireQueryFilters = true
+
+	_, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{IncludeDeleted: true, NoLimit: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL ORDER BY score DESC, id ASC LIMIT 100 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{AllowUnfiltered: true, NoLimit: true})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND status IN (?) ORDER BY score DESC, id ASC LIMIT 100 OFFSET 0")).
+		WithArgs("BANNED").WillReturnRows(sqlmock.NewRows([]string{"id", "score"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Status: []pb.ClientStatus{pb.ClientStatus_BANNED}})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsPageToken(t *testing.T) {
+	service, mock := newTestService(t)
+	cols := []string{"id", "score"}
+	filter := &pb.Int64Comp{Value: 10, Op: ">="}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND score >= ? " +
+		"ORDER BY score DESC, id ASC LIMIT 2 OFFSET 0")).
+		WithArgs(int64(10)).WillReturnRows(sqlmock.NewRows(cols).AddRow("ALICE", 50).AddRow("BOB", 40))
+	resp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Score: filter, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALICE", "BOB"}, resp.Ids)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	// the next page starts after (40, BOB), and comes back short: it is the last one
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND score >= ? "+
+		"AND ((score < ? OR score IS NULL) OR (score = ? AND id > ?)) ORDER BY score DESC, id ASC LIMIT 2 OFFSET 0")).
+		WithArgs(int64(10), int64(40), int64(40), "BOB").WillReturnRows(sqlmock.NewRows(cols).AddRow("CAROL", 40))
+	next, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Score: filter, Limit: 2, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CAROL"}, next.Ids)
+	assert.Empty(t, next.NextPageToken)
+
+	// clients without a score are last, after the ones with a score
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY score DESC, id ASC LIMIT 1 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("DAVE", nil))
+	resp, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Limit: 1})
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL AND (score IS NULL AND id > ?) ORDER BY")).
+		WithArgs("DAVE").WillReturnRows(sqlmock.NewRows(cols))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Limit: 1, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+
+	// tokens are only good for the filters they were issued for
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Score: filter, Limit: 1, PageToken: resp.NextPageToken})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{PageToken: "not a token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Offset: 2, PageToken: resp.NextPageToken})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsTotalCount(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND score >= ? " +
+		"AND ((score < ? OR score IS NULL) OR (score = ? AND id > ?)) ORDER BY score DESC, id ASC LIMIT 50 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}).AddRow("ALICE", 50))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL AND score >= ?") + "$").
+		WithArgs(int64(10)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3214))
+	req := &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 10, Op: ">="}, Limit: 50, IncludeTotalCount: true}
+	hash, err := queryClientsFilterHash(req)
+	require.NoError(t, err)
+	req.PageToken, err = encodeQueryClientsPageToken(clientRow{ID: "ZED", Score: sql.NullInt64{Int64: 60, Valid: true}}, []clientSortKey{{column: "score"}}, hash)
+	require.NoError(t, err)
+	resp, err := service.QueryClients(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3214), resp.TotalCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsOrder(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM clients WHERE deleted_at IS NULL ORDER BY name ASC, id ASC LIMIT 1 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("ALICE", "Alice"))
+	resp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_NAME, Ascending: true, Limit: 1})
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL AND (name > ? OR (name = ? AND id > ?)) ORDER BY name ASC, id ASC")).
+		WithArgs("Alice", "Alice", "ALICE").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{
+		OrderBy:   pb.ClientOrderBy_NAME,
+		Ascending: true,
+		Limit:     1,
+		PageToken: resp.NextPageToken,
+	})
+	require.NoError(t, err)
+
+	// newest first
+	createdAt := time.Unix(0, time.Now().UnixNano())
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, created_at FROM clients WHERE deleted_at IS NULL ORDER BY created_at DESC, id ASC LIMIT 1 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("BOB", createdAt))
+	resp, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_CREATED_AT, Limit: 1})
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("AND ((created_at < ? OR created_at IS NULL) OR (created_at = ? AND id > ?)) ORDER BY created_at DESC")).
+		WithArgs(createdAt, createdAt, "BOB").WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_CREATED_AT, Limit: 1, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+
+	// birthdays ascending: clients without one come first
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY birthday ASC, id ASC")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "birthday"}).AddRow("CAROL", nil))
+	resp, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_BIRTHDAY, Ascending: true, Limit: 1})
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("AND (birthday IS NOT NULL OR (birthday IS NULL AND id > ?)) ORDER BY birthday ASC")).
+		WithArgs("CAROL").WillReturnRows(sqlmock.NewRows([]string{"id", "birthday"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_BIRTHDAY, Ascending: true, Limit: 1, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+
+	// a token is only good for the order it was issued for
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy_BIRTHDAY, Limit: 1, PageToken: resp.NextPageToken})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{OrderBy: pb.ClientOrderBy(42)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsSearch(t *testing.T) {
+	service, mock := newTestService(t)
+
+	// without fulltext support, the words are matched with LIKE and the order is the usual one
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND name LIKE ? AND name LIKE ? "+
+		"ORDER BY score DESC, id ASC LIMIT 1 OFFSET 0")).
+		WithArgs("%maria%", "%silva%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}).AddRow("MARIA", 10))
+	resp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Search: "maria silva", Limit: 1})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.NextPageToken)
+
+	service.config.FullTextSearch = true
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND MATCH(name) AGAINST (? IN BOOLEAN MODE) "+
+		"ORDER BY MATCH(name) AGAINST (? IN BOOLEAN MODE) DESC, score DESC, id ASC LIMIT 1 OFFSET 1")).
+		WithArgs("+maria* +silva*", "+maria* +silva*").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score"}).AddRow("MARIA", 10))
+	resp, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Search: `"maria" -silva`, Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MARIA"}, resp.Ids)
+	assert.Empty(t, resp.NextPageToken)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL AND MATCH(name) AGAINST (? IN BOOLEAN MODE)")).
+		WithArgs("+maria*").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	count, err := service.CountClients(context.Background(), &pb.CountClientsRequest{Filter: &pb.QueryClientsRequest{Search: "maria"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count.Count)
+
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Search: "maria", PageToken: "x"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsReturnClients(t *testing.T) {
+	service, mock := newTestService(t)
+	page := " FROM clients WHERE deleted_at IS NULL AND score > ? ORDER BY name ASC, id ASC LIMIT 2 OFFSET 0"
+	rows := func(columns ...string) *sqlmock.Rows {
+		return sqlmock.NewRows(columns).AddRow("ALICE", "Alice", 50).AddRow("BOB", "Bob", 20)
+	}
+	query := func(returnClients bool) *pb.QueryClientsRequest {
+		return &pb.QueryClientsRequest{
+			Score:         &pb.Int64Comp{Value: 10, Op: ">"},
+			OrderBy:       pb.ClientOrderBy_NAME,
+			Ascending:     true,
+			Limit:         2,
+			ReturnClients: returnClients,
+		}
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name" + page)).WithArgs(int64(10)).WillReturnRows(rows("id", "name", "score"))
+	ids, err := service.QueryClients(context.Background(), query(false))
+	require.NoError(t, err)
+	assert.Empty(t, ids.Clients)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + strings.Join(clientColumns, ", ") + page)).WithArgs(int64(10)).
+		WillReturnRows(rows("id", "name", "score"))
+	expectClientTags(mock, [2]string{"BOB", "vip"})
+	clients, err := service.QueryClients(context.Background(), query(true))
+	require.NoError(t, err)
+	assert.Equal(t, ids.Ids, clients.Ids)
+	assert.Equal(t, ids.NextPageToken, clients.NextPageToken)
+	require.Len(t, clients.Clients, 2)
+	assert.Equal(t, "Alice", clients.Clients[0].Name)
+	assert.Equal(t, int64(20), clients.Clients[1].Score)
+	assert.Equal(t, []string{"vip"}, clients.Clients[1].Tags)
+
+	// the tokens of both modes are interchangeable
+	next := query(false)
+	next.PageToken = clients.NextPageToken
+	mock.ExpectQuery(regexp.QuoteMeta("AND (name > ? OR (name = ? AND id > ?)) ORDER BY name ASC, id ASC")).
+		WithArgs(int64(10), "Bob", "Bob", "BOB").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	_, err = service.QueryClients(context.Background(), next)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsSortKeys(t *testing.T) {
+	service, mock := newTestService(t)
+	sort := []*pb.ClientSortKey{{Column: pb.ClientOrderBy_SCORE}, {Column: pb.ClientOrderBy_NAME, Ascending: true}}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score, name FROM clients WHERE deleted_at IS NULL " +
+		"ORDER BY score DESC, name ASC, id ASC LIMIT 1 OFFSET 0")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "name"}).AddRow("ALICE", 10, "Alice"))
+	resp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Sort: sort, OrderBy: pb.ClientOrderBy_BIRTHDAY, Limit: 1})
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL AND ((score < ? OR score IS NULL) OR (score = ? AND name > ?) "+
+		"OR (score = ? AND name = ? AND id > ?)) ORDER BY score DESC, name ASC, id ASC")).
+		WithArgs(int64(10), int64(10), "Alice", int64(10), "Alice", "ALICE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "name"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Sort: sort, OrderBy: pb.ClientOrderBy_BIRTHDAY, Limit: 1, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Sort: []*pb.ClientSortKey{
+		{Column: pb.ClientOrderBy_NAME},
+		{Column: pb.ClientOrderBy_NAME, Ascending: true},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "sort[1]")
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Sort: []*pb.ClientSortKey{{Column: pb.ClientOrderBy(9)}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "allowed: SCORE, NAME, CREATED_AT, BIRTHDAY")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountClients(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL AND phone = ?")).
+		WithArgs("+5511912345678").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+	resp, err := service.CountClients(context.Background(), &pb.CountClientsRequest{Filter: &pb.QueryClientsRequest{
+		Phone: &pb.OptString{Value: "5511 91234-5678"},
+		Limit: 1,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Count)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at IS NULL") + "$").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(10))
+	resp, err = service.CountClients(context.Background(), &pb.CountClientsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateClient(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectBegin()
+	// the baseline is the part of the new score the matches of the client don't make up
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET name = ?, score = ?, score_baseline = ? - (SELECT COALESCE(SUM(score), 0) FROM client_matches WHERE client_id = ?), "+
+		"version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs("Bob", int64(10), int64(10), "MOCKID", "MOCKID").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+		WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}).
+			AddRow("MOCKID", "Bob", nil, 10, time.Now()))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:    "MOCKID",
+		Name:  &pb.OptString{Value: "Bob"},
+		Score: &pb.OptInt64{Value: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", resp.Client.Name)
+	assert.Equal(t, int64(10), resp.Client.Score)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateClientErrors(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.UpdateClient(context.Background(), &pb.UpdateClientRequest{Id: "MOCKID"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE clients").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM `clients`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}))
+	mock.ExpectRollback()
+	_, err = service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:   "MOCKID",
+		Name: &pb.OptString{Value: "Bob"},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateClientExpectedVersion(t *testing.T) {
+	service, mock := newTestService(t)
+	// two concurrent updates expecting version 1: only one of them can match the row
+	mock.MatchExpectationsInOrder(false)
+	updateSQL := regexp.QuoteMeta("UPDATE clients SET name = ?, version = version + 1, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL AND version = ?")
+	for _, affected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateSQL).WithArgs(sqlmock.AnyArg(), "MOCKID", int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, affected))
+		mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+			WithArgs("MOCKID").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at", "version"}).
+				AddRow("MOCKID", "Bob", nil, 10, time.Now(), 2))
+		expectClientTags(mock)
+	}
+	mock.ExpectCommit()
+	mock.ExpectRollback()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, name := range []string{"Bob", "Carol"} {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			_, errs[i] = service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+				Id:              "MOCKID",
+				Name:            &pb.OptString{Value: name},
+				ExpectedVersion: 1,
+			})
+		}(i, name)
+	}
+	wg.Wait()
+	codeSet := []codes.Code{status.Code(errs[0]), status.Code(errs[1])}
+	assert.ElementsMatch(t, []codes.Code{codes.OK, codes.Aborted}, codeSet)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClient(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+		WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}).
+			AddRow("MOCKID", "Alice", time.Now(), 100, time.Now()))
+	expectClientTags(mock)
+	resp, err := service.GetClient(context.Background(), &pb.GetClientRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", resp.Client.Name)
+
+	mock.ExpectQuery("SELECT (.+) FROM `clients`").WithArgs("MISSING").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}))
+	_, err = service.GetClient(context.Background(), &pb.GetClientRequest{Id: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewClientWithID(t *testing.T) {
+	service, mock := newTestService(t)
+	id := utils.SecureID().String()
+
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Id: "not-an-id", Name: "Test"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,score,score_baseline,updated_at)")).
+		WithArgs(id, "Test", int64(0), int64(0)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Test"))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.NewClient(context.Background(), &pb.NewClientRequest{Id: id, Name: "Test"})
+	require.NoError(t, err)
+	assert.Equal(t, id, resp.Id)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO clients").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '" + id + "' for key 'PRIMARY'"})
+	mock.ExpectRollback()
+	_, err = service.NewClient(context.Background(), &pb.NewClientRequest{Id: id, Name: "Test"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, id, st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientExists(t *testing.T) {
+	service, mock := newTestService(t)
+
+	existsSQL := regexp.QuoteMeta("SELECT 1 FROM clients WHERE id = ? AND deleted_at IS NULL LIMIT 1")
+	mock.ExpectQuery(existsSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	resp, err := service.ClientExists(context.Background(), &pb.ClientExistsRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+	assert.True(t, resp.Exists)
+
+	mock.ExpectQuery(existsSQL).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	resp, err = service.ClientExists(context.Background(), &pb.ClientExistsRequest{Id: "MISSING"})
+	require.NoError(t, err)
+	assert.False(t, resp.Exists)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE id IN (?,?,?) AND deleted_at IS NULL")).
+		WithArgs("C", "B", "A").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A").AddRow("C"))
+	bresp, err := service.ClientsExist(context.Background(), &pb.ClientsExistRequest{Ids: []string{"C", "B", "A"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "A"}, bresp.Ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewClients(t *testing.T) {
+	service, mock := newTestService(t)
+
+	reqs := make([]*pb.NewClientRequest, newClientsBatchSize+1)
+	for i := range reqs {
+		reqs[i] = &pb.NewClientRequest{Name: "Test"}
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO clients \\(id,name,birthday,score,score_baseline,email,phone,metadata,notes,external_id,updated_at\\) VALUES").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.com' for key 'uniq_email'"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO clients \\(id,name,birthday,score,score_baseline,email,phone,metadata,notes,external_id,updated_at\\) VALUES \\(\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,\\?,NOW\\(\\)\\)$").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.NewClients(context.Background(), &pb.NewClientsRequest{Clients: reqs})
+	require.NoError(t, err)
+	require.Len(t, resp.Ids, len(reqs))
+	assert.Len(t, resp.FailedIndexes, newClientsBatchSize)
+	assert.Equal(t, "", resp.Ids[0])
+	assert.NotEqual(t, "", resp.Ids[newClientsBatchSize])
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// errors other than duplicates aren't about the clients of the batch, so they fail the call
+	for _, batchErr := range []error{errors.New("connection lost"), context.Canceled} {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO clients").WillReturnError(batchErr)
+		mock.ExpectRollback()
+		_, err = service.NewClients(context.Background(), &pb.NewClientsRequest{Clients: reqs[:2]})
+		assert.Equal(t, batchErr, err)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = service.NewClients(context.Background(), &pb.NewClientsRequest{
+		Clients: []*pb.NewClientRequest{{Name: "A"}, {Name: "B", Id: utils.SecureID().String()}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "clients[1]: id")
+	_, err = service.NewClients(context.Background(), &pb.NewClientsRequest{
+		Clients: []*pb.NewClientRequest{{Name: "A", IdempotencyKey: "KEY"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCloneClient(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + strings.Join(clientColumns, ", ") +
+		" FROM clients WHERE id = ? AND deleted_at IS NULL LOCK IN SHARE MODE")).
+		WithArgs("SOURCE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "phone", "status"}).
+			AddRow("SOURCE", "Alice", 50, "+5511912345678", "ACTIVE"))
+	// without copy_score, the score of the copy is the one of its matches
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(score), 0) FROM client_matches WHERE client_id = ?")).
+		WithArgs("SOURCE").WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(30))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,birthday,score,score_baseline,phone,metadata,notes,status,updated_at) "+
+		"VALUES (?,?,?,?,?,?,?,?,?,NOW())")).
+		WithArgs(sqlmock.AnyArg(), "Alice (copy)", nil, int64(30), int64(0), "+5511912345678", nil, nil, "ACTIVE").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_tags (client_id, tag) SELECT ?, tag FROM client_tags WHERE client_id = ?")).
+		WithArgs(sqlmock.AnyArg(), "SOURCE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_matches (client_id, score, opponent_id, result, played_at, match_type, created_at, season_id) "+
+		"SELECT ?, score, opponent_id, result, played_at, match_type, created_at, season_id FROM client_matches WHERE client_id = ?")).
+		WithArgs(sqlmock.AnyArg(), "SOURCE").WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}).AddRow("CLONE", "Alice (copy)", 30))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.CloneClient(context.Background(), &pb.CloneClientRequest{
+		SourceId:    "SOURCE",
+		Name:        &pb.OptString{Value: "Alice (copy)"},
+		CopyMatches: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice (copy)", resp.Client.Name)
+	assert.Equal(t, int64(30), resp.Client.Score)
+
+	// with neither, the copy starts at 0
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM clients").WithArgs("SOURCE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "status"}).AddRow("SOURCE", "Alice", 50, "ACTIVE"))
+	mock.ExpectExec("INSERT INTO clients").
+		WithArgs(sqlmock.AnyArg(), "Alice", nil, int64(0), int64(0), nil, nil, nil, "ACTIVE").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO client_tags").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}).AddRow("CLONE", "Alice", 0))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err = service.CloneClient(context.Background(), &pb.CloneClientRequest{SourceId: "SOURCE"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Client.Score)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM clients").WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+	_, err = service.CloneClient(context.Background(), &pb.CloneClientRequest{SourceId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertClient(t *testing.T) {
+	service, mock := newTestService(t)
+	id := utils.SecureID().String()
+	existsSQL := regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(existsSQL).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,birthday,score,score_baseline,updated_at) "+
+		"VALUES (?,?,?,?,? - (SELECT COALESCE(SUM(score), 0) FROM client_matches WHERE client_id = ?),NOW()) "+
+		"ON DUPLICATE KEY UPDATE name = VALUES(name), birthday = VALUES(birthday), score = VALUES(score), "+
+		"score_baseline = VALUES(score_baseline), version = version + 1, updated_at = VALUES(updated_at)")).
+		WithArgs(id, "Bob", nil, int64(5), int64(5), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Bob", Score: 5})
+	require.NoError(t, err)
+	assert.True(t, resp.Created)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(existsSQL).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO clients").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	resp, err = service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Bobby", Score: 5})
+	require.NoError(t, err)
+	assert.False(t, resp.Created)
+
+	_, err = service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Name: "Bob"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertDeletedClient(t *testing.T) {
+	service, mock := newTestService(t)
+	id := utils.SecureID().String()
+
+	// the tombstone is left alone, the client has to be restored
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")).
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+	mock.ExpectRollback()
+	_, err := service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Bob", Score: 5})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "client "+id+" is deleted, restore it first", status.Convert(err).Message())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewMatch(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status, score FROM clients WHERE id = ? AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_matches (client_id,score,season_id) VALUES (?,?,(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1))")).
+		WithArgs("MOCKID", int64(5)).WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = score + ?, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(5), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	createdAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT created_at FROM client_matches WHERE id = ?")).WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	mock.ExpectCommit()
+	resp, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.Id)
+	assert.Equal(t, createdAt.UnixNano(), resp.CreatedAt)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	mock.ExpectRollback()
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MISSING", Score: 5})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "MISSING")
+
+	// deleted after the check: the score update misses and the insert is rolled back
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("GONE").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectExec("INSERT INTO client_matches").WithArgs("GONE", int64(5)).WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec("UPDATE clients SET score").WithArgs(int64(5), "GONE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "GONE", Score: 5})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "GONE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("SUSPENDED"))
+	mock.ExpectRollback()
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 5})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewMatchOpponent(t *testing.T) {
+	service, mock := newTestService(t)
+	playedAt := time.Unix(0, time.Now().Add(-time.Hour).UnixNano())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM clients WHERE id = ? AND deleted_at IS NULL)")).
+		WithArgs("RIVAL").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_matches (client_id,score,opponent_id,result,played_at,season_id) "+
+		"VALUES (?,?,?,?,?,(SELECT id FROM seasons ORDER BY started_at DESC LIMIT 1))")).
+		WithArgs("MOCKID", int64(3), "RIVAL", "WIN", playedAt).WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec("UPDATE clients SET score = score").WithArgs(int64(3), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT created_at FROM client_matches").WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+	mock.ExpectCommit()
+	resp, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{
+		ClientId:   "MOCKID",
+		Score:      3,
+		OpponentId: "RIVAL",
+		Result:     pb.MatchResult_WIN,
+		PlayedAt:   playedAt.UnixNano(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), resp.Id)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, score FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", OpponentId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", OpponentId: "MOCKID"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Result: 42})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", PlayedAt: time.Now().Add(time.Hour).UnixNano()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnonymizeClient(t *testing.T) {
+	service, mock := newTestService(t)
+	selectSQL := regexp.QuoteMeta("SELECT anonymized_at FROM clients WHERE id = ? FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"anonymized_at"}).AddRow(nil))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET anonymized_at = NOW(), birthday = ?, email = ?, external_id = ?, "+
+		"metadata = ?, name = ?, notes = ?, phone = ?, version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs(nil, nil, nil, nil, anonymizedName, nil, nil, "MOCKID").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients_archive SET anonymized_at = NOW(), birthday = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	_, err := service.AnonymizeClient(context.Background(), &pb.AnonymizeClientRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+
+	// already anonymized: nothing is written
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"anonymized_at"}).AddRow(time.Now()))
+	mock.ExpectRollback()
+	_, err = service.AnonymizeClient(context.Background(), &pb.AnonymizeClientRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectSQL).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"anonymized_at"}))
+	mock.ExpectRollback()
+	_, err = service.AnonymizeClient(context.Background(), &pb.AnonymizeClientRequest{Id: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "anonymized_at"}).AddRow("MOCKID", anonymizedName, time.Now()))
+	expectClientTags(mock)
+	resp, err := service.GetClient(context.Background(), &pb.GetClientRequest{Id: "MOCKID"})
+	require.NoError(t, err)
+	assert.True(t, resp.Client.Anonymized)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeClients(t *testing.T) {
+	service, mock := newTestService(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score, score_baseline FROM clients WHERE id IN (?, ?) AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("SOURCE", "TARGET").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "score_baseline"}).AddRow("SOURCE", 30, 20).AddRow("TARGET", 12, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE client_matches SET client_id = ? WHERE client_id = ?")).
+		WithArgs("TARGET", "SOURCE").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE client_matches SET opponent_id = IF(client_id = ?, NULL, ?) WHERE opponent_id = ?")).
+		WithArgs("TARGET", "TARGET", "SOURCE").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_tags (client_id, tag) SELECT ?, t.tag FROM client_tags t WHERE t.client_id = ? "+
+		"ON DUPLICATE KEY UPDATE tag = VALUES(tag)")).
+		WithArgs("TARGET", "SOURCE").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_tags WHERE client_id = ?")).
+		WithArgs("SOURCE").WillReturnResult(sqlmock.NewResult(0, 2))
+	// the target takes the baseline of the source along with its matches
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET score = ?, score_baseline = ?, version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs(int64(42), int64(22), "TARGET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs("SOURCE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET deleted_at = NOW() WHERE id = ?")).
+		WithArgs("SOURCE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.MergeClients(context.Background(), &pb.MergeClientsRequest{SourceId: "SOURCE", TargetId: "TARGET"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.MovedMatches)
+	assert.Equal(t, int64(42), resp.Score)
+
+	_, err = service.MergeClients(context.Background(), &pb.MergeClientsRequest{SourceId: "SOURCE", TargetId: "SOURCE"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, score, score_baseline FROM clients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "score_baseline"}).AddRow("SOURCE", 30, 0))
+	mock.ExpectRollback()
+	_, err = service.MergeClients(context.Background(), &pb.MergeClientsRequest{SourceId: "SOURCE", TargetId: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, score, score_baseline FROM clients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "score", "score_baseline"}).AddRow("SOURCE", 30, 0).AddRow("TARGET", 12, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE client_matches SET client_id = ? WHERE client_id = ?")).
+		WithArgs("TARGET", "SOURCE").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'TARGET-KEY' for key 'uniq_idempotency_key'"})
+	mock.ExpectRollback()
+	_, err = service.MergeClients(context.Background(), &pb.MergeClientsRequest{SourceId: "SOURCE", TargetId: "TARGET"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteClient(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err := service.DeleteClient(context.Background(), &pb.DeleteClientRequest{Id: "MOCKID"})
+	assert.NoError(t, err)
+
+	countSQL := regexp.QuoteMeta("SELECT COUNT(*) FROM client_matches WHERE client_id = ? FOR UPDATE")
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_matches WHERE client_id = ?")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM clients WHERE id = ?")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.DeleteClient(context.Background(), &pb.DeleteClientRequest{Id: "MOCKID", Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.DeletedMatches)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectRollback()
+	_, err = service.DeleteClient(context.Background(), &pb.DeleteClientRequest{Id: "MOCKID", FailIfMatches: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WithArgs("NOMATCHES").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs("NOMATCHES").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM clients WHERE id = ?")).
+		WithArgs("NOMATCHES").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err = service.DeleteClient(context.Background(), &pb.DeleteClientRequest{Id: "NOMATCHES", Force: true, FailIfMatches: true})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreClient(t *testing.T) {
+	service, mock := newTestService(t)
+	deletedSQL := regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(deletedSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET deleted_at = NULL WHERE id = ?")).
+		WithArgs("MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	_, err := service.RestoreClient(context.Background(), &pb.RestoreClientRequest{Id: "MOCKID"})
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(deletedSQL).WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectRollback()
+	_, err = service.RestoreClient(context.Background(), &pb.RestoreClientRequest{Id: "MOCKID"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(deletedSQL).WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+	mock.ExpectRollback()
+	_, err = service.RestoreClient(context.Background(), &pb.RestoreClientRequest{Id: "MISSING"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteClients(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.DeleteClients(context.Background(), &pb.DeleteClientsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE id IN (?,?,?) AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("A", "B", "C").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A").AddRow("C"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs("A", "C").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET deleted_at = NOW() WHERE id IN (?,?)")).
+		WithArgs("A", "C").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	resp, err := service.DeleteClients(context.Background(), &pb.DeleteClientsRequest{
+		Ids: []string{"A", "B", "C", "A"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Deleted)
+	assert.Equal(t, []string{"B"}, resp.NotFoundIds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeDeletedClients(t *testing.T) {
+	service, mock := newTestService(t)
+	olderThan := time.Unix(0, time.Now().Add(-30*24*time.Hour).UnixNano())
+
+	_, err := service.PurgeDeletedClients(context.Background(), &pb.PurgeDeletedClientsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE deleted_at < ?")).
+		WithArgs(olderThan).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1500))
+	resp, err := service.PurgeDeletedClients(context.Background(), &pb.PurgeDeletedClientsRequest{
+		OlderThan: olderThan.UnixNano(),
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), resp.Purged)
+
+	// a full batch is followed by another one, until a batch comes back short
+	for _, n := range []int{purgeDeletedClientsBatchSize, 2} {
+		rows := sqlmock.NewRows([]string{"id"})
+		for i := 0; i < n; i++ {
+			rows.AddRow(fmt.Sprintf("ID%d", i))
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE deleted_at < ? LIMIT ? FOR UPDATE")).
+			WithArgs(olderThan, purgeDeletedClientsBatchSize).WillReturnRows(rows)
+		mock.ExpectExec("INSERT INTO clients_archive").WillReturnResult(sqlmock.NewResult(0, int64(n)))
+		mock.ExpectExec("DELETE FROM client_matches WHERE client_id IN").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM clients WHERE id IN").WillReturnResult(sqlmock.NewResult(0, int64(n)))
+		mock.ExpectCommit()
+	}
+	resp, err = service.PurgeDeletedClients(context.Background(), &pb.PurgeDeletedClientsRequest{OlderThan: olderThan.UnixNano()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(purgeDeletedClientsBatchSize+2), resp.Purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteClientsByQuery(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.DeleteClientsByQuery(context.Background(), &pb.DeleteClientsByQueryRequest{
+		Filter: &pb.QueryClientsRequest{},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	filter := &pb.QueryClientsRequest{Score: &pb.Int64Comp{Value: 0, Op: "="}}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE score = ? AND deleted_at IS NULL")).
+		WithArgs(int64(0)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	resp, err := service.DeleteClientsByQuery(context.Background(), &pb.DeleteClientsByQueryRequest{
+		Filter: filter,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Deleted)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs(int64(0)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET deleted_at = NOW() WHERE score = ? AND deleted_at IS NULL")).
+		WithArgs(int64(0)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	resp, err = service.DeleteClientsByQuery(context.Background(), &pb.DeleteClientsByQueryRequest{Filter: filter})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Deleted)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).
+		WithArgs(int64(0)).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM clients WHERE score = ?")).
+		WithArgs(int64(0)).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+	resp, err = service.DeleteClientsByQuery(context.Background(), &pb.DeleteClientsByQueryRequest{Filter: filter, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Deleted)
+
+	// nothing is deleted if the archive fails
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).WillReturnError(errors.New("archive failed"))
+	mock.ExpectRollback()
+	_, err = service.DeleteClientsByQuery(context.Background(), &pb.DeleteClientsByQueryRequest{Filter: filter})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAllClients(t *testing.T) {
+	service, mock := newTestService(t)
+	countSQL := regexp.QuoteMeta("SELECT COUNT(*) FROM clients FOR UPDATE")
+	matchesSQL := regexp.QuoteMeta("SELECT COUNT(*) FROM client_matches FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(matchesSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectRollback()
+	resp, err := service.DeleteAllClients(context.Background(), &pb.DeleteAllClientsRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Deleted)
+	assert.Equal(t, int64(5), resp.DeletedMatches)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(matchesSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectRollback()
+	_, err = service.DeleteAllClients(context.Background(), &pb.DeleteAllClientsRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(matchesSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectRollback()
+	_, err = service.DeleteAllClients(context.Background(), &pb.DeleteAllClientsRequest{ConfirmCount: 3, FailIfMatches: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(matchesSQL).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients_archive")).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_matches")).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM clients")).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	resp, err = service.DeleteAllClients(context.Background(), &pb.DeleteAllClientsRequest{ConfirmCount: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewClientIdempotencyKey(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_idempotency_keys WHERE idem_key = ? AND created_at < ?")).
+		WithArgs("KEY", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_idempotency_keys (idem_key, client_id) VALUES (?, ?)")).
+		WithArgs("KEY", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT client_id FROM client_idempotency_keys WHERE idem_key = ? FOR UPDATE")).
+		WithArgs("KEY").WillReturnRows(sqlmock.NewRows([]string{"client_id"}).AddRow("ORIGINAL"))
+	mock.ExpectQuery("SELECT (.+) FROM `clients` WHERE id = ?").WithArgs("ORIGINAL").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "birthday", "score", "created_at"}).
+			AddRow("ORIGINAL", "Test", nil, 0, time.Now()))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.NewClient(context.Background(), &pb.NewClientRequest{
+		Name:           "Test",
+		IdempotencyKey: "KEY",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ORIGINAL", resp.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewClientUniqueNames(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.UniqueNames = true
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE name = ? AND id != ? AND deleted_at IS NULL LIMIT 1 FOR UPDATE")).
+		WithArgs("Test", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	mock.ExpectRollback()
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test"})
+	require.Error(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "EXISTING", st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewClientsUniqueNames(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.UniqueNames = true
+	nameSQL := regexp.QuoteMeta("SELECT id FROM clients WHERE name = ? AND id != ? AND deleted_at IS NULL LIMIT 1 FOR UPDATE")
+
+	// Alice is taken, and the second Bob repeats the first one: only the first Bob is inserted
+	mock.ExpectBegin()
+	mock.ExpectQuery(nameSQL).WithArgs("Alice", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	mock.ExpectQuery(nameSQL).WithArgs("Bob", "").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,birthday,score,score_baseline,email,phone,metadata,notes,external_id,updated_at) "+
+		"VALUES (?,?,?,?,?,?,?,?,?,?,NOW())")).
+		WithArgs(sqlmock.AnyArg(), "Bob", nil, int64(0), int64(0), nil, nil, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	resp, err := service.NewClients(context.Background(), &pb.NewClientsRequest{
+		Clients: []*pb.NewClientRequest{{Name: "Alice"}, {Name: "Bob"}, {Name: "Bob"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 2}, resp.FailedIndexes)
+	assert.Equal(t, "", resp.Ids[0])
+	assert.NotEqual(t, "", resp.Ids[1])
+	assert.Equal(t, "", resp.Ids[2])
+
+	// a request whose names are all taken inserts nothing
+	mock.ExpectBegin()
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(nameSQL).WithArgs("Alice", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	}
+	mock.ExpectRollback()
+	resp, err = service.NewClients(context.Background(), &pb.NewClientsRequest{
+		Clients: []*pb.NewClientRequest{{Name: "Alice"}, {Name: "Alice"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1}, resp.FailedIndexes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertClientUniqueNames(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.UniqueNames = true
+	id := utils.SecureID().String()
+	existsSQL := regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM clients WHERE id = ? FOR UPDATE")
+	nameSQL := regexp.QuoteMeta("SELECT id FROM clients WHERE name = ? AND id != ? AND deleted_at IS NULL LIMIT 1 FOR UPDATE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(existsSQL).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectQuery(nameSQL).WithArgs("Test", id).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	mock.ExpectRollback()
+	_, err := service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Test"})
+	require.Error(t, err)
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "EXISTING", st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+
+	// the upserted client keeping its own name is fine
+	mock.ExpectBegin()
+	mock.ExpectQuery(existsSQL).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectQuery(nameSQL).WithArgs("Test", id).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("INSERT INTO clients").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	_, err = service.UpsertClient(context.Background(), &pb.UpsertClientRequest{Id: id, Name: "Test"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientEmail(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", Email: "not an email"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO clients \\(id,name,score,score_baseline,email,updated_at\\)").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.com' for key 'uniq_email'"})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE email = ?")).WithArgs("a@b.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	mock.ExpectRollback()
+	_, err = service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", Email: "a@b.com"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "EXISTING", st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE email = ? AND deleted_at IS NULL")).
+		WithArgs("a@b.com").WillReturnRows(sqlmock.NewRows(clientColumns))
+	_, err = service.GetClientByEmail(context.Background(), &pb.GetClientByEmailRequest{Email: "a@b.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientExternalID(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,score,score_baseline,external_id,updated_at)")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'CRM-1' for key 'uniq_external_id'"})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE external_id = ?")).WithArgs("CRM-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	mock.ExpectRollback()
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", ExternalId: "CRM-1"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "EXISTING", st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE external_id = ? AND deleted_at IS NULL")).
+		WithArgs("CRM-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "external_id"}).AddRow("EXISTING", "Test", "CRM-1"))
+	expectClientTags(mock)
+	resp, err := service.GetClientByExternalId(context.Background(), &pb.GetClientByExternalIdRequest{ExternalId: "CRM-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "EXISTING", resp.Client.Id)
+	assert.Equal(t, "CRM-1", resp.Client.ExternalId)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND external_id = ?")).
+		WithArgs("CRM-1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("EXISTING"))
+	qresp, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{
+		ExternalId: &pb.OptString{Value: "CRM-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"EXISTING"}, qresp.Ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientPhone(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", Phone: "12"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "phone")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND phone = ?")).
+		WithArgs("+5511912345678").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{
+		Phone: &pb.OptString{Value: "5511 91234-5678"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientMetadata(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.MetadataMaxKeys = 2
+
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{
+		Name:     "Test",
+		Metadata: map[string]string{"a": "1", "b": "2", "c": "3"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	metadata := map[string]string{"origem": "São Paulo", "キャンペーン": "夏"}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,score,score_baseline,metadata,updated_at) VALUES (?,?,?,?,?,NOW())")).
+		WithArgs(sqlmock.AnyArg(), "Test", int64(0), int64(0), `{"origem":"São Paulo","キャンペーン":"夏"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "metadata"}).
+			AddRow("MOCKID", "Test", `{"origem":"São Paulo","キャンペーン":"夏"}`))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", Metadata: metadata})
+	require.NoError(t, err)
+	assert.Equal(t, metadata, resp.Client.Metadata)
+
+	// an empty map is stored as NULL, not as {}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET metadata = ?, version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs(nil, "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "metadata"}).AddRow("MOCKID", "Test", nil))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp2, err := service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:       "MOCKID",
+		Metadata: &pb.OptStringMap{},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp2.Client.Metadata)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientNotes(t *testing.T) {
+	service, mock := newTestService(t)
+	service.config.NotesMaxLength = 16
+
+	_, err := service.NewClient(context.Background(), &pb.NewClientRequest{
+		Name:  "Test",
+		Notes: strings.Repeat("x", 17),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "16 bytes")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO clients (id,name,score,score_baseline,notes,updated_at) VALUES (?,?,?,?,?,NOW())")).
+		WithArgs(sqlmock.AnyArg(), "Test", int64(0), int64(0), "called twice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "notes"}).AddRow("MOCKID", "Test", "called twice"))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp, err := service.NewClient(context.Background(), &pb.NewClientRequest{Name: "Test", Notes: "called twice"})
+	require.NoError(t, err)
+	assert.Equal(t, "called twice", resp.Client.Notes)
+
+	_, err = service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:    "MOCKID",
+		Notes: &pb.OptString{Value: strings.Repeat("x", 17)},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET notes = ?, version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs(nil, "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "notes"}).AddRow("MOCKID", "Test", nil))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	resp2, err := service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:    "MOCKID",
+		Notes: &pb.OptString{},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp2.Client.Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateClientFieldMask(t *testing.T) {
+	service, mock := newTestService(t)
+
+	// birthday is masked but empty: it is cleared, and the unmasked name is kept
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET birthday = ?, score = ?, score_baseline = ? - (SELECT COALESCE(SUM(score), 0) FROM client_matches WHERE client_id = ?), "+
+		"version = version + 1, updated_at = NOW() WHERE id = ?")).
+		WithArgs(nil, int64(0), int64(0), "MOCKID", "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("MOCKID", "Test"))
+	expectClientTags(mock)
+	mock.ExpectCommit()
+	_, err := service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:         "MOCKID",
+		Client:     &pb.Client{Name: "Ignored"},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"birthday", "score"}},
+	})
+	require.NoError(t, err)
+
+	_, err = service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:         "MOCKID",
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "created_at", "bogus"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "created_at, bogus")
+
+	_, err = service.UpdateClient(context.Background(), &pb.UpdateClientRequest{
+		Id:         "MOCKID",
+		Name:       &pb.OptString{Value: "Bob"},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewMatchIdempotencyKey(t *testing.T) {
+	db := newMatchesDB()
+	db.clients["MOCKID"] = &fakeClient{status: "ACTIVE", score: 10}
+	service := db.service()
+
+	// the same request fired three times at once: they queue on the client lock, the first one
+	// records the match and the others find it by its key
+	const n = 3
+	resps := make([]*pb.NewMatchResponse, n)
+	errs := make([]error, n)
+	concurrently(n, func(i int) {
+		resps[i], errs[i] = service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 5, IdempotencyKey: "KEY"})
+	})
+	require.Len(t, db.matches, 1)
+	for id, m := range db.matches {
+		for i := 0; i < n; i++ {
+			require.NoError(t, errs[i])
+			assert.Equal(t, &pb.NewMatchResponse{Id: id, CreatedAt: m.createdAt.UnixNano(), Score: 5}, resps[i])
+		}
+	}
+	assert.Equal(t, int64(15), db.clients["MOCKID"].score)
+
+	_, err := service.NewMatch(context.Background(), &pb.NewMatchRequest{ClientId: "MOCKID", Score: 5, IdempotencyKey: strings.Repeat("k", 65)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.NewMatches(context.Background(), &pb.NewMatchesRequest{Matches: []*pb.NewMatchRequest{{ClientId: "MOCKID", IdempotencyKey: "KEY"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,52 @@
+//go:build go1.18
+// +build go1.18
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+)
+
+// FuzzSort takes the items as a newline separated list
+func FuzzSort(f *testing.F) {
+	for _, seed := range []string{"", "a", "b\na\nb", "\n\n", "c\nb\na\na"} {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+	service := &Service{config: Config{}.withDefaults()}
+	f.Fuzz(func(t *testing.T, list string, removeDuplicates bool) {
+		items := strings.Split(list, "\n")
+		resp, err := service.Sort(context.Background(), &pb.SortRequest{Items: items, RemoveDuplicates: removeDuplicates})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sort.StringsAreSorted(resp.Items) {
+			t.Fatalf("not sorted: %q", resp.Items)
+		}
+		left := make(map[string]int, len(items))
+		for _, item := range items {
+			left[item]++
+		}
+		for i, item := range resp.Items {
+			if left[item] == 0 {
+				t.Fatalf("%q is not in the input (or is there fewer times)", item)
+			}
+			left[item]--
+			if removeDuplicates && i > 0 && resp.Items[i-1] == item {
+				t.Fatalf("%q is repeated", item)
+			}
+		}
+		if removeDuplicates {
+			if len(resp.Items) != len(left) {
+				t.Fatalf("%d distinct items sorted into %d", len(left), len(resp.Items))
+			}
+		} else if len(resp.Items) != len(items) {
+			t.Fatalf("%d items sorted into %d", len(items), len(resp.Items))
+		}
+	})
+}
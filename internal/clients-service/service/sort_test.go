@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSort(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name             string
+		items            []string
+		removeDuplicates bool
+		mode             pb.SortMode
+		want             []string
+	}{
+		{"empty", nil, false, pb.SortMode_STRING, []string{}},
+		{"empty without duplicates", nil, true, pb.SortMode_STRING, []string{}},
+		{"single", []string{"a"}, true, pb.SortMode_STRING, []string{"a"}},
+		{"sorts", []string{"c", "a", "b"}, false, pb.SortMode_STRING, []string{"a", "b", "c"}},
+		{"keeps duplicates", []string{"b", "a", "b", "a"}, false, pb.SortMode_STRING, []string{"a", "a", "b", "b"}},
+		{"removes duplicates", []string{"b", "a", "b", "a", "c"}, true, pb.SortMode_STRING, []string{"a", "b", "c"}},
+		{"all the same", []string{"x", "x", "x"}, true, pb.SortMode_STRING, []string{"x"}},
+		{"empty strings", []string{"", "b", ""}, true, pb.SortMode_STRING, []string{"", "b"}},
+		{"bytewise", []string{"b", "B", "á", "a"}, false, pb.SortMode_STRING, []string{"B", "a", "b", "á"}},
+		{"numbers as strings", []string{"2", "10", "1"}, false, pb.SortMode_STRING, []string{"1", "10", "2"}},
+		{"numeric", []string{"2", "10", "1", "-3"}, false, pb.SortMode_NUMERIC, []string{"-3", "1", "2", "10"}},
+		{"numeric ties bytewise", []string{"1", "+1", "01", "0"}, false, pb.SortMode_NUMERIC, []string{"0", "+1", "01", "1"}},
+		{"numeric duplicates by value", []string{"1", "01", "10", "1"}, true, pb.SortMode_NUMERIC, []string{"01", "10"}},
+		{"numeric empty", nil, true, pb.SortMode_NUMERIC, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := append([]string(nil), tt.items...)
+			resp, err := service.Sort(context.Background(), &pb.SortRequest{
+				Items:            tt.items,
+				RemoveDuplicates: tt.removeDuplicates,
+				Mode:             tt.mode,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+			assert.Equal(t, items, tt.items, "the request items are not changed")
+		})
+	}
+}
+
+func TestSortDescending(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name             string
+		items            []string
+		removeDuplicates bool
+		mode             pb.SortMode
+		want             []string
+	}{
+		{"empty", nil, false, pb.SortMode_STRING, []string{}},
+		{"empty without duplicates", nil, true, pb.SortMode_STRING, []string{}},
+		{"numeric empty without duplicates", nil, true, pb.SortMode_NUMERIC, []string{}},
+		{"single", []string{"a"}, true, pb.SortMode_STRING, []string{"a"}},
+		{"sorts", []string{"b", "c", "a"}, false, pb.SortMode_STRING, []string{"c", "b", "a"}},
+		{"keeps duplicates", []string{"b", "a", "b", "a"}, false, pb.SortMode_STRING, []string{"b", "b", "a", "a"}},
+		{"removes duplicates", []string{"b", "a", "b", "a", "c"}, true, pb.SortMode_STRING, []string{"c", "b", "a"}},
+		{"numeric", []string{"2", "10", "1", "-3"}, false, pb.SortMode_NUMERIC, []string{"10", "2", "1", "-3"}},
+		{"numeric ties", []string{"1", "+1", "01", "0"}, false, pb.SortMode_NUMERIC, []string{"1", "01", "+1", "0"}},
+		// the duplicates are removed in ascending order, so "01" is kept as when ascending
+		{"numeric removes duplicates", []string{"1", "01", "10", "1"}, true, pb.SortMode_NUMERIC, []string{"10", "01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.Sort(context.Background(), &pb.SortRequest{
+				Items:            tt.items,
+				RemoveDuplicates: tt.removeDuplicates,
+				Mode:             tt.mode,
+				Descending:       true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+		})
+	}
+}
+
+func TestSortLocale(t *testing.T) {
+	service, _ := newTestService(t)
+	names := []string{"Zelda", "Álvaro", "alvaro", "Alvaro", "Érica", "bruno"}
+	tests := []struct {
+		name string
+		req  *pb.SortRequest
+		want []string
+	}{
+		{"bytewise", &pb.SortRequest{}, []string{"Alvaro", "Zelda", "alvaro", "bruno", "Álvaro", "Érica"}},
+		{"pt-BR", &pb.SortRequest{Locale: "pt-BR"}, []string{"alvaro", "Alvaro", "Álvaro", "bruno", "Érica", "Zelda"}},
+		{"pt-BR without duplicates", &pb.SortRequest{Locale: "pt-BR", RemoveDuplicates: true}, []string{"alvaro", "Alvaro", "Álvaro", "bruno", "Érica", "Zelda"}},
+		{"ignore case", &pb.SortRequest{Locale: "pt-BR", RemoveDuplicates: true, IgnoreCase: true}, []string{"Alvaro", "Álvaro", "bruno", "Érica", "Zelda"}},
+		{"ignore case and diacritics", &pb.SortRequest{Locale: "pt-BR", RemoveDuplicates: true, IgnoreCase: true, IgnoreDiacritics: true}, []string{"Alvaro", "bruno", "Érica", "Zelda"}},
+		{"ignore case keeping duplicates", &pb.SortRequest{Locale: "pt-BR", IgnoreCase: true}, []string{"Alvaro", "alvaro", "Álvaro", "bruno", "Érica", "Zelda"}},
+		{"descending", &pb.SortRequest{Locale: "pt", RemoveDuplicates: true, IgnoreCase: true, IgnoreDiacritics: true, Descending: true}, []string{"Zelda", "Érica", "bruno", "Alvaro"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Items = names
+			resp, err := service.Sort(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+		})
+	}
+}
+
+func TestSortIgnoreCase(t *testing.T) {
+	service, _ := newTestService(t)
+	names := []string{"bob", "alice", "Bob", "ALICE", "Alice", "Straße", "STRASSE", "carol"}
+	tests := []struct {
+		name string
+		req  *pb.SortRequest
+		want []string
+	}{
+		{"case sensitive", &pb.SortRequest{}, []string{"ALICE", "Alice", "Bob", "STRASSE", "Straße", "alice", "bob", "carol"}},
+		{"case insensitive", &pb.SortRequest{IgnoreCase: true}, []string{"ALICE", "Alice", "alice", "Bob", "bob", "carol", "STRASSE", "Straße"}},
+		{"without duplicates", &pb.SortRequest{IgnoreCase: true, RemoveDuplicates: true}, []string{"ALICE", "Bob", "carol", "STRASSE"}},
+		{"descending", &pb.SortRequest{IgnoreCase: true, RemoveDuplicates: true, Descending: true}, []string{"STRASSE", "carol", "Bob", "ALICE"}},
+		{"empty", &pb.SortRequest{IgnoreCase: true, RemoveDuplicates: true, Items: []string{}}, []string{}},
+		{"locale", &pb.SortRequest{IgnoreCase: true, RemoveDuplicates: true, Locale: "en"}, []string{"ALICE", "Bob", "carol", "STRASSE", "Straße"}},
+		{"deprecated alias", &pb.SortRequest{CaseInsensitive: true, RemoveDuplicates: true}, []string{"ALICE", "Bob", "carol", "STRASSE"}},
+		{"deprecated alias with locale", &pb.SortRequest{CaseInsensitive: true, RemoveDuplicates: true, Locale: "en"}, []string{"ALICE", "Bob", "carol", "STRASSE", "Straße"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.req.Items == nil {
+				tt.req.Items = append([]string(nil), names...)
+			}
+			resp, err := service.Sort(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+		})
+	}
+}
+
+func TestSortNatural(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name  string
+		items []string
+		want  []string
+	}{
+		{"numbers", []string{"file10", "file2", "file1"}, []string{"file1", "file2", "file10"}},
+		{"versions", []string{"v1.10.2", "v1.9.0", "v1.9.10", "v1.9.2", "v10.0.0"}, []string{"v1.9.0", "v1.9.2", "v1.9.10", "v1.10.2", "v10.0.0"}},
+		{"leading zeros", []string{"file1", "file01", "file001", "file002"}, []string{"file001", "file01", "file1", "file002"}},
+		{"zero", []string{"1", "00", "0", "000"}, []string{"0", "00", "000", "1"}},
+		{"mixed widths", []string{"a100", "a99", "a0100", "a9"}, []string{"a9", "a99", "a0100", "a100"}},
+		{"beyond int64", []string{"x123456789012345678901234567890", "x99", "x123456789012345678901234567889"}, []string{"x99", "x123456789012345678901234567889", "x123456789012345678901234567890"}},
+		{"text and numbers", []string{"b1", "a", "a1", "1a", "10", "2", ""}, []string{"", "1a", "2", "10", "a", "a1", "b1"}},
+		{"prefix", []string{"file1a", "file1", "file"}, []string{"file", "file1", "file1a"}},
+		// only the ASCII digits are numbers: the arabic-indic ٢ sorts as text, after them
+		{"unicode digits", []string{"file٢", "file10", "file9"}, []string{"file9", "file10", "file٢"}},
+		{"empty", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.Sort(context.Background(), &pb.SortRequest{Items: tt.items, Mode: pb.SortMode_NATURAL})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+		})
+	}
+
+	items := []string{"file10", "file01", "file2", "file1", "file002"}
+	resp, err := service.Sort(context.Background(), &pb.SortRequest{Items: items, Mode: pb.SortMode_NATURAL, RemoveDuplicates: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file01", "file002", "file10"}, resp.Items)
+	resp, err = service.Sort(context.Background(), &pb.SortRequest{Items: items, Mode: pb.SortMode_NATURAL, RemoveDuplicates: true, Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file10", "file002", "file01"}, resp.Items)
+	resp, err = service.Sort(context.Background(), &pb.SortRequest{Items: items, Mode: pb.SortMode_NATURAL, Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file10", "file2", "file002", "file1", "file01"}, resp.Items)
+}
+
+func TestSortCountsAndPreserveOrder(t *testing.T) {
+	service, _ := newTestService(t)
+	items := []string{"b", "a", "c", "a", "b", "a"}
+	tests := []struct {
+		name       string
+		req        *pb.SortRequest
+		want       []string
+		wantCounts []int64
+	}{
+		{"counts", &pb.SortRequest{Items: items, RemoveDuplicates: true, ReturnCounts: true}, []string{"a", "b", "c"}, []int64{3, 2, 1}},
+		{"counts descending", &pb.SortRequest{Items: items, RemoveDuplicates: true, ReturnCounts: true, Descending: true}, []string{"c", "b", "a"}, []int64{1, 2, 3}},
+		{"counts with duplicates", &pb.SortRequest{Items: []string{"b", "a", "b"}, ReturnCounts: true}, []string{"a", "b", "b"}, []int64{1, 1, 1}},
+		{"counts by mode", &pb.SortRequest{Items: []string{"1", "01", "2", "+1"}, Mode: pb.SortMode_NUMERIC, RemoveDuplicates: true, ReturnCounts: true}, []string{"+1", "2"}, []int64{3, 1}},
+		{"no counts", &pb.SortRequest{Items: items, RemoveDuplicates: true}, []string{"a", "b", "c"}, nil},
+		{"empty counts", &pb.SortRequest{RemoveDuplicates: true, ReturnCounts: true}, []string{}, []int64{}},
+		{"preserve order", &pb.SortRequest{Items: items, RemoveDuplicates: true, PreserveOrder: true}, []string{"b", "a", "c"}, nil},
+		{"preserve order counts", &pb.SortRequest{Items: items, RemoveDuplicates: true, PreserveOrder: true, ReturnCounts: true}, []string{"b", "a", "c"}, []int64{2, 3, 1}},
+		{"preserve order with duplicates", &pb.SortRequest{Items: items, PreserveOrder: true}, items, nil},
+		{"preserve order by mode", &pb.SortRequest{Items: []string{"Bob", "alice", "bob", "ALICE"}, IgnoreCase: true, RemoveDuplicates: true, PreserveOrder: true, ReturnCounts: true}, []string{"Bob", "alice"}, []int64{2, 2}},
+		{"preserve order natural", &pb.SortRequest{Items: []string{"f10", "f02", "f2", "f1"}, Mode: pb.SortMode_NATURAL, RemoveDuplicates: true, PreserveOrder: true}, []string{"f10", "f02", "f1"}, nil},
+		{"preserve order empty", &pb.SortRequest{RemoveDuplicates: true, PreserveOrder: true, ReturnCounts: true}, []string{}, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.Sort(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Items)
+			assert.Equal(t, tt.wantCounts, resp.Counts)
+		})
+	}
+	assert.Equal(t, []string{"b", "a", "c", "a", "b", "a"}, items, "the request items are not changed")
+}
+
+func TestSortErrors(t *testing.T) {
+	service, _ := newTestService(t)
+	_, err := service.Sort(context.Background(), &pb.SortRequest{Items: []string{"1", "x2", "3"}, Mode: pb.SortMode_NUMERIC})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, `items[1]: "x2" is not an integer`, status.Convert(err).Message())
+	_, err = service.Sort(context.Background(), &pb.SortRequest{Items: []string{"99999999999999999999"}, Mode: pb.SortMode_NUMERIC})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.Sort(context.Background(), &pb.SortRequest{Mode: pb.SortMode(5)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	for _, req := range []*pb.SortRequest{
+		{Locale: "not a locale!"},
+		{Locale: "xx"},
+		{Locale: "zz-ZZ"},
+		{Locale: "pt-BR", Mode: pb.SortMode_NUMERIC},
+		{IgnoreDiacritics: true},
+		{IgnoreCase: true, Mode: pb.SortMode_NUMERIC},
+		{IgnoreCase: true, Mode: pb.SortMode_NATURAL},
+		{CaseInsensitive: true, Mode: pb.SortMode_NUMERIC},
+		{Locale: "pt-BR", IgnoreDiacritics: true, Mode: pb.SortMode_NUMERIC},
+		{Locale: "en", Mode: pb.SortMode_NATURAL},
+		{PreserveOrder: true, Descending: true},
+	} {
+		_, err = service.Sort(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", req)
+	}
+}
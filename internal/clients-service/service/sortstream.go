@@ -0,0 +1,75 @@
+package service
+
+import (
+	"io"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sortStreamChunkBytes is about the max size of the items of each SortStream response, well under the 4MB
+// gRPC message cap
+const sortStreamChunkBytes = 1 << 20
+
+// sortItemSize approximates what an item takes, in memory and in a message, counting some overhead so that
+// many tiny items are bounded too
+func sortItemSize(item string) int {
+	return len(item) + 16
+}
+
+// SortStream sorts the items of all the requests of the stream as Sort does with the options of the first
+// one. The items are kept in memory, failing with ResourceExhausted past Config.SortStreamMaxBytes, and once
+// the client closes its side they are sent back in chunks of about sortStreamChunkBytes.
+func (s *Service) SortStream(stream pb.ClientsService_SortStreamServer) error {
+	ctx := stream.Context()
+	var req *pb.SortRequest
+	var items []string
+	size := 0
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req == nil {
+			req = chunk
+		}
+		for _, item := range chunk.Items {
+			size += sortItemSize(item)
+		}
+		if size > s.config.SortStreamMaxBytes {
+			return status.Errorf(codes.ResourceExhausted, "the items take more than %d bytes", s.config.SortStreamMaxBytes)
+		}
+		items = append(items, chunk.Items...)
+	}
+	if req == nil {
+		return nil
+	}
+
+	items, counts, err := sortedItems(items, req)
+	if err != nil {
+		return err
+	}
+	for len(items) > 0 {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		n, size := 1, sortItemSize(items[0])
+		for n < len(items) && size+sortItemSize(items[n]) <= sortStreamChunkBytes {
+			size += sortItemSize(items[n])
+			n++
+		}
+		resp := &pb.SortResponse{Items: items[:n]}
+		if req.ReturnCounts {
+			resp.Counts = counts[:n]
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		items, counts = items[n:], counts[n:]
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// sortStream receives the requests given to it and collects the responses and header sent by SortStream,
+// calling onSend after each response
+type sortStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*pb.SortRequest
+	recvErr   error
+	responses []*pb.SortResponse
+	onSend    func()
+	header    metadata.MD
+}
+
+func (s *sortStream) Context() context.Context { return s.ctx }
+
+func (s *sortStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *sortStream) Recv() (*pb.SortRequest, error) {
+	if len(s.requests) == 0 {
+		if s.recvErr != nil {
+			return nil, s.recvErr
+		}
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *sortStream) Send(resp *pb.SortResponse) error {
+	s.responses = append(s.responses, resp)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return nil
+}
+
+// items are all the items sent back, along with how many responses there were
+func (s *sortStream) items() ([]string, int) {
+	items := []string{}
+	for _, resp := range s.responses {
+		items = append(items, resp.Items...)
+	}
+	return items, len(s.responses)
+}
+
+func TestSortStream(t *testing.T) {
+	service, _ := newTestService(t)
+	stream := &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{
+		{Items: []string{"c", "a"}, RemoveDuplicates: true, Descending: true},
+		// the options of the first request hold
+		{Items: []string{"b", "a"}, RemoveDuplicates: false},
+		{},
+		{Items: []string{"c", "d"}},
+	}}
+	require.NoError(t, service.SortStream(stream))
+	items, responses := stream.items()
+	assert.Equal(t, []string{"d", "c", "b", "a"}, items)
+	assert.Equal(t, 1, responses)
+
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{
+		{Items: []string{"10", "9"}, Mode: pb.SortMode_NUMERIC, RemoveDuplicates: true},
+		{Items: []string{"09", "1"}},
+	}}
+	require.NoError(t, service.SortStream(stream))
+	items, _ = stream.items()
+	assert.Equal(t, []string{"1", "09", "10"}, items)
+
+	// the duplicates are counted across requests
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{
+		{Items: []string{"b", "a"}, RemoveDuplicates: true, ReturnCounts: true, PreserveOrder: true},
+		{Items: []string{"a", "c", "b", "a"}},
+	}}
+	require.NoError(t, service.SortStream(stream))
+	require.Len(t, stream.responses, 1)
+	assert.Equal(t, []string{"b", "a", "c"}, stream.responses[0].Items)
+	assert.Equal(t, []int64{2, 3, 1}, stream.responses[0].Counts)
+
+	// no requests and no items send nothing back
+	stream = &sortStream{ctx: context.Background()}
+	require.NoError(t, service.SortStream(stream))
+	assert.Empty(t, stream.responses)
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{{RemoveDuplicates: true}}}
+	require.NoError(t, service.SortStream(stream))
+	assert.Empty(t, stream.responses)
+}
+
+func TestSortStreamChunks(t *testing.T) {
+	service, _ := newTestService(t)
+	// three items fit a response
+	item := strings.Repeat("x", sortStreamChunkBytes/3-16)
+	var want []string
+	for _, c := range "gfedcba" {
+		want = append([]string{string(c) + item[1:]}, want...)
+	}
+	stream := &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{
+		{Items: want[4:]},
+		{Items: want[:4]},
+	}}
+	require.NoError(t, service.SortStream(stream))
+	items, responses := stream.items()
+	assert.Equal(t, want, items)
+	assert.Equal(t, 3, responses)
+	for _, resp := range stream.responses {
+		assert.LessOrEqual(t, len(resp.Items), 3)
+	}
+
+	// an item larger than a chunk still goes alone
+	huge := strings.Repeat("y", sortStreamChunkBytes+1)
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{{Items: []string{huge, "a"}}}}
+	require.NoError(t, service.SortStream(stream))
+	assert.Len(t, stream.responses, 2)
+}
+
+func TestSortStreamErrors(t *testing.T) {
+	service, _ := newTestService(t)
+	service.config.SortStreamMaxBytes = 100
+	stream := &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{
+		{Items: []string{strings.Repeat("a", 40)}},
+		{Items: []string{strings.Repeat("b", 40)}},
+	}}
+	err := service.SortStream(stream)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Empty(t, stream.responses)
+
+	// so many empty items count as well
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{{Items: make([]string, 10)}}}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(service.SortStream(stream)))
+
+	service.config.SortStreamMaxBytes = 1 << 20
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{{Items: []string{"x"}, Mode: pb.SortMode_NUMERIC}}}
+	assert.Equal(t, codes.InvalidArgument, status.Code(service.SortStream(stream)))
+
+	recvErr := errors.New("connection reset")
+	stream = &sortStream{ctx: context.Background(), requests: []*pb.SortRequest{{Items: []string{"a"}}}, recvErr: recvErr}
+	assert.Equal(t, recvErr, service.SortStream(stream))
+	assert.Empty(t, stream.responses)
+}
+
+func TestSortStreamCanceled(t *testing.T) {
+	service, _ := newTestService(t)
+	// the client hangs up after the first response: no more are sent
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	item := strings.Repeat("x", sortStreamChunkBytes/2)
+	stream := &sortStream{
+		ctx:      ctx,
+		requests: []*pb.SortRequest{{Items: []string{item, item, item, item}}},
+		onSend:   cancel,
+	}
+	err := service.SortStream(stream)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Len(t, stream.responses, 1)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusTransitions lists the statuses a client can be moved to from each status.
+// A ban can only be lifted back to ACTIVE.
+var statusTransitions = map[pb.ClientStatus][]pb.ClientStatus{
+	pb.ClientStatus_ACTIVE:    {pb.ClientStatus_SUSPENDED, pb.ClientStatus_BANNED},
+	pb.ClientStatus_SUSPENDED: {pb.ClientStatus_ACTIVE, pb.ClientStatus_BANNED},
+	pb.ClientStatus_BANNED:    {pb.ClientStatus_ACTIVE},
+}
+
+func canTransition(from, to pb.ClientStatus) bool {
+	for _, st := range statusTransitions[from] {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+// SetClientStatus moves a client to req.Status, refusing deleted clients and invalid transitions.
+// Setting the status the client already has is a no-op.
+func (s *Service) SetClientStatus(ctx context.Context, req *pb.SetClientStatusRequest) (*pb.SetClientStatusResponse, error) {
+	if _, ok := pb.ClientStatus_name[int32(req.Status)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid status %d", req.Status)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	row := struct {
+		Status    string       `db:"status"`
+		DeletedAt sql.NullTime `db:"deleted_at"`
+	}{}
+	if err := tx.GetContext(ctx, &row, "SELECT status, deleted_at FROM clients WHERE id = ? FOR UPDATE", req.Id); err != nil {
+		return nil, notFoundOr(err, "client "+req.Id+" not found")
+	}
+	if row.DeletedAt.Valid {
+		return nil, status.Errorf(codes.FailedPrecondition, "client %s is deleted", req.Id)
+	}
+	current := pb.ClientStatus(pb.ClientStatus_value[row.Status])
+	if current != req.Status {
+		if !canTransition(current, req.Status) {
+			return nil, status.Errorf(codes.FailedPrecondition, "client %s can't go from %s to %s", req.Id, current, req.Status)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE clients SET status = ?, version = version + 1, updated_at = NOW() WHERE id = ?",
+			req.Status.String(), req.Id); err != nil {
+			return nil, err
+		}
+	}
+	client, err := getClient(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.SetClientStatusResponse{Client: client}, nil
+}
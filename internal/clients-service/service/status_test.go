@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetClientStatus(t *testing.T) {
+	active, suspended, banned := pb.ClientStatus_ACTIVE, pb.ClientStatus_SUSPENDED, pb.ClientStatus_BANNED
+	tests := []struct {
+		from, to pb.ClientStatus
+		code     codes.Code
+	}{
+		{active, suspended, codes.OK},
+		{active, banned, codes.OK},
+		{suspended, active, codes.OK},
+		{suspended, banned, codes.OK},
+		{banned, active, codes.OK},
+		{banned, suspended, codes.FailedPrecondition},
+		{active, active, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			service, mock := newTestService(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT status, deleted_at FROM clients WHERE id = ? FOR UPDATE")).
+				WithArgs("MOCKID").
+				WillReturnRows(sqlmock.NewRows([]string{"status", "deleted_at"}).AddRow(tt.from.String(), nil))
+			if tt.code == codes.OK {
+				if tt.from != tt.to {
+					mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET status = ?, version = version + 1, updated_at = NOW() WHERE id = ?")).
+						WithArgs(tt.to.String(), "MOCKID").WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL + " WHERE id = ?")).
+					WithArgs("MOCKID").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).AddRow("MOCKID", "Alice", tt.to.String()))
+				expectClientTags(mock)
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			resp, err := service.SetClientStatus(context.Background(), &pb.SetClientStatusRequest{Id: "MOCKID", Status: tt.to})
+			assert.Equal(t, tt.code, status.Code(err))
+			if err == nil {
+				assert.Equal(t, tt.to, resp.Client.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSetClientStatusErrors(t *testing.T) {
+	service, mock := newTestService(t)
+
+	_, err := service.SetClientStatus(context.Background(), &pb.SetClientStatusRequest{Id: "MOCKID", Status: 42})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, deleted_at FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "deleted_at"}).AddRow("ACTIVE", time.Now()))
+	mock.ExpectRollback()
+	_, err = service.SetClientStatus(context.Background(), &pb.SetClientStatusRequest{Id: "MOCKID", Status: pb.ClientStatus_BANNED})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, deleted_at FROM clients").WithArgs("MISSING").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "deleted_at"}))
+	mock.ExpectRollback()
+	_, err = service.SetClientStatus(context.Background(), &pb.SetClientStatusRequest{Id: "MISSING", Status: pb.ClientStatus_BANNED})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/pedidopago/trainingsvc-clients/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSuggestClientsLimit is the number of suggestions returned when no limit is given
+	defaultSuggestClientsLimit = 10
+	// maxSuggestClientsLimit is the largest number of suggestions returned
+	maxSuggestClientsLimit = 20
+)
+
+// SuggestClients returns the id and name of the clients whose name starts with req.Prefix, for typeaheads.
+// The prefix is matched literally, with a LIKE the name index can serve.
+func (s *Service) SuggestClients(ctx context.Context, req *pb.SuggestClientsRequest) (*pb.SuggestClientsResponse, error) {
+	if req.Prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "prefix is required")
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit can't be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSuggestClientsLimit
+	}
+	if limit > maxSuggestClientsLimit {
+		limit = maxSuggestClientsLimit
+	}
+	var orderBy string
+	switch req.OrderBy {
+	case pb.ClientOrderBy_SCORE:
+		orderBy = "score DESC"
+	case pb.ClientOrderBy_NAME:
+		orderBy = "name ASC"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "suggestions can only be ordered by %s or %s",
+			pb.ClientOrderBy_SCORE, pb.ClientOrderBy_NAME)
+	}
+
+	q, args, err := sq.Select("id", "name").From("clients").
+		Where("deleted_at IS NULL").Where("name LIKE ?", utils.EscapeLike(req.Prefix)+"%").
+		OrderBy(orderBy, "id ASC").Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows := []struct {
+		ID   string `db:"id"`
+		Name string `db:"name"`
+	}{}
+	if err := s.db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	resp := &pb.SuggestClientsResponse{Suggestions: make([]*pb.ClientSuggestion, 0, len(rows))}
+	for _, row := range rows {
+		resp.Suggestions = append(resp.Suggestions, &pb.ClientSuggestion{Id: row.ID, Name: row.Name})
+	}
+	return resp, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSuggestClients(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM clients WHERE deleted_at IS NULL AND name LIKE ? " +
+		"ORDER BY score DESC, id ASC LIMIT 10")).
+		WithArgs("Ali%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("ALICE", "Alice").AddRow("ALINE", "Aline"))
+	resp, err := service.SuggestClients(context.Background(), &pb.SuggestClientsRequest{Prefix: "Ali"})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.ClientSuggestion{{Id: "ALICE", Name: "Alice"}, {Id: "ALINE", Name: "Aline"}}, resp.Suggestions)
+
+	// the wildcards of the prefix are literal
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY name ASC, id ASC LIMIT 20")).WithArgs(`50\%\_%`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	resp, err = service.SuggestClients(context.Background(), &pb.SuggestClientsRequest{
+		Prefix:  "50%_",
+		Limit:   100,
+		OrderBy: pb.ClientOrderBy_NAME,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Suggestions)
+
+	for _, req := range []*pb.SuggestClientsRequest{
+		{},
+		{Prefix: "a", Limit: -1},
+		{Prefix: "a", OrderBy: pb.ClientOrderBy_BIRTHDAY},
+	} {
+		_, err = service.SuggestClients(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
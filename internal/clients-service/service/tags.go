@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTagLength is the size of the client_tags.tag column
+const maxTagLength = 64
+
+// normalizeTags trims the tags and removes duplicates, refusing empty or too long tags
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tags is empty")
+	}
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > maxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "tags must have between 1 and %d characters", maxTagLength)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			out = append(out, tag)
+		}
+	}
+	return out, nil
+}
+
+// loadClientTags fills the Tags of clients with a single query
+func loadClientTags(ctx context.Context, db sqlx.QueryerContext, clients ...*pb.Client) error {
+	if len(clients) == 0 {
+		return nil
+	}
+	byID := make(map[string]*pb.Client, len(clients))
+	ids := make([]string, 0, len(clients))
+	for _, c := range clients {
+		byID[c.Id] = c
+		ids = append(ids, c.Id)
+	}
+	q, args, err := sq.Select("client_id", "tag").From("client_tags").
+		Where(sq.Eq{"client_id": ids}).OrderBy("tag").ToSql()
+	if err != nil {
+		return err
+	}
+	rows := []struct {
+		ClientID string `db:"client_id"`
+		Tag      string `db:"tag"`
+	}{}
+	if err := sqlx.SelectContext(ctx, db, &rows, q, args...); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if c := byID[row.ClientID]; c != nil {
+			c.Tags = append(c.Tags, row.Tag)
+		}
+	}
+	return nil
+}
+
+// tagsFilter returns the predicate matching clients with any (or, if matchAll is set, all) of the tags
+func tagsFilter(tags []string, matchAll bool) (sq.Sqlizer, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	sub := sq.Select("client_id").From("client_tags").Where(sq.Eq{"tag": tags})
+	if matchAll {
+		sub = sub.GroupBy("client_id").Having("COUNT(DISTINCT tag) = ?", len(tags))
+	}
+	q, args, err := sub.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	return sq.Expr("id IN ("+q+")", args...), nil
+}
+
+// AddClientTags adds tags to a client, ignoring the ones it already has
+func (s *Service) AddClientTags(ctx context.Context, req *pb.AddClientTagsRequest) (*pb.AddClientTagsResponse, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var clientID string
+	if err := tx.GetContext(ctx, &clientID, "SELECT id FROM clients WHERE id = ? AND deleted_at IS NULL FOR UPDATE", req.ClientId); err != nil {
+		return nil, notFoundOr(err, "client "+req.ClientId+" not found")
+	}
+	iq := sq.Insert("client_tags").Columns("client_id", "tag").Suffix("ON DUPLICATE KEY UPDATE tag = tag")
+	for _, tag := range tags {
+		iq = iq.Values(req.ClientId, tag)
+	}
+	q, args, err := iq.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	client := &pb.Client{Id: req.ClientId}
+	if err := loadClientTags(ctx, tx, client); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.AddClientTagsResponse{Tags: client.Tags}, nil
+}
+
+// RemoveClientTags removes tags from a client, ignoring the ones it doesn't have
+func (s *Service) RemoveClientTags(ctx context.Context, req *pb.RemoveClientTagsRequest) (*pb.RemoveClientTagsResponse, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var clientID string
+	if err := tx.GetContext(ctx, &clientID, "SELECT id FROM clients WHERE id = ? AND deleted_at IS NULL FOR UPDATE", req.ClientId); err != nil {
+		return nil, notFoundOr(err, "client "+req.ClientId+" not found")
+	}
+	q, args, err := sq.Delete("client_tags").Where(sq.Eq{"client_id": req.ClientId, "tag": tags}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return nil, err
+	}
+	client := &pb.Client{Id: req.ClientId}
+	if err := loadClientTags(ctx, tx, client); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.RemoveClientTagsResponse{Tags: client.Tags}, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAddClientTags(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE id = ? AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("MOCKID").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO client_tags (client_id,tag) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE tag = tag")).
+		WithArgs("MOCKID", "vip", "MOCKID", "trial").WillReturnResult(sqlmock.NewResult(0, 2))
+	expectClientTags(mock, [2]string{"MOCKID", "trial"}, [2]string{"MOCKID", "vip"})
+	mock.ExpectCommit()
+	resp, err := service.AddClientTags(context.Background(), &pb.AddClientTagsRequest{
+		ClientId: "MOCKID",
+		Tags:     []string{"vip", " trial ", "vip"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trial", "vip"}, resp.Tags)
+
+	_, err = service.AddClientTags(context.Background(), &pb.AddClientTagsRequest{ClientId: "MOCKID", Tags: []string{" "}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM clients").WithArgs("MISSING").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+	_, err = service.AddClientTags(context.Background(), &pb.AddClientTagsRequest{ClientId: "MISSING", Tags: []string{"vip"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveClientTags(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM clients").WithArgs("MOCKID").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("MOCKID"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM client_tags WHERE client_id = ? AND tag IN (?)")).
+		WithArgs("MOCKID", "vip").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectClientTags(mock, [2]string{"MOCKID", "trial"})
+	mock.ExpectCommit()
+	resp, err := service.RemoveClientTags(context.Background(), &pb.RemoveClientTagsRequest{
+		ClientId: "MOCKID",
+		Tags:     []string{"vip"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trial"}, resp.Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientsTags(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectClientsSQL)).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+		AddRow("A", "Alice").AddRow("B", "Bob"))
+	expectClientTags(mock, [2]string{"B", "vip"}, [2]string{"A", "trial"}, [2]string{"B", "trial"})
+	resp, err := service.GetClients(context.Background(), &pb.GetClientsRequest{Ids: []string{"A", "B"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Clients, 2)
+	assert.Equal(t, []string{"trial"}, resp.Clients[0].Tags)
+	assert.Equal(t, []string{"vip", "trial"}, resp.Clients[1].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryClientsTags(t *testing.T) {
+	service, mock := newTestService(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND id IN (SELECT client_id FROM client_tags WHERE tag IN (?,?))")).
+		WithArgs("vip", "trial").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err := service.QueryClients(context.Background(), &pb.QueryClientsRequest{Tags: []string{"vip", "trial"}})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score FROM clients WHERE deleted_at IS NULL AND id IN (SELECT client_id FROM client_tags WHERE tag IN (?,?) GROUP BY client_id HAVING COUNT(DISTINCT tag) = ?)")).
+		WithArgs("vip", "trial", 2).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = service.QueryClients(context.Background(), &pb.QueryClientsRequest{Tags: []string{"vip", "trial"}, TagsMatchAll: true})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// TLSConfig are the certificates of a server built by NewServer. The files are checked every
+// ReloadInterval (default 30s), and read again once changed, the connections already open going on with
+// the certificates they were made with.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM certificate (chain) and key of the server, without which it has no TLS
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, when set, has the PEM certificates of the CAs the clients must have a certificate of
+	ClientCAFile   string
+	ReloadInterval time.Duration
+}
+
+// NewServer creates a gRPC server with opts, serving TLS when config has a certificate, until ctx is done
+// for the reload of the certificates
+func NewServer(ctx context.Context, config TLSConfig, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if config.CertFile == "" && config.KeyFile == "" {
+		if config.ClientCAFile != "" {
+			return nil, errors.New("client CA without server certificate")
+		}
+		return grpc.NewServer(opts...), nil
+	}
+	if config.ReloadInterval <= 0 {
+		config.ReloadInterval = 30 * time.Second
+	}
+	certs := &tlsCerts{config: config}
+	if err := certs.load(); err != nil {
+		return nil, err
+	}
+	go certs.reload(ctx)
+	creds := credentials.NewTLS(&tls.Config{GetConfigForClient: certs.tlsConfig})
+	return grpc.NewServer(append([]grpc.ServerOption{grpc.Creds(creds)}, opts...)...), nil
+}
+
+// ClientCommonName is the common name of the verified client certificate of the call of ctx, when the
+// server requires them
+func ClientCommonName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// tlsCerts are the certificates of the files of config, as last read
+type tlsCerts struct {
+	config TLSConfig
+
+	mu       sync.RWMutex
+	cert     tls.Certificate
+	clientCA *x509.CertPool
+	modTimes []time.Time
+}
+
+// tlsConfig is the TLS config of a new connection, with the current certificates
+func (c *tlsCerts) tlsConfig(*tls.ClientHelloInfo) (*tls.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	config := &tls.Config{
+		Certificates: []tls.Certificate{c.cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}
+	if c.clientCA != nil {
+		config.ClientCAs = c.clientCA
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+func (c *tlsCerts) files() []string {
+	files := []string{c.config.CertFile, c.config.KeyFile}
+	if c.config.ClientCAFile != "" {
+		files = append(files, c.config.ClientCAFile)
+	}
+	return files
+}
+
+// changed tells if any file was modified since it was last read
+func (c *tlsCerts) changed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for i, file := range c.files() {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(c.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads the files, keeping the current certificates if any is invalid
+func (c *tlsCerts) load() error {
+	var modTimes []time.Time
+	for _, file := range c.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	cert, err := tls.LoadX509KeyPair(c.config.CertFile, c.config.KeyFile)
+	if err != nil {
+		return err
+	}
+	var clientCA *x509.CertPool
+	if c.config.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(c.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCA = x509.NewCertPool()
+		if !clientCA.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate in client CA file %s", c.config.ClientCAFile)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert, c.clientCA, c.modTimes = cert, clientCA, modTimes
+	return nil
+}
+
+// reload loads the files every ReloadInterval they changed, until ctx is done
+func (c *tlsCerts) reload(ctx context.Context) {
+	ticker := time.NewTicker(c.config.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !c.changed() {
+			continue
+		}
+		if err := c.load(); err != nil {
+			log.Error().Err(err).Str("cert_file", c.config.CertFile).Caller().Msg("tls certificates reload error")
+			continue
+		}
+		log.Info().Str("cert_file", c.config.CertFile).Msg("tls certificates reloaded")
+	}
+}
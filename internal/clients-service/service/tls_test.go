@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedidopago/trainingsvc-clients/protos/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testCert is a certificate for tests, signed by parent or self-signed when it's nil
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write writes the PEM certificate and key to certFile and keyFile, set as modified at modTime
+func (c *testCert) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	if keyFile != "" {
+		require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+		require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestNewServerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	config := TLSConfig{
+		CertFile:       filepath.Join(dir, "server.pem"),
+		KeyFile:        filepath.Join(dir, "server.key"),
+		ClientCAFile:   filepath.Join(dir, "ca.pem"),
+		ReloadInterval: 10 * time.Millisecond,
+	}
+	ca := newTestCert(t, "Test CA", nil)
+	ca.write(t, config.ClientCAFile, "", time.Now())
+	newTestCert(t, "clients", ca).write(t, config.CertFile, config.KeyFile, time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var clientCN string
+	sv, err := NewServer(ctx, config, grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			clientCN = ClientCommonName(ctx)
+			return handler(ctx, req)
+		}))
+	require.NoError(t, err)
+	require.NoError(t, New(ctx, sv, Config{}))
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = sv.Serve(lis) }()
+	defer sv.Stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	dial := func(certs ...tls.Certificate) *grpc.ClientConn {
+		conn, err := grpc.Dial("clients", grpc.WithBlock(), grpc.WithTimeout(5*time.Second),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: certs})),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+		require.NoError(t, err)
+		return conn
+	}
+	// sort calls Sort on conn, returning the common name of the server certificate
+	sort := func(conn *grpc.ClientConn) string {
+		var p peer.Peer
+		resp, err := pb.NewClientsServiceClient(conn).Sort(ctx, &pb.SortRequest{Items: []string{"b", "a"}}, grpc.Peer(&p))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, resp.Items)
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0].Subject.CommonName
+	}
+
+	conn := dial(newTestCert(t, "batch-job", ca).tlsCertificate())
+	defer conn.Close()
+	assert.Equal(t, "clients", sort(conn))
+	assert.Equal(t, "batch-job", clientCN)
+
+	// the clients without a certificate of the CA are refused
+	for _, certs := range [][]tls.Certificate{nil, {newTestCert(t, "batch-job", nil).tlsCertificate()}} {
+		_, err := grpc.Dial("clients", grpc.WithBlock(), grpc.WithTimeout(200*time.Millisecond),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: certs})),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }))
+		assert.Error(t, err)
+	}
+
+	// the new certificates are used by the new connections, the open ones going on
+	newTestCert(t, "clients", ca).write(t, config.CertFile, config.KeyFile, time.Now().Add(time.Minute))
+	serial := func(conn *grpc.ClientConn) *big.Int {
+		var p peer.Peer
+		_, err := pb.NewClientsServiceClient(conn).Sort(ctx, &pb.SortRequest{}, grpc.Peer(&p))
+		require.NoError(t, err)
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0].SerialNumber
+	}
+	oldSerial := serial(conn)
+	assert.Eventually(t, func() bool {
+		newConn := dial(newTestCert(t, "batch-job", ca).tlsCertificate())
+		defer newConn.Close()
+		return serial(newConn).Cmp(oldSerial) != 0
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, oldSerial, serial(conn))
+	assert.Equal(t, "clients", sort(conn))
+}
+
+func TestNewServerInvalidTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+
+	_, err := NewServer(ctx, TLSConfig{ClientCAFile: filepath.Join(dir, "ca.pem")})
+	assert.EqualError(t, err, "client CA without server certificate")
+	_, err = NewServer(ctx, TLSConfig{CertFile: filepath.Join(dir, "server.pem"), KeyFile: filepath.Join(dir, "server.key")})
+	assert.Error(t, err)
+
+	sv, err := NewServer(ctx, TLSConfig{})
+	require.NoError(t, err)
+	assert.NotNil(t, sv)
+}
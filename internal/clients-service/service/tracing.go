@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tracerName is the instrumentation name of the spans of the service
+const tracerName = "github.com/pedidopago/trainingsvc-clients/internal/clients-service/service"
+
+// maxTracedStatement is the max size of the SQL text recorded on a span. Only the text is recorded, as the
+// args may carry personal data.
+const maxTracedStatement = 2048
+
+// tracerProvider is tp, or the global provider when it's nil
+func tracerProvider(tp trace.TracerProvider) trace.TracerProvider {
+	if tp == nil {
+		return otel.GetTracerProvider()
+	}
+	return tp
+}
+
+// TracingServerOptions are the grpc.NewServer options starting a span for each RPC, continuing the trace
+// of the incoming metadata as extracted by the global propagator. A nil tp stands for the global provider.
+func TracingServerOptions(tp trace.TracerProvider) []grpc.ServerOption {
+	t := rpcTracer{tracer: tracerProvider(tp).Tracer(tracerName)}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(t.unaryInterceptor),
+		grpc.ChainStreamInterceptor(t.streamInterceptor),
+	}
+}
+
+// rpcTracer has the interceptors of TracingServerOptions
+type rpcTracer struct {
+	tracer trace.Tracer
+}
+
+func (t rpcTracer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := t.start(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	t.end(span, err)
+	return resp, err
+}
+
+func (t rpcTracer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := t.start(ss.Context(), info.FullMethod)
+	err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	t.end(span, err)
+	return err
+}
+
+// start starts the span of a call of fullMethod ("/service/method")
+func (t rpcTracer) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	name := strings.TrimPrefix(fullMethod, "/")
+	attrs := []attribute.KeyValue{semconv.RPCSystemKey.String("grpc")}
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		attrs = append(attrs, semconv.RPCServiceKey.String(name[:i]), semconv.RPCMethodKey.String(name[i+1:]))
+	}
+	return t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// end ends the span of an RPC that returned err
+func (rpcTracer) end(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+	span.End()
+}
+
+// contextServerStream is a stream with the context its handler is given, as with the span of the RPC
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context { return s.ctx }
+
+// metadataCarrier lets the propagators read and write gRPC metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) { metadata.MD(c).Set(key, value) }
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// tracedConnector opens connections recording a span for each statement they run, as a child of the span
+// of its context. The span of a query lasts until its rows are closed, so it includes their scanning.
+type tracedConnector struct {
+	driver.Connector
+	tracer trace.Tracer
+}
+
+func (c tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, tracer: c.tracer}, nil
+}
+
+// tracedConn passes everything through to its Conn, falling back as database/sql would on what it
+// doesn't implement. The spans are started once a statement returns, with the time it was run, so the
+// statements the driver skips (to run them prepared) have none.
+type tracedConn struct {
+	driver.Conn
+	tracer trace.Tracer
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, tracer: c.tracer, query: query}, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+	traceExec(ctx, c.tracer, start, query, result, err)
+	return result, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+	return traceQuery(ctx, c.tracer, start, query, rows, err)
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *tracedConn) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+	return driver.ErrSkip
+}
+
+// tracedStmt is a prepared statement of a tracedConn
+type tracedStmt struct {
+	driver.Stmt
+	tracer trace.Tracer
+	query  string
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.Stmt.Exec(namedValues(args))
+	}
+	traceExec(ctx, s.tracer, start, s.query, result, err)
+	return result, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(namedValues(args))
+	}
+	return traceQuery(ctx, s.tracer, start, s.query, rows, err)
+}
+
+func (s *tracedStmt) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+	return driver.ErrSkip
+}
+
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+// startStatementSpan starts the span of query, run at start
+func startStatementSpan(ctx context.Context, tracer trace.Tracer, start time.Time, query string) trace.Span {
+	statement := query
+	if len(statement) > maxTracedStatement {
+		statement = statement[:maxTracedStatement] + "..."
+	}
+	name := "sql"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		name += " " + strings.ToUpper(fields[0])
+	}
+	attrs := []attribute.KeyValue{semconv.DBSystemMySQL, semconv.DBStatementKey.String(statement)}
+	if id := RequestID(ctx); id != "" {
+		attrs = append(attrs, attribute.String("request.id", id))
+	}
+	_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithTimestamp(start),
+		trace.WithAttributes(attrs...))
+	return span
+}
+
+func traceExec(ctx context.Context, tracer trace.Tracer, start time.Time, query string, result driver.Result, err error) {
+	span := startStatementSpan(ctx, tracer, start, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	} else if n, err := result.RowsAffected(); err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", n))
+	}
+	span.End()
+}
+
+func traceQuery(ctx context.Context, tracer trace.Tracer, start time.Time, query string, rows driver.Rows, err error) (driver.Rows, error) {
+	span := startStatementSpan(ctx, tracer, start, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+// tracedRows ends the span of their query once closed, with the number of rows read
+type tracedRows struct {
+	driver.Rows
+	span trace.Span
+	n    int64
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.n++
+	} else if err != io.EOF {
+		r.span.RecordError(err)
+		r.span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	r.span.SetAttributes(attribute.Int64("db.rows", r.n))
+	r.span.End()
+	return err
+}
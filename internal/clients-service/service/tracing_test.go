@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// spanAttrs are the attributes of span by key
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingRPCs(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagator)
+	recorder := tracetest.NewSpanRecorder()
+	tracer := rpcTracer{tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)}
+
+	// the trace of the caller goes on
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"))
+	var handlerSpan trace.SpanContext
+	_, err := tracer.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pb.ClientsService/GetClient"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return nil, status.Error(codes.NotFound, "client not found")
+		})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "pb.ClientsService/GetClient", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+	assert.Equal(t, span.SpanContext(), handlerSpan, "the handler runs in the span")
+	assert.Equal(t, otelcodes.Error, span.Status().Code)
+	attrs := spanAttrs(span)
+	assert.Equal(t, "pb.ClientsService", attrs["rpc.service"].AsString())
+	assert.Equal(t, "GetClient", attrs["rpc.method"].AsString())
+	assert.Equal(t, int64(codes.NotFound), attrs["rpc.grpc.status_code"].AsInt64())
+
+	// without incoming trace a new one starts, and the streams carry their span
+	err = tracer.streamInterceptor(nil, &sortStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/pb.ClientsService/SortStream"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			handlerSpan = trace.SpanContextFromContext(stream.Context())
+			return nil
+		})
+	require.NoError(t, err)
+	spans = recorder.Ended()
+	require.Len(t, spans, 2)
+	span = spans[1]
+	assert.Equal(t, "pb.ClientsService/SortStream", span.Name())
+	assert.False(t, span.Parent().IsValid())
+	assert.Equal(t, span.SpanContext(), handlerSpan)
+	assert.Equal(t, otelcodes.Unset, span.Status().Code)
+}
+
+// dsnConnector opens the connections of drv to dsn, as sql.Open does for the drivers without connectors
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.drv.Open(c.dsn) }
+
+func (c dsnConnector) Driver() driver.Driver { return c.drv }
+
+func TestTracingStatements(t *testing.T) {
+	dsn := fmt.Sprintf("traced-%d", time.Now().UnixNano())
+	rdb, mock, err := sqlmock.NewWithDSN(dsn)
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	db := sqlx.NewDb(sql.OpenDB(tracedConnector{
+		Connector: dsnConnector{dsn: dsn, drv: rdb.Driver()},
+		tracer:    tp.Tracer(tracerName),
+	}), "mysql")
+	defer db.Close()
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	// lastSpan is the last span ended, checking it's a statement of the parent span
+	lastSpan := func() sdktrace.ReadOnlySpan {
+		t.Helper()
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		span := spans[len(spans)-1]
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, "mysql", spanAttrs(span)["db.system"].AsString())
+		return span
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE clients SET notes = ? WHERE score > ?")).
+		WithArgs("SECRET", 10).WillReturnResult(sqlmock.NewResult(0, 2))
+	_, err = db.ExecContext(context.WithValue(ctx, requestIDKey{}, "req-1"), "UPDATE clients SET notes = ? WHERE score > ?", "SECRET", 10)
+	require.NoError(t, err)
+	span := lastSpan()
+	assert.Equal(t, "sql UPDATE", span.Name())
+	attrs := spanAttrs(span)
+	assert.Equal(t, "UPDATE clients SET notes = ? WHERE score > ?", attrs["db.statement"].AsString())
+	assert.Equal(t, int64(2), attrs["db.rows_affected"].AsInt64())
+	assert.Equal(t, "req-1", attrs["request.id"].AsString())
+	for _, kv := range span.Attributes() {
+		assert.NotContains(t, kv.Value.Emit(), "SECRET", "the args are not recorded")
+	}
+
+	// the span of a query lasts until its rows are read
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("A").AddRow("B").AddRow("C"))
+	var ids []string
+	require.NoError(t, db.SelectContext(ctx, &ids, "SELECT id FROM clients"))
+	span = lastSpan()
+	assert.Equal(t, "sql SELECT", span.Name())
+	assert.Equal(t, int64(3), spanAttrs(span)["db.rows"].AsInt64())
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM clients WHERE id = ?")).WillReturnError(errors.New("connection reset"))
+	require.Error(t, db.GetContext(ctx, &ids, "SELECT id FROM clients WHERE id = ?", "A"))
+	span = lastSpan()
+	assert.Equal(t, otelcodes.Error, span.Status().Code)
+	assert.Equal(t, "connection reset", span.Status().Description)
+
+	// prepared statements and transactions are traced alike
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT COUNT(*) FROM clients WHERE score > ?")).
+		ExpectQuery().WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	stmt, err := db.PreparexContext(ctx, "SELECT COUNT(*) FROM clients WHERE score > ?")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, stmt.GetContext(ctx, &count, 5))
+	assert.Equal(t, 7, count)
+	span = lastSpan()
+	assert.Equal(t, "SELECT COUNT(*) FROM clients WHERE score > ?", spanAttrs(span)["db.statement"].AsString())
+	assert.Equal(t, int64(1), spanAttrs(span)["db.rows"].AsInt64())
+
+	long := "DELETE FROM clients WHERE id IN (" + strings.Repeat("?, ", 1000) + "?)"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(long)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	tx, err := db.BeginTxx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, long)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	span = lastSpan()
+	assert.Equal(t, "sql DELETE", span.Name())
+	assert.Equal(t, long[:maxTracedStatement]+"...", spanAttrs(span)["db.statement"].AsString())
+
+	assert.Len(t, recorder.Ended(), 5)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
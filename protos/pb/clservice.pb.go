@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,10 +25,143 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type NameMatch int32
+
+const (
+	// name is a LIKE pattern, with the caller's own wildcards
+	NameMatch_PATTERN  NameMatch = 0
+	NameMatch_EXACT    NameMatch = 1
+	NameMatch_PREFIX   NameMatch = 2
+	NameMatch_CONTAINS NameMatch = 3
+)
+
+var NameMatch_name = map[int32]string{
+	0: "PATTERN",
+	1: "EXACT",
+	2: "PREFIX",
+	3: "CONTAINS",
+}
+
+var NameMatch_value = map[string]int32{
+	"PATTERN":  0,
+	"EXACT":    1,
+	"PREFIX":   2,
+	"CONTAINS": 3,
+}
+
+func (x NameMatch) String() string {
+	return proto.EnumName(NameMatch_name, int32(x))
+}
+
+func (NameMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{0}
+}
+
+// ClientOrderBy is the column QueryClients orders by; ties are broken by id
+type ClientOrderBy int32
+
+const (
+	ClientOrderBy_SCORE      ClientOrderBy = 0
+	ClientOrderBy_NAME       ClientOrderBy = 1
+	ClientOrderBy_CREATED_AT ClientOrderBy = 2
+	ClientOrderBy_BIRTHDAY   ClientOrderBy = 3
+)
+
+var ClientOrderBy_name = map[int32]string{
+	0: "SCORE",
+	1: "NAME",
+	2: "CREATED_AT",
+	3: "BIRTHDAY",
+}
+
+var ClientOrderBy_value = map[string]int32{
+	"SCORE":      0,
+	"NAME":       1,
+	"CREATED_AT": 2,
+	"BIRTHDAY":   3,
+}
+
+func (x ClientOrderBy) String() string {
+	return proto.EnumName(ClientOrderBy_name, int32(x))
+}
+
+func (ClientOrderBy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{1}
+}
+
+type ScoreHistoryBucket int32
+
+const (
+	ScoreHistoryBucket_DAY  ScoreHistoryBucket = 0
+	ScoreHistoryBucket_WEEK ScoreHistoryBucket = 1
+)
+
+var ScoreHistoryBucket_name = map[int32]string{
+	0: "DAY",
+	1: "WEEK",
+}
+
+var ScoreHistoryBucket_value = map[string]int32{
+	"DAY":  0,
+	"WEEK": 1,
+}
+
+func (x ScoreHistoryBucket) String() string {
+	return proto.EnumName(ScoreHistoryBucket_name, int32(x))
+}
+
+func (ScoreHistoryBucket) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{2}
+}
+
+type SortMode int32
+
+const (
+	SortMode_STRING SortMode = 0
+	// by value, the items being base 10 int64 (InvalidArgument otherwise); equal
+	// values are ordered bytewise
+	SortMode_NUMERIC SortMode = 1
+	// the runs of ASCII digits compared as numbers and the text around them bytewise, so
+	// "file2" comes before "file10"; items equal but for leading zeros, as "file01" and
+	// "file1", are ordered bytewise and are duplicates
+	SortMode_NATURAL SortMode = 2
+)
+
+var SortMode_name = map[int32]string{
+	0: "STRING",
+	1: "NUMERIC",
+	2: "NATURAL",
+}
+
+var SortMode_value = map[string]int32{
+	"STRING":  0,
+	"NUMERIC": 1,
+	"NATURAL": 2,
+}
+
+func (x SortMode) String() string {
+	return proto.EnumName(SortMode_name, int32(x))
+}
+
+func (SortMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{3}
+}
+
 type NewClientRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Birthday             int64    `protobuf:"varint,2,opt,name=birthday,proto3" json:"birthday,omitempty"`
-	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Birthday int64  `protobuf:"varint,2,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Score    int64  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	// retries with the same key return the originally created client; not
+	// supported by NewClients
+	IdempotencyKey string            `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Email          string            `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone          string            `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	Metadata       map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Notes          string            `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	ExternalId     string            `protobuf:"bytes,9,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// optional, must be a valid SecureID (ULID); a new one is generated if empty.
+	// Not supported by NewClients
+	Id                   string   `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -79,8 +213,58 @@ func (m *NewClientRequest) GetScore() int64 {
 	return 0
 }
 
+func (m *NewClientRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *NewClientRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *NewClientRequest) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *NewClientRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *NewClientRequest) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
+
+func (m *NewClientRequest) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+func (m *NewClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 type NewClientResponse struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Client               *Client  `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -118,22 +302,172 @@ func (m *NewClientResponse) GetId() string {
 	return ""
 }
 
+func (m *NewClientResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type NewClientsRequest struct {
+	Clients              []*NewClientRequest `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *NewClientsRequest) Reset()         { *m = NewClientsRequest{} }
+func (m *NewClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*NewClientsRequest) ProtoMessage()    {}
+func (*NewClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{2}
+}
+
+func (m *NewClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewClientsRequest.Unmarshal(m, b)
+}
+func (m *NewClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *NewClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewClientsRequest.Merge(m, src)
+}
+func (m *NewClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_NewClientsRequest.Size(m)
+}
+func (m *NewClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewClientsRequest proto.InternalMessageInfo
+
+func (m *NewClientsRequest) GetClients() []*NewClientRequest {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+type NewClientsResponse struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	FailedIndexes        []int64  `protobuf:"varint,2,rep,packed,name=failed_indexes,json=failedIndexes,proto3" json:"failed_indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewClientsResponse) Reset()         { *m = NewClientsResponse{} }
+func (m *NewClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*NewClientsResponse) ProtoMessage()    {}
+func (*NewClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{3}
+}
+
+func (m *NewClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewClientsResponse.Unmarshal(m, b)
+}
+func (m *NewClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *NewClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewClientsResponse.Merge(m, src)
+}
+func (m *NewClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_NewClientsResponse.Size(m)
+}
+func (m *NewClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewClientsResponse proto.InternalMessageInfo
+
+func (m *NewClientsResponse) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *NewClientsResponse) GetFailedIndexes() []int64 {
+	if m != nil {
+		return m.FailedIndexes
+	}
+	return nil
+}
+
 type QueryClientsRequest struct {
-	Id       *OptString `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name     *OptString `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Birthday *Int64Comp `protobuf:"bytes,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
-	Score    *Int64Comp `protobuf:"bytes,4,opt,name=score,proto3" json:"score,omitempty"`
-	CreatedAt            *Int64Comp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Id           *OptString     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         *OptString     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Birthday     *Int64Comp     `protobuf:"bytes,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Score        *Int64Comp     `protobuf:"bytes,4,opt,name=score,proto3" json:"score,omitempty"`
+	CreatedAt    *Int64Comp     `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Email        *OptString     `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Phone        *OptString     `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone,omitempty"`
+	Tags         []string       `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagsMatchAll bool           `protobuf:"varint,9,opt,name=tags_match_all,json=tagsMatchAll,proto3" json:"tags_match_all,omitempty"`
+	UpdatedAt    *Int64Comp     `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status       []ClientStatus `protobuf:"varint,11,rep,packed,name=status,proto3,enum=pb.ClientStatus" json:"status,omitempty"`
+	ExternalId   *OptString     `protobuf:"bytes,12,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	LastSeenAt   *Int64Comp     `protobuf:"bytes,13,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	Limit        int64          `protobuf:"varint,14,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset       int64          `protobuf:"varint,15,opt,name=offset,proto3" json:"offset,omitempty"`
+	// returns every matching id, ignoring limit and offset; ignored without
+	// filters, so the whole table is never returned at once
+	NoLimit bool `protobuf:"varint,16,opt,name=no_limit,json=noLimit,proto3" json:"no_limit,omitempty"`
+	// next_page_token of the previous page; can't be used with offset. A token
+	// is only valid with the same filters as the request that returned it
+	PageToken string `protobuf:"bytes,17,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// also counts every client matching the filters, with a second query
+	IncludeTotalCount bool          `protobuf:"varint,18,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	OrderBy           ClientOrderBy `protobuf:"varint,19,opt,name=order_by,json=orderBy,proto3,enum=pb.ClientOrderBy" json:"order_by,omitempty"`
+	Ascending         bool          `protobuf:"varint,20,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	// sort keys applied in order, each column at most once; when set, order_by
+	// and ascending are ignored
+	Sort      []*ClientSortKey `protobuf:"bytes,21,rep,name=sort,proto3" json:"sort,omitempty"`
+	NameMatch NameMatch        `protobuf:"varint,22,opt,name=name_match,json=nameMatch,proto3,enum=pb.NameMatch" json:"name_match,omitempty"`
+	// words that must all be in the name; the results are ordered by relevance
+	// (before the sort keys) and paged by offset only when the service uses the
+	// fulltext index
+	Search string `protobuf:"bytes,23,opt,name=search,proto3" json:"search,omitempty"`
+	// score range, both bounds inclusive; clients without a score never match
+	ScoreMin *OptInt64 `protobuf:"bytes,24,opt,name=score_min,json=scoreMin,proto3" json:"score_min,omitempty"`
+	ScoreMax *OptInt64 `protobuf:"bytes,25,opt,name=score_max,json=scoreMax,proto3" json:"score_max,omitempty"`
+	// birthday range (unixnano), both bounds inclusive; clients without a
+	// birthday never match
+	BirthdayFrom *OptInt64 `protobuf:"bytes,26,opt,name=birthday_from,json=birthdayFrom,proto3" json:"birthday_from,omitempty"`
+	BirthdayTo   *OptInt64 `protobuf:"bytes,27,opt,name=birthday_to,json=birthdayTo,proto3" json:"birthday_to,omitempty"`
+	// restricts the results to these ids; empty means no restriction. Can't be
+	// used with id
+	Ids            []string `protobuf:"bytes,28,rep,name=ids,proto3" json:"ids,omitempty"`
+	BirthdayIsNull *OptBool `protobuf:"bytes,29,opt,name=birthday_is_null,json=birthdayIsNull,proto3" json:"birthday_is_null,omitempty"`
+	// clients created in the last created_within seconds, as of the database
+	// clock; 0 means no restriction. Can't be used with created_at
+	CreatedWithin int64 `protobuf:"varint,30,opt,name=created_within,json=createdWithin,proto3" json:"created_within,omitempty"`
+	// groups of filters, each ANDed like the filters of a request and ORed with
+	// the other groups; the result is ANDed with the filters of the request. A
+	// group must set at least one filter and nothing else (no paging, order or
+	// groups of its own)
+	FilterGroups   []*QueryClientsRequest `protobuf:"bytes,31,rep,name=filter_groups,json=filterGroups,proto3" json:"filter_groups,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,32,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// also returns the matching clients, read by the same query as the ids
+	ReturnClients bool `protobuf:"varint,33,opt,name=return_clients,json=returnClients,proto3" json:"return_clients,omitempty"`
+	// age range in whole years as of the database's current date, both bounds
+	// inclusive (a client turning age_min today matches); clients without a
+	// birthday never match
+	AgeMin *OptInt64 `protobuf:"bytes,34,opt,name=age_min,json=ageMin,proto3" json:"age_min,omitempty"`
+	AgeMax *OptInt64 `protobuf:"bytes,35,opt,name=age_max,json=ageMax,proto3" json:"age_max,omitempty"`
+	// acknowledges a request without filters, when the service requires them
+	AllowUnfiltered      bool     `protobuf:"varint,36,opt,name=allow_unfiltered,json=allowUnfiltered,proto3" json:"allow_unfiltered,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *QueryClientsRequest) Reset()         { *m = QueryClientsRequest{} }
 func (m *QueryClientsRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryClientsRequest) ProtoMessage()    {}
 func (*QueryClientsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{2}
+	return fileDescriptor_1b09ac349de90e68, []int{4}
 }
 
 func (m *QueryClientsRequest) XXX_Unmarshal(b []byte) error {
@@ -189,724 +523,7281 @@ func (m *QueryClientsRequest) GetCreatedAt() *Int64Comp {
 	return nil
 }
 
-type QueryClientsResponse struct {
-	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QueryClientsRequest) GetEmail() *OptString {
+	if m != nil {
+		return m.Email
+	}
+	return nil
 }
 
-func (m *QueryClientsResponse) Reset()         { *m = QueryClientsResponse{} }
-func (m *QueryClientsResponse) String() string { return proto.CompactTextString(m) }
-func (*QueryClientsResponse) ProtoMessage()    {}
-func (*QueryClientsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{3}
+func (m *QueryClientsRequest) GetPhone() *OptString {
+	if m != nil {
+		return m.Phone
+	}
+	return nil
 }
 
-func (m *QueryClientsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_QueryClientsResponse.Unmarshal(m, b)
-}
-func (m *QueryClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_QueryClientsResponse.Marshal(b, m, deterministic)
-}
-func (m *QueryClientsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryClientsResponse.Merge(m, src)
+func (m *QueryClientsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
-func (m *QueryClientsResponse) XXX_Size() int {
-	return xxx_messageInfo_QueryClientsResponse.Size(m)
+
+func (m *QueryClientsRequest) GetTagsMatchAll() bool {
+	if m != nil {
+		return m.TagsMatchAll
+	}
+	return false
 }
-func (m *QueryClientsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryClientsResponse.DiscardUnknown(m)
+
+func (m *QueryClientsRequest) GetUpdatedAt() *Int64Comp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
 }
 
-var xxx_messageInfo_QueryClientsResponse proto.InternalMessageInfo
+func (m *QueryClientsRequest) GetStatus() []ClientStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
 
-func (m *QueryClientsResponse) GetIds() []string {
+func (m *QueryClientsRequest) GetExternalId() *OptString {
 	if m != nil {
-		return m.Ids
+		return m.ExternalId
 	}
 	return nil
 }
 
-type GetClientsRequest struct {
-	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QueryClientsRequest) GetLastSeenAt() *Int64Comp {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return nil
 }
 
-func (m *GetClientsRequest) Reset()         { *m = GetClientsRequest{} }
-func (m *GetClientsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetClientsRequest) ProtoMessage()    {}
-func (*GetClientsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{4}
+func (m *QueryClientsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-func (m *GetClientsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClientsRequest.Unmarshal(m, b)
+func (m *QueryClientsRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
 }
-func (m *GetClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClientsRequest.Marshal(b, m, deterministic)
+
+func (m *QueryClientsRequest) GetNoLimit() bool {
+	if m != nil {
+		return m.NoLimit
+	}
+	return false
 }
-func (m *GetClientsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClientsRequest.Merge(m, src)
+
+func (m *QueryClientsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
 }
-func (m *GetClientsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetClientsRequest.Size(m)
+
+func (m *QueryClientsRequest) GetIncludeTotalCount() bool {
+	if m != nil {
+		return m.IncludeTotalCount
+	}
+	return false
 }
-func (m *GetClientsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClientsRequest.DiscardUnknown(m)
+
+func (m *QueryClientsRequest) GetOrderBy() ClientOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ClientOrderBy_SCORE
 }
 
-var xxx_messageInfo_GetClientsRequest proto.InternalMessageInfo
+func (m *QueryClientsRequest) GetAscending() bool {
+	if m != nil {
+		return m.Ascending
+	}
+	return false
+}
 
-func (m *GetClientsRequest) GetIds() []string {
+func (m *QueryClientsRequest) GetSort() []*ClientSortKey {
 	if m != nil {
-		return m.Ids
+		return m.Sort
 	}
 	return nil
 }
 
-type GetClientsResponse struct {
-	Clients              []*Client `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *QueryClientsRequest) GetNameMatch() NameMatch {
+	if m != nil {
+		return m.NameMatch
+	}
+	return NameMatch_PATTERN
 }
 
-func (m *GetClientsResponse) Reset()         { *m = GetClientsResponse{} }
-func (m *GetClientsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetClientsResponse) ProtoMessage()    {}
-func (*GetClientsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{5}
+func (m *QueryClientsRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
 }
 
-func (m *GetClientsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClientsResponse.Unmarshal(m, b)
-}
-func (m *GetClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClientsResponse.Marshal(b, m, deterministic)
-}
-func (m *GetClientsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClientsResponse.Merge(m, src)
-}
-func (m *GetClientsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetClientsResponse.Size(m)
+func (m *QueryClientsRequest) GetScoreMin() *OptInt64 {
+	if m != nil {
+		return m.ScoreMin
+	}
+	return nil
 }
-func (m *GetClientsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClientsResponse.DiscardUnknown(m)
+
+func (m *QueryClientsRequest) GetScoreMax() *OptInt64 {
+	if m != nil {
+		return m.ScoreMax
+	}
+	return nil
 }
 
-var xxx_messageInfo_GetClientsResponse proto.InternalMessageInfo
+func (m *QueryClientsRequest) GetBirthdayFrom() *OptInt64 {
+	if m != nil {
+		return m.BirthdayFrom
+	}
+	return nil
+}
 
-func (m *GetClientsResponse) GetClients() []*Client {
+func (m *QueryClientsRequest) GetBirthdayTo() *OptInt64 {
 	if m != nil {
-		return m.Clients
+		return m.BirthdayTo
 	}
 	return nil
 }
 
-type DeleteClientRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QueryClientsRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
 }
 
-func (m *DeleteClientRequest) Reset()         { *m = DeleteClientRequest{} }
-func (m *DeleteClientRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteClientRequest) ProtoMessage()    {}
-func (*DeleteClientRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{6}
+func (m *QueryClientsRequest) GetBirthdayIsNull() *OptBool {
+	if m != nil {
+		return m.BirthdayIsNull
+	}
+	return nil
 }
 
-func (m *DeleteClientRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteClientRequest.Unmarshal(m, b)
+func (m *QueryClientsRequest) GetCreatedWithin() int64 {
+	if m != nil {
+		return m.CreatedWithin
+	}
+	return 0
 }
-func (m *DeleteClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteClientRequest.Marshal(b, m, deterministic)
+
+func (m *QueryClientsRequest) GetFilterGroups() []*QueryClientsRequest {
+	if m != nil {
+		return m.FilterGroups
+	}
+	return nil
 }
-func (m *DeleteClientRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteClientRequest.Merge(m, src)
+
+func (m *QueryClientsRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
 }
-func (m *DeleteClientRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteClientRequest.Size(m)
+
+func (m *QueryClientsRequest) GetReturnClients() bool {
+	if m != nil {
+		return m.ReturnClients
+	}
+	return false
 }
-func (m *DeleteClientRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteClientRequest.DiscardUnknown(m)
+
+func (m *QueryClientsRequest) GetAgeMin() *OptInt64 {
+	if m != nil {
+		return m.AgeMin
+	}
+	return nil
 }
 
-var xxx_messageInfo_DeleteClientRequest proto.InternalMessageInfo
+func (m *QueryClientsRequest) GetAgeMax() *OptInt64 {
+	if m != nil {
+		return m.AgeMax
+	}
+	return nil
+}
 
-func (m *DeleteClientRequest) GetId() string {
+func (m *QueryClientsRequest) GetAllowUnfiltered() bool {
 	if m != nil {
-		return m.Id
+		return m.AllowUnfiltered
 	}
-	return ""
+	return false
 }
 
-type DeleteClientResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ClientSortKey struct {
+	Column               ClientOrderBy `protobuf:"varint,1,opt,name=column,proto3,enum=pb.ClientOrderBy" json:"column,omitempty"`
+	Ascending            bool          `protobuf:"varint,2,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *DeleteClientResponse) Reset()         { *m = DeleteClientResponse{} }
-func (m *DeleteClientResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteClientResponse) ProtoMessage()    {}
-func (*DeleteClientResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{7}
+func (m *ClientSortKey) Reset()         { *m = ClientSortKey{} }
+func (m *ClientSortKey) String() string { return proto.CompactTextString(m) }
+func (*ClientSortKey) ProtoMessage()    {}
+func (*ClientSortKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{5}
 }
 
-func (m *DeleteClientResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteClientResponse.Unmarshal(m, b)
+func (m *ClientSortKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientSortKey.Unmarshal(m, b)
 }
-func (m *DeleteClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteClientResponse.Marshal(b, m, deterministic)
+func (m *ClientSortKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientSortKey.Marshal(b, m, deterministic)
 }
-func (m *DeleteClientResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteClientResponse.Merge(m, src)
+func (m *ClientSortKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientSortKey.Merge(m, src)
 }
-func (m *DeleteClientResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteClientResponse.Size(m)
+func (m *ClientSortKey) XXX_Size() int {
+	return xxx_messageInfo_ClientSortKey.Size(m)
 }
-func (m *DeleteClientResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteClientResponse.DiscardUnknown(m)
+func (m *ClientSortKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientSortKey.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteClientResponse proto.InternalMessageInfo
+var xxx_messageInfo_ClientSortKey proto.InternalMessageInfo
 
-type DeleteAllClientsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ClientSortKey) GetColumn() ClientOrderBy {
+	if m != nil {
+		return m.Column
+	}
+	return ClientOrderBy_SCORE
 }
 
-func (m *DeleteAllClientsRequest) Reset()         { *m = DeleteAllClientsRequest{} }
-func (m *DeleteAllClientsRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteAllClientsRequest) ProtoMessage()    {}
-func (*DeleteAllClientsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{8}
+func (m *ClientSortKey) GetAscending() bool {
+	if m != nil {
+		return m.Ascending
+	}
+	return false
 }
 
-func (m *DeleteAllClientsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteAllClientsRequest.Unmarshal(m, b)
+type QueryClientsResponse struct {
+	Ids                  []string  `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	NextPageToken        string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount           int64     `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Clients              []*Client `protobuf:"bytes,4,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
-func (m *DeleteAllClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteAllClientsRequest.Marshal(b, m, deterministic)
+
+func (m *QueryClientsResponse) Reset()         { *m = QueryClientsResponse{} }
+func (m *QueryClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryClientsResponse) ProtoMessage()    {}
+func (*QueryClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{6}
 }
-func (m *DeleteAllClientsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteAllClientsRequest.Merge(m, src)
+
+func (m *QueryClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryClientsResponse.Unmarshal(m, b)
 }
-func (m *DeleteAllClientsRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteAllClientsRequest.Size(m)
+func (m *QueryClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryClientsResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteAllClientsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteAllClientsRequest.DiscardUnknown(m)
+func (m *QueryClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryClientsResponse.Merge(m, src)
+}
+func (m *QueryClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryClientsResponse.Size(m)
+}
+func (m *QueryClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryClientsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteAllClientsRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryClientsResponse proto.InternalMessageInfo
 
-type DeleteAllClientsResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QueryClientsResponse) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
 }
 
-func (m *DeleteAllClientsResponse) Reset()         { *m = DeleteAllClientsResponse{} }
-func (m *DeleteAllClientsResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteAllClientsResponse) ProtoMessage()    {}
-func (*DeleteAllClientsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{9}
+func (m *QueryClientsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
 }
 
-func (m *DeleteAllClientsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteAllClientsResponse.Unmarshal(m, b)
-}
-func (m *DeleteAllClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteAllClientsResponse.Marshal(b, m, deterministic)
-}
-func (m *DeleteAllClientsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteAllClientsResponse.Merge(m, src)
-}
-func (m *DeleteAllClientsResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteAllClientsResponse.Size(m)
-}
-func (m *DeleteAllClientsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteAllClientsResponse.DiscardUnknown(m)
+func (m *QueryClientsResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
 }
 
-var xxx_messageInfo_DeleteAllClientsResponse proto.InternalMessageInfo
+func (m *QueryClientsResponse) GetClients() []*Client {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
 
-type NewMatchRequest struct {
-	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Score                int64    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// QueryClientsPageToken is encoded (base64) in the QueryClients page tokens,
+// which are opaque to the callers
+type QueryClientsPageToken struct {
+	Id                   string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FilterHash           uint64                         `protobuf:"fixed64,4,opt,name=filter_hash,json=filterHash,proto3" json:"filter_hash,omitempty"`
+	Values               []*QueryClientsPageToken_Value `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
 }
 
-func (m *NewMatchRequest) Reset()         { *m = NewMatchRequest{} }
-func (m *NewMatchRequest) String() string { return proto.CompactTextString(m) }
-func (*NewMatchRequest) ProtoMessage()    {}
-func (*NewMatchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{10}
+func (m *QueryClientsPageToken) Reset()         { *m = QueryClientsPageToken{} }
+func (m *QueryClientsPageToken) String() string { return proto.CompactTextString(m) }
+func (*QueryClientsPageToken) ProtoMessage()    {}
+func (*QueryClientsPageToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{7}
 }
 
-func (m *NewMatchRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewMatchRequest.Unmarshal(m, b)
+func (m *QueryClientsPageToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryClientsPageToken.Unmarshal(m, b)
 }
-func (m *NewMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewMatchRequest.Marshal(b, m, deterministic)
+func (m *QueryClientsPageToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryClientsPageToken.Marshal(b, m, deterministic)
 }
-func (m *NewMatchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewMatchRequest.Merge(m, src)
+func (m *QueryClientsPageToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryClientsPageToken.Merge(m, src)
 }
-func (m *NewMatchRequest) XXX_Size() int {
-	return xxx_messageInfo_NewMatchRequest.Size(m)
+func (m *QueryClientsPageToken) XXX_Size() int {
+	return xxx_messageInfo_QueryClientsPageToken.Size(m)
 }
-func (m *NewMatchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewMatchRequest.DiscardUnknown(m)
+func (m *QueryClientsPageToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryClientsPageToken.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewMatchRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryClientsPageToken proto.InternalMessageInfo
 
-func (m *NewMatchRequest) GetClientId() string {
+func (m *QueryClientsPageToken) GetId() string {
 	if m != nil {
-		return m.ClientId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *NewMatchRequest) GetScore() int64 {
+func (m *QueryClientsPageToken) GetFilterHash() uint64 {
 	if m != nil {
-		return m.Score
+		return m.FilterHash
 	}
 	return 0
 }
 
-type NewMatchResponse struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *QueryClientsPageToken) GetValues() []*QueryClientsPageToken_Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// a value of a sort key column: score or unixnano in int_value, name in
+// string_value
+type QueryClientsPageToken_Value struct {
+	IntValue             int64    `protobuf:"varint,1,opt,name=int_value,json=intValue,proto3" json:"int_value,omitempty"`
+	StringValue          string   `protobuf:"bytes,2,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	Null                 bool     `protobuf:"varint,3,opt,name=null,proto3" json:"null,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NewMatchResponse) Reset()         { *m = NewMatchResponse{} }
-func (m *NewMatchResponse) String() string { return proto.CompactTextString(m) }
-func (*NewMatchResponse) ProtoMessage()    {}
-func (*NewMatchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{11}
+func (m *QueryClientsPageToken_Value) Reset()         { *m = QueryClientsPageToken_Value{} }
+func (m *QueryClientsPageToken_Value) String() string { return proto.CompactTextString(m) }
+func (*QueryClientsPageToken_Value) ProtoMessage()    {}
+func (*QueryClientsPageToken_Value) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{7, 0}
 }
 
-func (m *NewMatchResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NewMatchResponse.Unmarshal(m, b)
+func (m *QueryClientsPageToken_Value) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryClientsPageToken_Value.Unmarshal(m, b)
 }
-func (m *NewMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NewMatchResponse.Marshal(b, m, deterministic)
+func (m *QueryClientsPageToken_Value) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryClientsPageToken_Value.Marshal(b, m, deterministic)
 }
-func (m *NewMatchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewMatchResponse.Merge(m, src)
+func (m *QueryClientsPageToken_Value) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryClientsPageToken_Value.Merge(m, src)
 }
-func (m *NewMatchResponse) XXX_Size() int {
-	return xxx_messageInfo_NewMatchResponse.Size(m)
+func (m *QueryClientsPageToken_Value) XXX_Size() int {
+	return xxx_messageInfo_QueryClientsPageToken_Value.Size(m)
 }
-func (m *NewMatchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewMatchResponse.DiscardUnknown(m)
+func (m *QueryClientsPageToken_Value) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryClientsPageToken_Value.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewMatchResponse proto.InternalMessageInfo
+var xxx_messageInfo_QueryClientsPageToken_Value proto.InternalMessageInfo
 
-func (m *NewMatchResponse) GetId() int64 {
+func (m *QueryClientsPageToken_Value) GetIntValue() int64 {
 	if m != nil {
-		return m.Id
+		return m.IntValue
 	}
 	return 0
 }
 
-type SortRequest struct {
-	Items                []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	RemoveDuplicates     bool     `protobuf:"varint,2,opt,name=remove_duplicates,json=removeDuplicates,proto3" json:"remove_duplicates,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *QueryClientsPageToken_Value) GetStringValue() string {
+	if m != nil {
+		return m.StringValue
+	}
+	return ""
 }
 
-func (m *SortRequest) Reset()         { *m = SortRequest{} }
-func (m *SortRequest) String() string { return proto.CompactTextString(m) }
-func (*SortRequest) ProtoMessage()    {}
-func (*SortRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{12}
-}
+func (m *QueryClientsPageToken_Value) GetNull() bool {
+	if m != nil {
+		return m.Null
+	}
+	return false
+}
 
-func (m *SortRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SortRequest.Unmarshal(m, b)
+type CountClientsRequest struct {
+	Filter               *QueryClientsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *SortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SortRequest.Marshal(b, m, deterministic)
+
+func (m *CountClientsRequest) Reset()         { *m = CountClientsRequest{} }
+func (m *CountClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*CountClientsRequest) ProtoMessage()    {}
+func (*CountClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{8}
 }
-func (m *SortRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SortRequest.Merge(m, src)
+
+func (m *CountClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountClientsRequest.Unmarshal(m, b)
 }
-func (m *SortRequest) XXX_Size() int {
-	return xxx_messageInfo_SortRequest.Size(m)
+func (m *CountClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountClientsRequest.Marshal(b, m, deterministic)
 }
-func (m *SortRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SortRequest.DiscardUnknown(m)
+func (m *CountClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountClientsRequest.Merge(m, src)
+}
+func (m *CountClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_CountClientsRequest.Size(m)
+}
+func (m *CountClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountClientsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SortRequest proto.InternalMessageInfo
+var xxx_messageInfo_CountClientsRequest proto.InternalMessageInfo
 
-func (m *SortRequest) GetItems() []string {
+func (m *CountClientsRequest) GetFilter() *QueryClientsRequest {
 	if m != nil {
-		return m.Items
+		return m.Filter
 	}
 	return nil
 }
 
-func (m *SortRequest) GetRemoveDuplicates() bool {
+type CountClientsResponse struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountClientsResponse) Reset()         { *m = CountClientsResponse{} }
+func (m *CountClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*CountClientsResponse) ProtoMessage()    {}
+func (*CountClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{9}
+}
+
+func (m *CountClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountClientsResponse.Unmarshal(m, b)
+}
+func (m *CountClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *CountClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountClientsResponse.Merge(m, src)
+}
+func (m *CountClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_CountClientsResponse.Size(m)
+}
+func (m *CountClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountClientsResponse proto.InternalMessageInfo
+
+func (m *CountClientsResponse) GetCount() int64 {
 	if m != nil {
-		return m.RemoveDuplicates
+		return m.Count
 	}
-	return false
+	return 0
 }
 
-type SortResponse struct {
-	Items                []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+type SampleClientsRequest struct {
+	Filter               *QueryClientsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	N                    int64                `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SampleClientsRequest) Reset()         { *m = SampleClientsRequest{} }
+func (m *SampleClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*SampleClientsRequest) ProtoMessage()    {}
+func (*SampleClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{10}
+}
+
+func (m *SampleClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SampleClientsRequest.Unmarshal(m, b)
+}
+func (m *SampleClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SampleClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *SampleClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SampleClientsRequest.Merge(m, src)
+}
+func (m *SampleClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_SampleClientsRequest.Size(m)
+}
+func (m *SampleClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SampleClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SampleClientsRequest proto.InternalMessageInfo
+
+func (m *SampleClientsRequest) GetFilter() *QueryClientsRequest {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *SampleClientsRequest) GetN() int64 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+// up to n ids of clients drawn at random, in random order
+type SampleClientsResponse struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SortResponse) Reset()         { *m = SortResponse{} }
-func (m *SortResponse) String() string { return proto.CompactTextString(m) }
-func (*SortResponse) ProtoMessage()    {}
-func (*SortResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b09ac349de90e68, []int{13}
+func (m *SampleClientsResponse) Reset()         { *m = SampleClientsResponse{} }
+func (m *SampleClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*SampleClientsResponse) ProtoMessage()    {}
+func (*SampleClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{11}
 }
 
-func (m *SortResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SortResponse.Unmarshal(m, b)
+func (m *SampleClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SampleClientsResponse.Unmarshal(m, b)
 }
-func (m *SortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SortResponse.Marshal(b, m, deterministic)
+func (m *SampleClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SampleClientsResponse.Marshal(b, m, deterministic)
 }
-func (m *SortResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SortResponse.Merge(m, src)
+func (m *SampleClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SampleClientsResponse.Merge(m, src)
 }
-func (m *SortResponse) XXX_Size() int {
-	return xxx_messageInfo_SortResponse.Size(m)
+func (m *SampleClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_SampleClientsResponse.Size(m)
 }
-func (m *SortResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SortResponse.DiscardUnknown(m)
+func (m *SampleClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SampleClientsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SortResponse proto.InternalMessageInfo
+var xxx_messageInfo_SampleClientsResponse proto.InternalMessageInfo
 
-func (m *SortResponse) GetItems() []string {
+func (m *SampleClientsResponse) GetIds() []string {
 	if m != nil {
-		return m.Items
+		return m.Ids
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*NewClientRequest)(nil), "pb.NewClientRequest")
-	proto.RegisterType((*NewClientResponse)(nil), "pb.NewClientResponse")
-	proto.RegisterType((*QueryClientsRequest)(nil), "pb.QueryClientsRequest")
-	proto.RegisterType((*QueryClientsResponse)(nil), "pb.QueryClientsResponse")
-	proto.RegisterType((*GetClientsRequest)(nil), "pb.GetClientsRequest")
-	proto.RegisterType((*GetClientsResponse)(nil), "pb.GetClientsResponse")
-	proto.RegisterType((*DeleteClientRequest)(nil), "pb.DeleteClientRequest")
-	proto.RegisterType((*DeleteClientResponse)(nil), "pb.DeleteClientResponse")
-	proto.RegisterType((*DeleteAllClientsRequest)(nil), "pb.DeleteAllClientsRequest")
-	proto.RegisterType((*DeleteAllClientsResponse)(nil), "pb.DeleteAllClientsResponse")
-	proto.RegisterType((*NewMatchRequest)(nil), "pb.NewMatchRequest")
-	proto.RegisterType((*NewMatchResponse)(nil), "pb.NewMatchResponse")
-	proto.RegisterType((*SortRequest)(nil), "pb.SortRequest")
-	proto.RegisterType((*SortResponse)(nil), "pb.SortResponse")
+type SuggestClientsRequest struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit  int64  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// SCORE (highest first, the default) or NAME (alphabetical)
+	OrderBy              ClientOrderBy `protobuf:"varint,3,opt,name=order_by,json=orderBy,proto3,enum=pb.ClientOrderBy" json:"order_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func init() { proto.RegisterFile("clservice.proto", fileDescriptor_1b09ac349de90e68) }
+func (m *SuggestClientsRequest) Reset()         { *m = SuggestClientsRequest{} }
+func (m *SuggestClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*SuggestClientsRequest) ProtoMessage()    {}
+func (*SuggestClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{12}
+}
 
-var fileDescriptor_1b09ac349de90e68 = []byte{
-	// 590 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0x5d, 0x6f, 0xda, 0x30,
-	0x14, 0x2d, 0x49, 0xbb, 0xc1, 0xed, 0x07, 0x60, 0xb2, 0x36, 0x4b, 0x37, 0x89, 0xa5, 0xad, 0xc4,
-	0xd4, 0x0d, 0x24, 0xba, 0x0f, 0xa9, 0xd2, 0x1e, 0x3a, 0x90, 0xa6, 0x3e, 0xac, 0xdd, 0xe0, 0x65,
-	0xda, 0x0b, 0xca, 0xc7, 0x15, 0x58, 0x0a, 0x49, 0x16, 0x1b, 0x2a, 0xfe, 0xe3, 0x5e, 0xf7, 0x7f,
-	0xa6, 0xc4, 0x4e, 0x08, 0x49, 0xde, 0xe2, 0x73, 0x8f, 0xcf, 0x3d, 0xd7, 0x3e, 0x0e, 0x34, 0x1d,
-	0x8f, 0x61, 0xb4, 0xa6, 0x0e, 0xf6, 0xc3, 0x28, 0xe0, 0x01, 0x51, 0x42, 0xdb, 0x38, 0x76, 0x3c,
-	0xbe, 0x09, 0x91, 0x09, 0xc8, 0xfc, 0x05, 0xad, 0x07, 0x7c, 0x1a, 0x79, 0x14, 0x7d, 0x3e, 0xc1,
-	0x3f, 0x2b, 0x64, 0x9c, 0x10, 0xd8, 0xf7, 0xad, 0x25, 0xea, 0xb5, 0x6e, 0xad, 0xd7, 0x98, 0x24,
-	0xdf, 0xc4, 0x80, 0xba, 0x4d, 0x23, 0xbe, 0x70, 0xad, 0x8d, 0xae, 0x74, 0x6b, 0x3d, 0x75, 0x92,
-	0xad, 0x89, 0x06, 0x07, 0xcc, 0x09, 0x22, 0xd4, 0xd5, 0xa4, 0x20, 0x16, 0xe6, 0x05, 0xb4, 0x73,
-	0xca, 0x2c, 0x0c, 0x7c, 0x86, 0xe4, 0x04, 0x14, 0xea, 0x4a, 0x61, 0x85, 0xba, 0xe6, 0xbf, 0x1a,
-	0x74, 0x7e, 0xae, 0x30, 0xda, 0x08, 0x1e, 0x4b, 0x2d, 0xbc, 0xce, 0x78, 0x87, 0xc3, 0xe3, 0x7e,
-	0x68, 0xf7, 0x1f, 0x43, 0x3e, 0xe5, 0x11, 0xf5, 0xe7, 0xf1, 0x36, 0xf2, 0x46, 0x3a, 0x54, 0xaa,
-	0x08, 0xc2, 0xf0, 0xdb, 0x9c, 0x61, 0x75, 0x4b, 0xbb, 0xf7, 0xf9, 0xa7, 0x0f, 0xa3, 0x60, 0x19,
-	0xe6, 0xfc, 0x5f, 0xa4, 0xfe, 0xf7, 0xab, 0x78, 0xa2, 0x46, 0xde, 0x01, 0x38, 0x11, 0x5a, 0x1c,
-	0xdd, 0x99, 0xc5, 0xf5, 0x83, 0x2a, 0x66, 0x43, 0x12, 0xee, 0xb8, 0xd9, 0x03, 0x6d, 0x77, 0x2c,
-	0x39, 0x7f, 0x0b, 0x54, 0xea, 0x32, 0xbd, 0xd6, 0x55, 0x7b, 0x8d, 0x49, 0xfc, 0x69, 0x5e, 0x41,
-	0xfb, 0x1b, 0xf2, 0xc2, 0xf8, 0x65, 0xda, 0x2d, 0x90, 0x3c, 0x4d, 0xca, 0x5d, 0xc2, 0x73, 0x47,
-	0x40, 0x09, 0xf7, 0x70, 0x08, 0xb1, 0x23, 0x79, 0xe6, 0x69, 0xc9, 0xbc, 0x82, 0xce, 0x18, 0x3d,
-	0xe4, 0xb8, 0x7b, 0xcd, 0xc5, 0xbb, 0x38, 0x05, 0x6d, 0x97, 0x26, 0x9a, 0x98, 0x2f, 0xe1, 0x4c,
-	0xe0, 0x77, 0x9e, 0xb7, 0xeb, 0xd3, 0x34, 0x40, 0x2f, 0x97, 0xe4, 0xb6, 0x31, 0x34, 0x1f, 0xf0,
-	0xe9, 0xbb, 0xc5, 0x9d, 0x45, 0xda, 0xf1, 0x1c, 0x1a, 0xc2, 0xd3, 0x2c, 0x6b, 0x5c, 0x17, 0xc0,
-	0xbd, 0xbb, 0x4d, 0x91, 0x92, 0x4f, 0x91, 0x99, 0xe4, 0x53, 0xaa, 0x94, 0x42, 0xa4, 0x26, 0xc6,
-	0x7f, 0xc0, 0xe1, 0x34, 0x88, 0xb2, 0xb9, 0x34, 0x38, 0xa0, 0x1c, 0x97, 0xe9, 0xf1, 0x89, 0x05,
-	0xb9, 0x86, 0x76, 0x84, 0xcb, 0x60, 0x8d, 0x33, 0x77, 0x15, 0x7a, 0xd4, 0xb1, 0x38, 0xb2, 0xa4,
-	0x55, 0x7d, 0xd2, 0x12, 0x85, 0x71, 0x86, 0x9b, 0x97, 0x70, 0x24, 0x14, 0x65, 0xc7, 0x4a, 0xc9,
-	0xe1, 0x5f, 0x15, 0x4e, 0xe4, 0xd4, 0x53, 0xf1, 0xce, 0xc8, 0x2d, 0x34, 0xb2, 0xd0, 0x13, 0x2d,
-	0xbe, 0x8c, 0xe2, 0xeb, 0x32, 0x5e, 0x14, 0x50, 0x79, 0x5c, 0x7b, 0x64, 0x04, 0x47, 0xf9, 0xcc,
-	0x90, 0xb3, 0x98, 0x58, 0xf1, 0x38, 0x0c, 0xbd, 0x5c, 0xc8, 0x44, 0xbe, 0x00, 0x6c, 0x73, 0x42,
-	0x92, 0x5e, 0xa5, 0x78, 0x19, 0xa7, 0x45, 0x38, 0xef, 0x21, 0x9f, 0x01, 0xe1, 0xa1, 0x22, 0x3c,
-	0xc2, 0x43, 0x65, 0x5c, 0xf6, 0xc8, 0x23, 0xb4, 0x8a, 0xa9, 0x20, 0xe7, 0x5b, 0x7e, 0x29, 0x46,
-	0xc6, 0xab, 0xea, 0x62, 0x26, 0xf8, 0x19, 0xea, 0x69, 0x08, 0x48, 0x47, 0x1e, 0x5f, 0x3e, 0x58,
-	0x86, 0xb6, 0x0b, 0x66, 0x1b, 0xaf, 0x61, 0x3f, 0xbe, 0x47, 0xd2, 0x8c, 0xeb, 0xb9, 0x8c, 0x18,
-	0xad, 0x2d, 0x90, 0x92, 0xbf, 0x7e, 0xfc, 0x7d, 0x33, 0xa7, 0x7c, 0xb1, 0xb2, 0xfb, 0x4e, 0xb0,
-	0x1c, 0x84, 0xe8, 0x52, 0x37, 0x08, 0xad, 0x79, 0x30, 0xe0, 0x91, 0x45, 0x7d, 0xea, 0xcf, 0xd9,
-	0xda, 0x79, 0x2f, 0xdf, 0xd4, 0x20, 0xf9, 0x7b, 0xb2, 0x41, 0x68, 0xdb, 0xcf, 0x92, 0xcf, 0x9b,
-	0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x38, 0x25, 0x76, 0xd1, 0x6e, 0x05, 0x00, 0x00,
+func (m *SuggestClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuggestClientsRequest.Unmarshal(m, b)
+}
+func (m *SuggestClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuggestClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *SuggestClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestClientsRequest.Merge(m, src)
+}
+func (m *SuggestClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_SuggestClientsRequest.Size(m)
+}
+func (m *SuggestClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestClientsRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_SuggestClientsRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SuggestClientsRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
 
-// ClientsServiceClient is the client API for ClientsService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ClientsServiceClient interface {
-	NewClient(ctx context.Context, in *NewClientRequest, opts ...grpc.CallOption) (*NewClientResponse, error)
-	QueryClients(ctx context.Context, in *QueryClientsRequest, opts ...grpc.CallOption) (*QueryClientsResponse, error)
-	GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*GetClientsResponse, error)
-	DeleteClient(ctx context.Context, in *DeleteClientRequest, opts ...grpc.CallOption) (*DeleteClientResponse, error)
-	DeleteAllClients(ctx context.Context, in *DeleteAllClientsRequest, opts ...grpc.CallOption) (*DeleteAllClientsResponse, error)
-	NewMatch(ctx context.Context, in *NewMatchRequest, opts ...grpc.CallOption) (*NewMatchResponse, error)
-	Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error)
+func (m *SuggestClientsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-type clientsServiceClient struct {
-	cc *grpc.ClientConn
+func (m *SuggestClientsRequest) GetOrderBy() ClientOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ClientOrderBy_SCORE
 }
 
-func NewClientsServiceClient(cc *grpc.ClientConn) ClientsServiceClient {
-	return &clientsServiceClient{cc}
+type ClientSuggestion struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *clientsServiceClient) NewClient(ctx context.Context, in *NewClientRequest, opts ...grpc.CallOption) (*NewClientResponse, error) {
-	out := new(NewClientResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewClient", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientSuggestion) Reset()         { *m = ClientSuggestion{} }
+func (m *ClientSuggestion) String() string { return proto.CompactTextString(m) }
+func (*ClientSuggestion) ProtoMessage()    {}
+func (*ClientSuggestion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{13}
+}
+
+func (m *ClientSuggestion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientSuggestion.Unmarshal(m, b)
+}
+func (m *ClientSuggestion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientSuggestion.Marshal(b, m, deterministic)
+}
+func (m *ClientSuggestion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientSuggestion.Merge(m, src)
+}
+func (m *ClientSuggestion) XXX_Size() int {
+	return xxx_messageInfo_ClientSuggestion.Size(m)
+}
+func (m *ClientSuggestion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientSuggestion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientSuggestion proto.InternalMessageInfo
+
+func (m *ClientSuggestion) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return ""
 }
 
-func (c *clientsServiceClient) QueryClients(ctx context.Context, in *QueryClientsRequest, opts ...grpc.CallOption) (*QueryClientsResponse, error) {
-	out := new(QueryClientsResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/QueryClients", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ClientSuggestion) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *clientsServiceClient) GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*GetClientsResponse, error) {
-	out := new(GetClientsResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClients", in, out, opts...)
-	if err != nil {
-		return nil, err
+type SuggestClientsResponse struct {
+	Suggestions          []*ClientSuggestion `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SuggestClientsResponse) Reset()         { *m = SuggestClientsResponse{} }
+func (m *SuggestClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*SuggestClientsResponse) ProtoMessage()    {}
+func (*SuggestClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{14}
+}
+
+func (m *SuggestClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuggestClientsResponse.Unmarshal(m, b)
+}
+func (m *SuggestClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuggestClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *SuggestClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestClientsResponse.Merge(m, src)
+}
+func (m *SuggestClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_SuggestClientsResponse.Size(m)
+}
+func (m *SuggestClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuggestClientsResponse proto.InternalMessageInfo
+
+func (m *SuggestClientsResponse) GetSuggestions() []*ClientSuggestion {
+	if m != nil {
+		return m.Suggestions
 	}
-	return out, nil
+	return nil
 }
 
-func (c *clientsServiceClient) DeleteClient(ctx context.Context, in *DeleteClientRequest, opts ...grpc.CallOption) (*DeleteClientResponse, error) {
-	out := new(DeleteClientResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteClient", in, out, opts...)
-	if err != nil {
-		return nil, err
+type GetClientsRequest struct {
+	Ids            []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	IncludeDeleted bool     `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Client fields to read, all of them when unset; id is always read. The
+	// fields left out are not populated: they keep their zero values
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// fails with NotFound (naming the missing ids) unless every id is found
+	RequireAll           bool     `protobuf:"varint,4,opt,name=require_all,json=requireAll,proto3" json:"require_all,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientsRequest) Reset()         { *m = GetClientsRequest{} }
+func (m *GetClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientsRequest) ProtoMessage()    {}
+func (*GetClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{15}
+}
+
+func (m *GetClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsRequest.Unmarshal(m, b)
+}
+func (m *GetClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsRequest.Merge(m, src)
+}
+func (m *GetClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientsRequest.Size(m)
+}
+func (m *GetClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsRequest proto.InternalMessageInfo
+
+func (m *GetClientsRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
 	}
-	return out, nil
+	return nil
 }
 
-func (c *clientsServiceClient) DeleteAllClients(ctx context.Context, in *DeleteAllClientsRequest, opts ...grpc.CallOption) (*DeleteAllClientsResponse, error) {
-	out := new(DeleteAllClientsResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteAllClients", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetClientsRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *GetClientsRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+func (m *GetClientsRequest) GetRequireAll() bool {
+	if m != nil {
+		return m.RequireAll
+	}
+	return false
+}
+
+type GetClientsResponse struct {
+	// in the order of the requested ids, each client once; missing ids are left out
+	Clients []*Client `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	// the requested ids without a client, each once, in the order requested
+	MissingIds           []string `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientsResponse) Reset()         { *m = GetClientsResponse{} }
+func (m *GetClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientsResponse) ProtoMessage()    {}
+func (*GetClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{16}
+}
+
+func (m *GetClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsResponse.Unmarshal(m, b)
+}
+func (m *GetClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsResponse.Merge(m, src)
+}
+func (m *GetClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientsResponse.Size(m)
+}
+func (m *GetClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsResponse proto.InternalMessageInfo
+
+func (m *GetClientsResponse) GetClients() []*Client {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+func (m *GetClientsResponse) GetMissingIds() []string {
+	if m != nil {
+		return m.MissingIds
+	}
+	return nil
+}
+
+type GetClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientRequest) Reset()         { *m = GetClientRequest{} }
+func (m *GetClientRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientRequest) ProtoMessage()    {}
+func (*GetClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{17}
+}
+
+func (m *GetClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientRequest.Unmarshal(m, b)
+}
+func (m *GetClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientRequest.Merge(m, src)
+}
+func (m *GetClientRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientRequest.Size(m)
+}
+func (m *GetClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientRequest proto.InternalMessageInfo
+
+func (m *GetClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetClientResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientResponse) Reset()         { *m = GetClientResponse{} }
+func (m *GetClientResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientResponse) ProtoMessage()    {}
+func (*GetClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{18}
+}
+
+func (m *GetClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientResponse.Unmarshal(m, b)
+}
+func (m *GetClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientResponse.Merge(m, src)
+}
+func (m *GetClientResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientResponse.Size(m)
+}
+func (m *GetClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientResponse proto.InternalMessageInfo
+
+func (m *GetClientResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type ClientExistsRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientExistsRequest) Reset()         { *m = ClientExistsRequest{} }
+func (m *ClientExistsRequest) String() string { return proto.CompactTextString(m) }
+func (*ClientExistsRequest) ProtoMessage()    {}
+func (*ClientExistsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{19}
+}
+
+func (m *ClientExistsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientExistsRequest.Unmarshal(m, b)
+}
+func (m *ClientExistsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientExistsRequest.Marshal(b, m, deterministic)
+}
+func (m *ClientExistsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientExistsRequest.Merge(m, src)
+}
+func (m *ClientExistsRequest) XXX_Size() int {
+	return xxx_messageInfo_ClientExistsRequest.Size(m)
+}
+func (m *ClientExistsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientExistsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientExistsRequest proto.InternalMessageInfo
+
+func (m *ClientExistsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ClientExistsResponse struct {
+	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientExistsResponse) Reset()         { *m = ClientExistsResponse{} }
+func (m *ClientExistsResponse) String() string { return proto.CompactTextString(m) }
+func (*ClientExistsResponse) ProtoMessage()    {}
+func (*ClientExistsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{20}
+}
+
+func (m *ClientExistsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientExistsResponse.Unmarshal(m, b)
+}
+func (m *ClientExistsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientExistsResponse.Marshal(b, m, deterministic)
+}
+func (m *ClientExistsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientExistsResponse.Merge(m, src)
+}
+func (m *ClientExistsResponse) XXX_Size() int {
+	return xxx_messageInfo_ClientExistsResponse.Size(m)
+}
+func (m *ClientExistsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientExistsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientExistsResponse proto.InternalMessageInfo
+
+func (m *ClientExistsResponse) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+type ClientsExistRequest struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientsExistRequest) Reset()         { *m = ClientsExistRequest{} }
+func (m *ClientsExistRequest) String() string { return proto.CompactTextString(m) }
+func (*ClientsExistRequest) ProtoMessage()    {}
+func (*ClientsExistRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{21}
+}
+
+func (m *ClientsExistRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientsExistRequest.Unmarshal(m, b)
+}
+func (m *ClientsExistRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientsExistRequest.Marshal(b, m, deterministic)
+}
+func (m *ClientsExistRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientsExistRequest.Merge(m, src)
+}
+func (m *ClientsExistRequest) XXX_Size() int {
+	return xxx_messageInfo_ClientsExistRequest.Size(m)
+}
+func (m *ClientsExistRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientsExistRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientsExistRequest proto.InternalMessageInfo
+
+func (m *ClientsExistRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type ClientsExistResponse struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientsExistResponse) Reset()         { *m = ClientsExistResponse{} }
+func (m *ClientsExistResponse) String() string { return proto.CompactTextString(m) }
+func (*ClientsExistResponse) ProtoMessage()    {}
+func (*ClientsExistResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{22}
+}
+
+func (m *ClientsExistResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientsExistResponse.Unmarshal(m, b)
+}
+func (m *ClientsExistResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientsExistResponse.Marshal(b, m, deterministic)
+}
+func (m *ClientsExistResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientsExistResponse.Merge(m, src)
+}
+func (m *ClientsExistResponse) XXX_Size() int {
+	return xxx_messageInfo_ClientsExistResponse.Size(m)
+}
+func (m *ClientsExistResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientsExistResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientsExistResponse proto.InternalMessageInfo
+
+func (m *ClientsExistResponse) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type GetClientByEmailRequest struct {
+	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientByEmailRequest) Reset()         { *m = GetClientByEmailRequest{} }
+func (m *GetClientByEmailRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientByEmailRequest) ProtoMessage()    {}
+func (*GetClientByEmailRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{23}
+}
+
+func (m *GetClientByEmailRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientByEmailRequest.Unmarshal(m, b)
+}
+func (m *GetClientByEmailRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientByEmailRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientByEmailRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientByEmailRequest.Merge(m, src)
+}
+func (m *GetClientByEmailRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientByEmailRequest.Size(m)
+}
+func (m *GetClientByEmailRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientByEmailRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientByEmailRequest proto.InternalMessageInfo
+
+func (m *GetClientByEmailRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type GetClientByEmailResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientByEmailResponse) Reset()         { *m = GetClientByEmailResponse{} }
+func (m *GetClientByEmailResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientByEmailResponse) ProtoMessage()    {}
+func (*GetClientByEmailResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{24}
+}
+
+func (m *GetClientByEmailResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientByEmailResponse.Unmarshal(m, b)
+}
+func (m *GetClientByEmailResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientByEmailResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientByEmailResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientByEmailResponse.Merge(m, src)
+}
+func (m *GetClientByEmailResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientByEmailResponse.Size(m)
+}
+func (m *GetClientByEmailResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientByEmailResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientByEmailResponse proto.InternalMessageInfo
+
+func (m *GetClientByEmailResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type GetClientByExternalIdRequest struct {
+	ExternalId           string   `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientByExternalIdRequest) Reset()         { *m = GetClientByExternalIdRequest{} }
+func (m *GetClientByExternalIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientByExternalIdRequest) ProtoMessage()    {}
+func (*GetClientByExternalIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{25}
+}
+
+func (m *GetClientByExternalIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientByExternalIdRequest.Unmarshal(m, b)
+}
+func (m *GetClientByExternalIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientByExternalIdRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientByExternalIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientByExternalIdRequest.Merge(m, src)
+}
+func (m *GetClientByExternalIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientByExternalIdRequest.Size(m)
+}
+func (m *GetClientByExternalIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientByExternalIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientByExternalIdRequest proto.InternalMessageInfo
+
+func (m *GetClientByExternalIdRequest) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+type GetClientByExternalIdResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientByExternalIdResponse) Reset()         { *m = GetClientByExternalIdResponse{} }
+func (m *GetClientByExternalIdResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientByExternalIdResponse) ProtoMessage()    {}
+func (*GetClientByExternalIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{26}
+}
+
+func (m *GetClientByExternalIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientByExternalIdResponse.Unmarshal(m, b)
+}
+func (m *GetClientByExternalIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientByExternalIdResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientByExternalIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientByExternalIdResponse.Merge(m, src)
+}
+func (m *GetClientByExternalIdResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientByExternalIdResponse.Size(m)
+}
+func (m *GetClientByExternalIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientByExternalIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientByExternalIdResponse proto.InternalMessageInfo
+
+func (m *GetClientByExternalIdResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type TouchClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TouchClientRequest) Reset()         { *m = TouchClientRequest{} }
+func (m *TouchClientRequest) String() string { return proto.CompactTextString(m) }
+func (*TouchClientRequest) ProtoMessage()    {}
+func (*TouchClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{27}
+}
+
+func (m *TouchClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TouchClientRequest.Unmarshal(m, b)
+}
+func (m *TouchClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TouchClientRequest.Marshal(b, m, deterministic)
+}
+func (m *TouchClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TouchClientRequest.Merge(m, src)
+}
+func (m *TouchClientRequest) XXX_Size() int {
+	return xxx_messageInfo_TouchClientRequest.Size(m)
+}
+func (m *TouchClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TouchClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TouchClientRequest proto.InternalMessageInfo
+
+func (m *TouchClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type TouchClientResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TouchClientResponse) Reset()         { *m = TouchClientResponse{} }
+func (m *TouchClientResponse) String() string { return proto.CompactTextString(m) }
+func (*TouchClientResponse) ProtoMessage()    {}
+func (*TouchClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{28}
+}
+
+func (m *TouchClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TouchClientResponse.Unmarshal(m, b)
+}
+func (m *TouchClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TouchClientResponse.Marshal(b, m, deterministic)
+}
+func (m *TouchClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TouchClientResponse.Merge(m, src)
+}
+func (m *TouchClientResponse) XXX_Size() int {
+	return xxx_messageInfo_TouchClientResponse.Size(m)
+}
+func (m *TouchClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TouchClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TouchClientResponse proto.InternalMessageInfo
+
+type UpdateClientRequest struct {
+	Id       string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     *OptString    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Birthday *OptInt64     `protobuf:"bytes,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Score    *OptInt64     `protobuf:"bytes,4,opt,name=score,proto3" json:"score,omitempty"`
+	Email    *OptString    `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone    *OptString    `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	Metadata *OptStringMap `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// alternative to the fields above: the paths in update_mask are set from client.
+	// Empty values clear birthday, email, phone, metadata and notes; score is set as given.
+	Client     *Client               `protobuf:"bytes,8,opt,name=client,proto3" json:"client,omitempty"`
+	UpdateMask *field_mask.FieldMask `protobuf:"bytes,9,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// if set, the update fails with ABORTED unless the client is at this version
+	ExpectedVersion      int64      `protobuf:"varint,10,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Notes                *OptString `protobuf:"bytes,11,opt,name=notes,proto3" json:"notes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateClientRequest) Reset()         { *m = UpdateClientRequest{} }
+func (m *UpdateClientRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateClientRequest) ProtoMessage()    {}
+func (*UpdateClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{29}
+}
+
+func (m *UpdateClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateClientRequest.Unmarshal(m, b)
+}
+func (m *UpdateClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateClientRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateClientRequest.Merge(m, src)
+}
+func (m *UpdateClientRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateClientRequest.Size(m)
+}
+func (m *UpdateClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateClientRequest proto.InternalMessageInfo
+
+func (m *UpdateClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateClientRequest) GetName() *OptString {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetBirthday() *OptInt64 {
+	if m != nil {
+		return m.Birthday
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetScore() *OptInt64 {
+	if m != nil {
+		return m.Score
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetEmail() *OptString {
+	if m != nil {
+		return m.Email
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetPhone() *OptString {
+	if m != nil {
+		return m.Phone
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetMetadata() *OptStringMap {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdateClientRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *UpdateClientRequest) GetNotes() *OptString {
+	if m != nil {
+		return m.Notes
+	}
+	return nil
+}
+
+type UpdateClientResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateClientResponse) Reset()         { *m = UpdateClientResponse{} }
+func (m *UpdateClientResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateClientResponse) ProtoMessage()    {}
+func (*UpdateClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{30}
+}
+
+func (m *UpdateClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateClientResponse.Unmarshal(m, b)
+}
+func (m *UpdateClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateClientResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateClientResponse.Merge(m, src)
+}
+func (m *UpdateClientResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateClientResponse.Size(m)
+}
+func (m *UpdateClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateClientResponse proto.InternalMessageInfo
+
+func (m *UpdateClientResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+// the unique email and external_id aren't copied
+type CloneClientRequest struct {
+	SourceId             string     `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Name                 *OptString `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CopyScore            bool       `protobuf:"varint,3,opt,name=copy_score,json=copyScore,proto3" json:"copy_score,omitempty"`
+	CopyMatches          bool       `protobuf:"varint,4,opt,name=copy_matches,json=copyMatches,proto3" json:"copy_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CloneClientRequest) Reset()         { *m = CloneClientRequest{} }
+func (m *CloneClientRequest) String() string { return proto.CompactTextString(m) }
+func (*CloneClientRequest) ProtoMessage()    {}
+func (*CloneClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{31}
+}
+
+func (m *CloneClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneClientRequest.Unmarshal(m, b)
+}
+func (m *CloneClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneClientRequest.Marshal(b, m, deterministic)
+}
+func (m *CloneClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneClientRequest.Merge(m, src)
+}
+func (m *CloneClientRequest) XXX_Size() int {
+	return xxx_messageInfo_CloneClientRequest.Size(m)
+}
+func (m *CloneClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneClientRequest proto.InternalMessageInfo
+
+func (m *CloneClientRequest) GetSourceId() string {
+	if m != nil {
+		return m.SourceId
+	}
+	return ""
+}
+
+func (m *CloneClientRequest) GetName() *OptString {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *CloneClientRequest) GetCopyScore() bool {
+	if m != nil {
+		return m.CopyScore
+	}
+	return false
+}
+
+func (m *CloneClientRequest) GetCopyMatches() bool {
+	if m != nil {
+		return m.CopyMatches
+	}
+	return false
+}
+
+type CloneClientResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloneClientResponse) Reset()         { *m = CloneClientResponse{} }
+func (m *CloneClientResponse) String() string { return proto.CompactTextString(m) }
+func (*CloneClientResponse) ProtoMessage()    {}
+func (*CloneClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{32}
+}
+
+func (m *CloneClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneClientResponse.Unmarshal(m, b)
+}
+func (m *CloneClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneClientResponse.Marshal(b, m, deterministic)
+}
+func (m *CloneClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneClientResponse.Merge(m, src)
+}
+func (m *CloneClientResponse) XXX_Size() int {
+	return xxx_messageInfo_CloneClientResponse.Size(m)
+}
+func (m *CloneClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneClientResponse proto.InternalMessageInfo
+
+func (m *CloneClientResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type UpsertClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Birthday             int64    `protobuf:"varint,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Score                int64    `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpsertClientRequest) Reset()         { *m = UpsertClientRequest{} }
+func (m *UpsertClientRequest) String() string { return proto.CompactTextString(m) }
+func (*UpsertClientRequest) ProtoMessage()    {}
+func (*UpsertClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{33}
+}
+
+func (m *UpsertClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertClientRequest.Unmarshal(m, b)
+}
+func (m *UpsertClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertClientRequest.Marshal(b, m, deterministic)
+}
+func (m *UpsertClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertClientRequest.Merge(m, src)
+}
+func (m *UpsertClientRequest) XXX_Size() int {
+	return xxx_messageInfo_UpsertClientRequest.Size(m)
+}
+func (m *UpsertClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertClientRequest proto.InternalMessageInfo
+
+func (m *UpsertClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpsertClientRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpsertClientRequest) GetBirthday() int64 {
+	if m != nil {
+		return m.Birthday
+	}
+	return 0
+}
+
+func (m *UpsertClientRequest) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type UpsertClientResponse struct {
+	Created              bool     `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpsertClientResponse) Reset()         { *m = UpsertClientResponse{} }
+func (m *UpsertClientResponse) String() string { return proto.CompactTextString(m) }
+func (*UpsertClientResponse) ProtoMessage()    {}
+func (*UpsertClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{34}
+}
+
+func (m *UpsertClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpsertClientResponse.Unmarshal(m, b)
+}
+func (m *UpsertClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpsertClientResponse.Marshal(b, m, deterministic)
+}
+func (m *UpsertClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpsertClientResponse.Merge(m, src)
+}
+func (m *UpsertClientResponse) XXX_Size() int {
+	return xxx_messageInfo_UpsertClientResponse.Size(m)
+}
+func (m *UpsertClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpsertClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpsertClientResponse proto.InternalMessageInfo
+
+func (m *UpsertClientResponse) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+type DeleteClientRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// permanently removes the row, and the client matches, instead of soft
+	// deleting it
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	FailIfMatches        bool     `protobuf:"varint,3,opt,name=fail_if_matches,json=failIfMatches,proto3" json:"fail_if_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteClientRequest) Reset()         { *m = DeleteClientRequest{} }
+func (m *DeleteClientRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientRequest) ProtoMessage()    {}
+func (*DeleteClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{35}
+}
+
+func (m *DeleteClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientRequest.Unmarshal(m, b)
+}
+func (m *DeleteClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientRequest.Merge(m, src)
+}
+func (m *DeleteClientRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientRequest.Size(m)
+}
+func (m *DeleteClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientRequest proto.InternalMessageInfo
+
+func (m *DeleteClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteClientRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *DeleteClientRequest) GetFailIfMatches() bool {
+	if m != nil {
+		return m.FailIfMatches
+	}
+	return false
+}
+
+type DeleteClientResponse struct {
+	DeletedMatches       int64    `protobuf:"varint,1,opt,name=deleted_matches,json=deletedMatches,proto3" json:"deleted_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteClientResponse) Reset()         { *m = DeleteClientResponse{} }
+func (m *DeleteClientResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientResponse) ProtoMessage()    {}
+func (*DeleteClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{36}
+}
+
+func (m *DeleteClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientResponse.Unmarshal(m, b)
+}
+func (m *DeleteClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientResponse.Merge(m, src)
+}
+func (m *DeleteClientResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientResponse.Size(m)
+}
+func (m *DeleteClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientResponse proto.InternalMessageInfo
+
+func (m *DeleteClientResponse) GetDeletedMatches() int64 {
+	if m != nil {
+		return m.DeletedMatches
+	}
+	return 0
+}
+
+type DeleteClientsRequest struct {
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteClientsRequest) Reset()         { *m = DeleteClientsRequest{} }
+func (m *DeleteClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientsRequest) ProtoMessage()    {}
+func (*DeleteClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{37}
+}
+
+func (m *DeleteClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientsRequest.Unmarshal(m, b)
+}
+func (m *DeleteClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientsRequest.Merge(m, src)
+}
+func (m *DeleteClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientsRequest.Size(m)
+}
+func (m *DeleteClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientsRequest proto.InternalMessageInfo
+
+func (m *DeleteClientsRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *DeleteClientsRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type DeleteClientsResponse struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	NotFoundIds          []string `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteClientsResponse) Reset()         { *m = DeleteClientsResponse{} }
+func (m *DeleteClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientsResponse) ProtoMessage()    {}
+func (*DeleteClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{38}
+}
+
+func (m *DeleteClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientsResponse.Unmarshal(m, b)
+}
+func (m *DeleteClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientsResponse.Merge(m, src)
+}
+func (m *DeleteClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientsResponse.Size(m)
+}
+func (m *DeleteClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientsResponse proto.InternalMessageInfo
+
+func (m *DeleteClientsResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func (m *DeleteClientsResponse) GetNotFoundIds() []string {
+	if m != nil {
+		return m.NotFoundIds
+	}
+	return nil
+}
+
+type DeleteClientsByQueryRequest struct {
+	Filter               *QueryClientsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	DryRun               bool                 `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Force                bool                 `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeleteClientsByQueryRequest) Reset()         { *m = DeleteClientsByQueryRequest{} }
+func (m *DeleteClientsByQueryRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientsByQueryRequest) ProtoMessage()    {}
+func (*DeleteClientsByQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{39}
+}
+
+func (m *DeleteClientsByQueryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientsByQueryRequest.Unmarshal(m, b)
+}
+func (m *DeleteClientsByQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientsByQueryRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientsByQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientsByQueryRequest.Merge(m, src)
+}
+func (m *DeleteClientsByQueryRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientsByQueryRequest.Size(m)
+}
+func (m *DeleteClientsByQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientsByQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientsByQueryRequest proto.InternalMessageInfo
+
+func (m *DeleteClientsByQueryRequest) GetFilter() *QueryClientsRequest {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *DeleteClientsByQueryRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *DeleteClientsByQueryRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type DeleteClientsByQueryResponse struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteClientsByQueryResponse) Reset()         { *m = DeleteClientsByQueryResponse{} }
+func (m *DeleteClientsByQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteClientsByQueryResponse) ProtoMessage()    {}
+func (*DeleteClientsByQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{40}
+}
+
+func (m *DeleteClientsByQueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteClientsByQueryResponse.Unmarshal(m, b)
+}
+func (m *DeleteClientsByQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteClientsByQueryResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteClientsByQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteClientsByQueryResponse.Merge(m, src)
+}
+func (m *DeleteClientsByQueryResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteClientsByQueryResponse.Size(m)
+}
+func (m *DeleteClientsByQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteClientsByQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteClientsByQueryResponse proto.InternalMessageInfo
+
+func (m *DeleteClientsByQueryResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type RestoreClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreClientRequest) Reset()         { *m = RestoreClientRequest{} }
+func (m *RestoreClientRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreClientRequest) ProtoMessage()    {}
+func (*RestoreClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{41}
+}
+
+func (m *RestoreClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreClientRequest.Unmarshal(m, b)
+}
+func (m *RestoreClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreClientRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreClientRequest.Merge(m, src)
+}
+func (m *RestoreClientRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreClientRequest.Size(m)
+}
+func (m *RestoreClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreClientRequest proto.InternalMessageInfo
+
+func (m *RestoreClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RestoreClientResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreClientResponse) Reset()         { *m = RestoreClientResponse{} }
+func (m *RestoreClientResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreClientResponse) ProtoMessage()    {}
+func (*RestoreClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{42}
+}
+
+func (m *RestoreClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreClientResponse.Unmarshal(m, b)
+}
+func (m *RestoreClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreClientResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreClientResponse.Merge(m, src)
+}
+func (m *RestoreClientResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreClientResponse.Size(m)
+}
+func (m *RestoreClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreClientResponse proto.InternalMessageInfo
+
+type GetArchivedClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetArchivedClientRequest) Reset()         { *m = GetArchivedClientRequest{} }
+func (m *GetArchivedClientRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArchivedClientRequest) ProtoMessage()    {}
+func (*GetArchivedClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{43}
+}
+
+func (m *GetArchivedClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArchivedClientRequest.Unmarshal(m, b)
+}
+func (m *GetArchivedClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArchivedClientRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArchivedClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArchivedClientRequest.Merge(m, src)
+}
+func (m *GetArchivedClientRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArchivedClientRequest.Size(m)
+}
+func (m *GetArchivedClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArchivedClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArchivedClientRequest proto.InternalMessageInfo
+
+func (m *GetArchivedClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetArchivedClientResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	DeletedAt            int64    `protobuf:"varint,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetArchivedClientResponse) Reset()         { *m = GetArchivedClientResponse{} }
+func (m *GetArchivedClientResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArchivedClientResponse) ProtoMessage()    {}
+func (*GetArchivedClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{44}
+}
+
+func (m *GetArchivedClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArchivedClientResponse.Unmarshal(m, b)
+}
+func (m *GetArchivedClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArchivedClientResponse.Marshal(b, m, deterministic)
+}
+func (m *GetArchivedClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArchivedClientResponse.Merge(m, src)
+}
+func (m *GetArchivedClientResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArchivedClientResponse.Size(m)
+}
+func (m *GetArchivedClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArchivedClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArchivedClientResponse proto.InternalMessageInfo
+
+func (m *GetArchivedClientResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *GetArchivedClientResponse) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return 0
+}
+
+type PurgeDeletedClientsRequest struct {
+	OlderThan            int64    `protobuf:"varint,1,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeDeletedClientsRequest) Reset()         { *m = PurgeDeletedClientsRequest{} }
+func (m *PurgeDeletedClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeDeletedClientsRequest) ProtoMessage()    {}
+func (*PurgeDeletedClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{45}
+}
+
+func (m *PurgeDeletedClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeDeletedClientsRequest.Unmarshal(m, b)
+}
+func (m *PurgeDeletedClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeDeletedClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeDeletedClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeDeletedClientsRequest.Merge(m, src)
+}
+func (m *PurgeDeletedClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeDeletedClientsRequest.Size(m)
+}
+func (m *PurgeDeletedClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeDeletedClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeDeletedClientsRequest proto.InternalMessageInfo
+
+func (m *PurgeDeletedClientsRequest) GetOlderThan() int64 {
+	if m != nil {
+		return m.OlderThan
+	}
+	return 0
+}
+
+func (m *PurgeDeletedClientsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PurgeDeletedClientsResponse struct {
+	Purged               int64    `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeDeletedClientsResponse) Reset()         { *m = PurgeDeletedClientsResponse{} }
+func (m *PurgeDeletedClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeDeletedClientsResponse) ProtoMessage()    {}
+func (*PurgeDeletedClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{46}
+}
+
+func (m *PurgeDeletedClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeDeletedClientsResponse.Unmarshal(m, b)
+}
+func (m *PurgeDeletedClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeDeletedClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeDeletedClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeDeletedClientsResponse.Merge(m, src)
+}
+func (m *PurgeDeletedClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeDeletedClientsResponse.Size(m)
+}
+func (m *PurgeDeletedClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeDeletedClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeDeletedClientsResponse proto.InternalMessageInfo
+
+func (m *PurgeDeletedClientsResponse) GetPurged() int64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
+
+type DeleteAllClientsRequest struct {
+	// must be the current number of clients (as returned by a dry run)
+	ConfirmCount         int64    `protobuf:"varint,1,opt,name=confirm_count,json=confirmCount,proto3" json:"confirm_count,omitempty"`
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	FailIfMatches        bool     `protobuf:"varint,3,opt,name=fail_if_matches,json=failIfMatches,proto3" json:"fail_if_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAllClientsRequest) Reset()         { *m = DeleteAllClientsRequest{} }
+func (m *DeleteAllClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAllClientsRequest) ProtoMessage()    {}
+func (*DeleteAllClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{47}
+}
+
+func (m *DeleteAllClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAllClientsRequest.Unmarshal(m, b)
+}
+func (m *DeleteAllClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAllClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteAllClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAllClientsRequest.Merge(m, src)
+}
+func (m *DeleteAllClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAllClientsRequest.Size(m)
+}
+func (m *DeleteAllClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAllClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAllClientsRequest proto.InternalMessageInfo
+
+func (m *DeleteAllClientsRequest) GetConfirmCount() int64 {
+	if m != nil {
+		return m.ConfirmCount
+	}
+	return 0
+}
+
+func (m *DeleteAllClientsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *DeleteAllClientsRequest) GetFailIfMatches() bool {
+	if m != nil {
+		return m.FailIfMatches
+	}
+	return false
+}
+
+type DeleteAllClientsResponse struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	DeletedMatches       int64    `protobuf:"varint,2,opt,name=deleted_matches,json=deletedMatches,proto3" json:"deleted_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAllClientsResponse) Reset()         { *m = DeleteAllClientsResponse{} }
+func (m *DeleteAllClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteAllClientsResponse) ProtoMessage()    {}
+func (*DeleteAllClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{48}
+}
+
+func (m *DeleteAllClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAllClientsResponse.Unmarshal(m, b)
+}
+func (m *DeleteAllClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAllClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteAllClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAllClientsResponse.Merge(m, src)
+}
+func (m *DeleteAllClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteAllClientsResponse.Size(m)
+}
+func (m *DeleteAllClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAllClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAllClientsResponse proto.InternalMessageInfo
+
+func (m *DeleteAllClientsResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+func (m *DeleteAllClientsResponse) GetDeletedMatches() int64 {
+	if m != nil {
+		return m.DeletedMatches
+	}
+	return 0
+}
+
+type AddClientTagsRequest struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Tags                 []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddClientTagsRequest) Reset()         { *m = AddClientTagsRequest{} }
+func (m *AddClientTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*AddClientTagsRequest) ProtoMessage()    {}
+func (*AddClientTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{49}
+}
+
+func (m *AddClientTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddClientTagsRequest.Unmarshal(m, b)
+}
+func (m *AddClientTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddClientTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *AddClientTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddClientTagsRequest.Merge(m, src)
+}
+func (m *AddClientTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_AddClientTagsRequest.Size(m)
+}
+func (m *AddClientTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddClientTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddClientTagsRequest proto.InternalMessageInfo
+
+func (m *AddClientTagsRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *AddClientTagsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type AddClientTagsResponse struct {
+	Tags                 []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddClientTagsResponse) Reset()         { *m = AddClientTagsResponse{} }
+func (m *AddClientTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*AddClientTagsResponse) ProtoMessage()    {}
+func (*AddClientTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{50}
+}
+
+func (m *AddClientTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddClientTagsResponse.Unmarshal(m, b)
+}
+func (m *AddClientTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddClientTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *AddClientTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddClientTagsResponse.Merge(m, src)
+}
+func (m *AddClientTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_AddClientTagsResponse.Size(m)
+}
+func (m *AddClientTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddClientTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddClientTagsResponse proto.InternalMessageInfo
+
+func (m *AddClientTagsResponse) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type RemoveClientTagsRequest struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Tags                 []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveClientTagsRequest) Reset()         { *m = RemoveClientTagsRequest{} }
+func (m *RemoveClientTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveClientTagsRequest) ProtoMessage()    {}
+func (*RemoveClientTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{51}
+}
+
+func (m *RemoveClientTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveClientTagsRequest.Unmarshal(m, b)
+}
+func (m *RemoveClientTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveClientTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveClientTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveClientTagsRequest.Merge(m, src)
+}
+func (m *RemoveClientTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveClientTagsRequest.Size(m)
+}
+func (m *RemoveClientTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveClientTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveClientTagsRequest proto.InternalMessageInfo
+
+func (m *RemoveClientTagsRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *RemoveClientTagsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type RemoveClientTagsResponse struct {
+	Tags                 []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveClientTagsResponse) Reset()         { *m = RemoveClientTagsResponse{} }
+func (m *RemoveClientTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveClientTagsResponse) ProtoMessage()    {}
+func (*RemoveClientTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{52}
+}
+
+func (m *RemoveClientTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveClientTagsResponse.Unmarshal(m, b)
+}
+func (m *RemoveClientTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveClientTagsResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveClientTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveClientTagsResponse.Merge(m, src)
+}
+func (m *RemoveClientTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveClientTagsResponse.Size(m)
+}
+func (m *RemoveClientTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveClientTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveClientTagsResponse proto.InternalMessageInfo
+
+func (m *RemoveClientTagsResponse) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetClientStatusRequest struct {
+	Id                   string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               ClientStatus `protobuf:"varint,2,opt,name=status,proto3,enum=pb.ClientStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetClientStatusRequest) Reset()         { *m = SetClientStatusRequest{} }
+func (m *SetClientStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SetClientStatusRequest) ProtoMessage()    {}
+func (*SetClientStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{53}
+}
+
+func (m *SetClientStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetClientStatusRequest.Unmarshal(m, b)
+}
+func (m *SetClientStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetClientStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SetClientStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetClientStatusRequest.Merge(m, src)
+}
+func (m *SetClientStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SetClientStatusRequest.Size(m)
+}
+func (m *SetClientStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetClientStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetClientStatusRequest proto.InternalMessageInfo
+
+func (m *SetClientStatusRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SetClientStatusRequest) GetStatus() ClientStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ClientStatus_ACTIVE
+}
+
+type SetClientStatusResponse struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetClientStatusResponse) Reset()         { *m = SetClientStatusResponse{} }
+func (m *SetClientStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*SetClientStatusResponse) ProtoMessage()    {}
+func (*SetClientStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{54}
+}
+
+func (m *SetClientStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetClientStatusResponse.Unmarshal(m, b)
+}
+func (m *SetClientStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetClientStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *SetClientStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetClientStatusResponse.Merge(m, src)
+}
+func (m *SetClientStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_SetClientStatusResponse.Size(m)
+}
+func (m *SetClientStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetClientStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetClientStatusResponse proto.InternalMessageInfo
+
+func (m *SetClientStatusResponse) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+type NewMatchRequest struct {
+	ClientId   string      `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Score      int64       `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	OpponentId string      `protobuf:"bytes,3,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"`
+	Result     MatchResult `protobuf:"varint,4,opt,name=result,proto3,enum=pb.MatchResult" json:"result,omitempty"`
+	// unixnano, defaults to now; set it to back-fill past matches, it can't be
+	// in the future
+	PlayedAt  int64     `protobuf:"varint,5,opt,name=played_at,json=playedAt,proto3" json:"played_at,omitempty"`
+	MatchType MatchType `protobuf:"varint,6,opt,name=match_type,json=matchType,proto3,enum=pb.MatchType" json:"match_type,omitempty"`
+	// optional, max 64 chars; a retry with a key already used for the client
+	// returns the match recorded by the first request instead of a new one
+	IdempotencyKey       string   `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewMatchRequest) Reset()         { *m = NewMatchRequest{} }
+func (m *NewMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*NewMatchRequest) ProtoMessage()    {}
+func (*NewMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{55}
+}
+
+func (m *NewMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewMatchRequest.Unmarshal(m, b)
+}
+func (m *NewMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *NewMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewMatchRequest.Merge(m, src)
+}
+func (m *NewMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_NewMatchRequest.Size(m)
+}
+func (m *NewMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewMatchRequest proto.InternalMessageInfo
+
+func (m *NewMatchRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *NewMatchRequest) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *NewMatchRequest) GetOpponentId() string {
+	if m != nil {
+		return m.OpponentId
+	}
+	return ""
+}
+
+func (m *NewMatchRequest) GetResult() MatchResult {
+	if m != nil {
+		return m.Result
+	}
+	return MatchResult_NO_RESULT
+}
+
+func (m *NewMatchRequest) GetPlayedAt() int64 {
+	if m != nil {
+		return m.PlayedAt
+	}
+	return 0
+}
+
+func (m *NewMatchRequest) GetMatchType() MatchType {
+	if m != nil {
+		return m.MatchType
+	}
+	return MatchType_RANKED
+}
+
+func (m *NewMatchRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type NewMatchResponse struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewMatchResponse) Reset()         { *m = NewMatchResponse{} }
+func (m *NewMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*NewMatchResponse) ProtoMessage()    {}
+func (*NewMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{56}
+}
+
+func (m *NewMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewMatchResponse.Unmarshal(m, b)
+}
+func (m *NewMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *NewMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewMatchResponse.Merge(m, src)
+}
+func (m *NewMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_NewMatchResponse.Size(m)
+}
+func (m *NewMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewMatchResponse proto.InternalMessageInfo
+
+func (m *NewMatchResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *NewMatchResponse) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *NewMatchResponse) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type NewMatchesRequest struct {
+	Matches              []*NewMatchRequest `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *NewMatchesRequest) Reset()         { *m = NewMatchesRequest{} }
+func (m *NewMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*NewMatchesRequest) ProtoMessage()    {}
+func (*NewMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{57}
+}
+
+func (m *NewMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewMatchesRequest.Unmarshal(m, b)
+}
+func (m *NewMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *NewMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewMatchesRequest.Merge(m, src)
+}
+func (m *NewMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_NewMatchesRequest.Size(m)
+}
+func (m *NewMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewMatchesRequest proto.InternalMessageInfo
+
+func (m *NewMatchesRequest) GetMatches() []*NewMatchRequest {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type NewMatchesResponse struct {
+	Ids                  []int64  `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewMatchesResponse) Reset()         { *m = NewMatchesResponse{} }
+func (m *NewMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*NewMatchesResponse) ProtoMessage()    {}
+func (*NewMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{58}
+}
+
+func (m *NewMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewMatchesResponse.Unmarshal(m, b)
+}
+func (m *NewMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *NewMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewMatchesResponse.Merge(m, src)
+}
+func (m *NewMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_NewMatchesResponse.Size(m)
+}
+func (m *NewMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewMatchesResponse proto.InternalMessageInfo
+
+func (m *NewMatchesResponse) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type GetMatchRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchRequest) Reset()         { *m = GetMatchRequest{} }
+func (m *GetMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMatchRequest) ProtoMessage()    {}
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{59}
+}
+
+func (m *GetMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchRequest.Unmarshal(m, b)
+}
+func (m *GetMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchRequest.Merge(m, src)
+}
+func (m *GetMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMatchRequest.Size(m)
+}
+func (m *GetMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchRequest proto.InternalMessageInfo
+
+func (m *GetMatchRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetMatchResponse struct {
+	Match                *Match   `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchResponse) Reset()         { *m = GetMatchResponse{} }
+func (m *GetMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMatchResponse) ProtoMessage()    {}
+func (*GetMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{60}
+}
+
+func (m *GetMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchResponse.Unmarshal(m, b)
+}
+func (m *GetMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchResponse.Merge(m, src)
+}
+func (m *GetMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMatchResponse.Size(m)
+}
+func (m *GetMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchResponse proto.InternalMessageInfo
+
+func (m *GetMatchResponse) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+type UpdateMatchRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score                int64    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateMatchRequest) Reset()         { *m = UpdateMatchRequest{} }
+func (m *UpdateMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMatchRequest) ProtoMessage()    {}
+func (*UpdateMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{61}
+}
+
+func (m *UpdateMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMatchRequest.Unmarshal(m, b)
+}
+func (m *UpdateMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMatchRequest.Merge(m, src)
+}
+func (m *UpdateMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateMatchRequest.Size(m)
+}
+func (m *UpdateMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMatchRequest proto.InternalMessageInfo
+
+func (m *UpdateMatchRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UpdateMatchRequest) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type UpdateMatchResponse struct {
+	Match                *Match   `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateMatchResponse) Reset()         { *m = UpdateMatchResponse{} }
+func (m *UpdateMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateMatchResponse) ProtoMessage()    {}
+func (*UpdateMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{62}
+}
+
+func (m *UpdateMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMatchResponse.Unmarshal(m, b)
+}
+func (m *UpdateMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMatchResponse.Merge(m, src)
+}
+func (m *UpdateMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateMatchResponse.Size(m)
+}
+func (m *UpdateMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMatchResponse proto.InternalMessageInfo
+
+func (m *UpdateMatchResponse) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+type DeleteMatchRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMatchRequest) Reset()         { *m = DeleteMatchRequest{} }
+func (m *DeleteMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMatchRequest) ProtoMessage()    {}
+func (*DeleteMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{63}
+}
+
+func (m *DeleteMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMatchRequest.Unmarshal(m, b)
+}
+func (m *DeleteMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMatchRequest.Merge(m, src)
+}
+func (m *DeleteMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMatchRequest.Size(m)
+}
+func (m *DeleteMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMatchRequest proto.InternalMessageInfo
+
+func (m *DeleteMatchRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteMatchResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMatchResponse) Reset()         { *m = DeleteMatchResponse{} }
+func (m *DeleteMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteMatchResponse) ProtoMessage()    {}
+func (*DeleteMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{64}
+}
+
+func (m *DeleteMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMatchResponse.Unmarshal(m, b)
+}
+func (m *DeleteMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMatchResponse.Merge(m, src)
+}
+func (m *DeleteMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteMatchResponse.Size(m)
+}
+func (m *DeleteMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMatchResponse proto.InternalMessageInfo
+
+type UndoLastMatchRequest struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndoLastMatchRequest) Reset()         { *m = UndoLastMatchRequest{} }
+func (m *UndoLastMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*UndoLastMatchRequest) ProtoMessage()    {}
+func (*UndoLastMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{65}
+}
+
+func (m *UndoLastMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndoLastMatchRequest.Unmarshal(m, b)
+}
+func (m *UndoLastMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndoLastMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *UndoLastMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndoLastMatchRequest.Merge(m, src)
+}
+func (m *UndoLastMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_UndoLastMatchRequest.Size(m)
+}
+func (m *UndoLastMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndoLastMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndoLastMatchRequest proto.InternalMessageInfo
+
+func (m *UndoLastMatchRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type UndoLastMatchResponse struct {
+	Match                *Match   `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndoLastMatchResponse) Reset()         { *m = UndoLastMatchResponse{} }
+func (m *UndoLastMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*UndoLastMatchResponse) ProtoMessage()    {}
+func (*UndoLastMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{66}
+}
+
+func (m *UndoLastMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndoLastMatchResponse.Unmarshal(m, b)
+}
+func (m *UndoLastMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndoLastMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *UndoLastMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndoLastMatchResponse.Merge(m, src)
+}
+func (m *UndoLastMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_UndoLastMatchResponse.Size(m)
+}
+func (m *UndoLastMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndoLastMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndoLastMatchResponse proto.InternalMessageInfo
+
+func (m *UndoLastMatchResponse) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+type DeleteMatchesOlderThanRequest struct {
+	Before               int64    `protobuf:"varint,1,opt,name=before,proto3" json:"before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMatchesOlderThanRequest) Reset()         { *m = DeleteMatchesOlderThanRequest{} }
+func (m *DeleteMatchesOlderThanRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMatchesOlderThanRequest) ProtoMessage()    {}
+func (*DeleteMatchesOlderThanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{67}
+}
+
+func (m *DeleteMatchesOlderThanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMatchesOlderThanRequest.Unmarshal(m, b)
+}
+func (m *DeleteMatchesOlderThanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMatchesOlderThanRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMatchesOlderThanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMatchesOlderThanRequest.Merge(m, src)
+}
+func (m *DeleteMatchesOlderThanRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMatchesOlderThanRequest.Size(m)
+}
+func (m *DeleteMatchesOlderThanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMatchesOlderThanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMatchesOlderThanRequest proto.InternalMessageInfo
+
+func (m *DeleteMatchesOlderThanRequest) GetBefore() int64 {
+	if m != nil {
+		return m.Before
+	}
+	return 0
+}
+
+type DeleteMatchesOlderThanResponse struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMatchesOlderThanResponse) Reset()         { *m = DeleteMatchesOlderThanResponse{} }
+func (m *DeleteMatchesOlderThanResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteMatchesOlderThanResponse) ProtoMessage()    {}
+func (*DeleteMatchesOlderThanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{68}
+}
+
+func (m *DeleteMatchesOlderThanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMatchesOlderThanResponse.Unmarshal(m, b)
+}
+func (m *DeleteMatchesOlderThanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMatchesOlderThanResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteMatchesOlderThanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMatchesOlderThanResponse.Merge(m, src)
+}
+func (m *DeleteMatchesOlderThanResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteMatchesOlderThanResponse.Size(m)
+}
+func (m *DeleteMatchesOlderThanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMatchesOlderThanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMatchesOlderThanResponse proto.InternalMessageInfo
+
+func (m *DeleteMatchesOlderThanResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type ListMatchesRequest struct {
+	ClientId      string      `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	CreatedAfter  int64       `protobuf:"varint,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore int64       `protobuf:"varint,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Limit         int64       `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int64       `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	MatchTypes    []MatchType `protobuf:"varint,6,rep,packed,name=match_types,json=matchTypes,proto3,enum=pb.MatchType" json:"match_types,omitempty"`
+	// next_page_token of the previous page; can't be used with offset
+	PageToken            string    `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PlayedFrom           *OptInt64 `protobuf:"bytes,8,opt,name=played_from,json=playedFrom,proto3" json:"played_from,omitempty"`
+	PlayedTo             *OptInt64 `protobuf:"bytes,9,opt,name=played_to,json=playedTo,proto3" json:"played_to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListMatchesRequest) Reset()         { *m = ListMatchesRequest{} }
+func (m *ListMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMatchesRequest) ProtoMessage()    {}
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{69}
+}
+
+func (m *ListMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMatchesRequest.Unmarshal(m, b)
+}
+func (m *ListMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMatchesRequest.Merge(m, src)
+}
+func (m *ListMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMatchesRequest.Size(m)
+}
+func (m *ListMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMatchesRequest proto.InternalMessageInfo
+
+func (m *ListMatchesRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *ListMatchesRequest) GetCreatedAfter() int64 {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return 0
+}
+
+func (m *ListMatchesRequest) GetCreatedBefore() int64 {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return 0
+}
+
+func (m *ListMatchesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListMatchesRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListMatchesRequest) GetMatchTypes() []MatchType {
+	if m != nil {
+		return m.MatchTypes
+	}
+	return nil
+}
+
+func (m *ListMatchesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListMatchesRequest) GetPlayedFrom() *OptInt64 {
+	if m != nil {
+		return m.PlayedFrom
+	}
+	return nil
+}
+
+func (m *ListMatchesRequest) GetPlayedTo() *OptInt64 {
+	if m != nil {
+		return m.PlayedTo
+	}
+	return nil
+}
+
+type ListMatchesResponse struct {
+	Matches              []*Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMatchesResponse) Reset()         { *m = ListMatchesResponse{} }
+func (m *ListMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMatchesResponse) ProtoMessage()    {}
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{70}
+}
+
+func (m *ListMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMatchesResponse.Unmarshal(m, b)
+}
+func (m *ListMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMatchesResponse.Merge(m, src)
+}
+func (m *ListMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMatchesResponse.Size(m)
+}
+func (m *ListMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMatchesResponse proto.InternalMessageInfo
+
+func (m *ListMatchesResponse) GetMatches() []*Match {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+func (m *ListMatchesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetTopMatchesRequest struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTopMatchesRequest) Reset()         { *m = GetTopMatchesRequest{} }
+func (m *GetTopMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTopMatchesRequest) ProtoMessage()    {}
+func (*GetTopMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{71}
+}
+
+func (m *GetTopMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTopMatchesRequest.Unmarshal(m, b)
+}
+func (m *GetTopMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTopMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTopMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTopMatchesRequest.Merge(m, src)
+}
+func (m *GetTopMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTopMatchesRequest.Size(m)
+}
+func (m *GetTopMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTopMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTopMatchesRequest proto.InternalMessageInfo
+
+func (m *GetTopMatchesRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *GetTopMatchesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetTopMatchesResponse struct {
+	Matches              []*Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTopMatchesResponse) Reset()         { *m = GetTopMatchesResponse{} }
+func (m *GetTopMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTopMatchesResponse) ProtoMessage()    {}
+func (*GetTopMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{72}
+}
+
+func (m *GetTopMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTopMatchesResponse.Unmarshal(m, b)
+}
+func (m *GetTopMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTopMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTopMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTopMatchesResponse.Merge(m, src)
+}
+func (m *GetTopMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTopMatchesResponse.Size(m)
+}
+func (m *GetTopMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTopMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTopMatchesResponse proto.InternalMessageInfo
+
+func (m *GetTopMatchesResponse) GetMatches() []*Match {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type StreamMatchesRequest struct {
+	ClientId             string      `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	CreatedAfter         int64       `protobuf:"varint,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore        int64       `protobuf:"varint,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	MatchTypes           []MatchType `protobuf:"varint,4,rep,packed,name=match_types,json=matchTypes,proto3,enum=pb.MatchType" json:"match_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StreamMatchesRequest) Reset()         { *m = StreamMatchesRequest{} }
+func (m *StreamMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamMatchesRequest) ProtoMessage()    {}
+func (*StreamMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{73}
+}
+
+func (m *StreamMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamMatchesRequest.Unmarshal(m, b)
+}
+func (m *StreamMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamMatchesRequest.Merge(m, src)
+}
+func (m *StreamMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamMatchesRequest.Size(m)
+}
+func (m *StreamMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamMatchesRequest proto.InternalMessageInfo
+
+func (m *StreamMatchesRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *StreamMatchesRequest) GetCreatedAfter() int64 {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return 0
+}
+
+func (m *StreamMatchesRequest) GetCreatedBefore() int64 {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return 0
+}
+
+func (m *StreamMatchesRequest) GetMatchTypes() []MatchType {
+	if m != nil {
+		return m.MatchTypes
+	}
+	return nil
+}
+
+type GetHeadToHeadRequest struct {
+	ClientA              string      `protobuf:"bytes,1,opt,name=client_a,json=clientA,proto3" json:"client_a,omitempty"`
+	ClientB              string      `protobuf:"bytes,2,opt,name=client_b,json=clientB,proto3" json:"client_b,omitempty"`
+	MatchTypes           []MatchType `protobuf:"varint,3,rep,packed,name=match_types,json=matchTypes,proto3,enum=pb.MatchType" json:"match_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetHeadToHeadRequest) Reset()         { *m = GetHeadToHeadRequest{} }
+func (m *GetHeadToHeadRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHeadToHeadRequest) ProtoMessage()    {}
+func (*GetHeadToHeadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{74}
+}
+
+func (m *GetHeadToHeadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeadToHeadRequest.Unmarshal(m, b)
+}
+func (m *GetHeadToHeadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeadToHeadRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHeadToHeadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeadToHeadRequest.Merge(m, src)
+}
+func (m *GetHeadToHeadRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHeadToHeadRequest.Size(m)
+}
+func (m *GetHeadToHeadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeadToHeadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeadToHeadRequest proto.InternalMessageInfo
+
+func (m *GetHeadToHeadRequest) GetClientA() string {
+	if m != nil {
+		return m.ClientA
+	}
+	return ""
+}
+
+func (m *GetHeadToHeadRequest) GetClientB() string {
+	if m != nil {
+		return m.ClientB
+	}
+	return ""
+}
+
+func (m *GetHeadToHeadRequest) GetMatchTypes() []MatchType {
+	if m != nil {
+		return m.MatchTypes
+	}
+	return nil
+}
+
+// GetHeadToHeadResponse sums the matches recorded by either client against
+// the other one
+type GetHeadToHeadResponse struct {
+	Matches              int64    `protobuf:"varint,1,opt,name=matches,proto3" json:"matches,omitempty"`
+	ClientAWins          int64    `protobuf:"varint,2,opt,name=client_a_wins,json=clientAWins,proto3" json:"client_a_wins,omitempty"`
+	ClientBWins          int64    `protobuf:"varint,3,opt,name=client_b_wins,json=clientBWins,proto3" json:"client_b_wins,omitempty"`
+	Draws                int64    `protobuf:"varint,4,opt,name=draws,proto3" json:"draws,omitempty"`
+	ClientAScore         int64    `protobuf:"varint,5,opt,name=client_a_score,json=clientAScore,proto3" json:"client_a_score,omitempty"`
+	ClientBScore         int64    `protobuf:"varint,6,opt,name=client_b_score,json=clientBScore,proto3" json:"client_b_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHeadToHeadResponse) Reset()         { *m = GetHeadToHeadResponse{} }
+func (m *GetHeadToHeadResponse) String() string { return proto.CompactTextString(m) }
+func (*GetHeadToHeadResponse) ProtoMessage()    {}
+func (*GetHeadToHeadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{75}
+}
+
+func (m *GetHeadToHeadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeadToHeadResponse.Unmarshal(m, b)
+}
+func (m *GetHeadToHeadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeadToHeadResponse.Marshal(b, m, deterministic)
+}
+func (m *GetHeadToHeadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeadToHeadResponse.Merge(m, src)
+}
+func (m *GetHeadToHeadResponse) XXX_Size() int {
+	return xxx_messageInfo_GetHeadToHeadResponse.Size(m)
+}
+func (m *GetHeadToHeadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeadToHeadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeadToHeadResponse proto.InternalMessageInfo
+
+func (m *GetHeadToHeadResponse) GetMatches() int64 {
+	if m != nil {
+		return m.Matches
+	}
+	return 0
+}
+
+func (m *GetHeadToHeadResponse) GetClientAWins() int64 {
+	if m != nil {
+		return m.ClientAWins
+	}
+	return 0
+}
+
+func (m *GetHeadToHeadResponse) GetClientBWins() int64 {
+	if m != nil {
+		return m.ClientBWins
+	}
+	return 0
+}
+
+func (m *GetHeadToHeadResponse) GetDraws() int64 {
+	if m != nil {
+		return m.Draws
+	}
+	return 0
+}
+
+func (m *GetHeadToHeadResponse) GetClientAScore() int64 {
+	if m != nil {
+		return m.ClientAScore
+	}
+	return 0
+}
+
+func (m *GetHeadToHeadResponse) GetClientBScore() int64 {
+	if m != nil {
+		return m.ClientBScore
+	}
+	return 0
+}
+
+type GetClientStatsRequest struct {
+	ClientId             string      `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	MatchTypes           []MatchType `protobuf:"varint,2,rep,packed,name=match_types,json=matchTypes,proto3,enum=pb.MatchType" json:"match_types,omitempty"`
+	SeasonId             string      `protobuf:"bytes,3,opt,name=season_id,json=seasonId,proto3" json:"season_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetClientStatsRequest) Reset()         { *m = GetClientStatsRequest{} }
+func (m *GetClientStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientStatsRequest) ProtoMessage()    {}
+func (*GetClientStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{76}
+}
+
+func (m *GetClientStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientStatsRequest.Unmarshal(m, b)
+}
+func (m *GetClientStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientStatsRequest.Merge(m, src)
+}
+func (m *GetClientStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientStatsRequest.Size(m)
+}
+func (m *GetClientStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientStatsRequest proto.InternalMessageInfo
+
+func (m *GetClientStatsRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *GetClientStatsRequest) GetMatchTypes() []MatchType {
+	if m != nil {
+		return m.MatchTypes
+	}
+	return nil
+}
+
+func (m *GetClientStatsRequest) GetSeasonId() string {
+	if m != nil {
+		return m.SeasonId
+	}
+	return ""
+}
+
+type GetClientStatsResponse struct {
+	Stats                *ClientStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetClientStatsResponse) Reset()         { *m = GetClientStatsResponse{} }
+func (m *GetClientStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientStatsResponse) ProtoMessage()    {}
+func (*GetClientStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{77}
+}
+
+func (m *GetClientStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientStatsResponse.Unmarshal(m, b)
+}
+func (m *GetClientStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientStatsResponse.Merge(m, src)
+}
+func (m *GetClientStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientStatsResponse.Size(m)
+}
+func (m *GetClientStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientStatsResponse proto.InternalMessageInfo
+
+func (m *GetClientStatsResponse) GetStats() *ClientStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type RecalculateScoreRequest struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecalculateScoreRequest) Reset()         { *m = RecalculateScoreRequest{} }
+func (m *RecalculateScoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RecalculateScoreRequest) ProtoMessage()    {}
+func (*RecalculateScoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{78}
+}
+
+func (m *RecalculateScoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecalculateScoreRequest.Unmarshal(m, b)
+}
+func (m *RecalculateScoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecalculateScoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RecalculateScoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecalculateScoreRequest.Merge(m, src)
+}
+func (m *RecalculateScoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RecalculateScoreRequest.Size(m)
+}
+func (m *RecalculateScoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecalculateScoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecalculateScoreRequest proto.InternalMessageInfo
+
+func (m *RecalculateScoreRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *RecalculateScoreRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ScoreDrift struct {
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	OldScore int64  `protobuf:"varint,2,opt,name=old_score,json=oldScore,proto3" json:"old_score,omitempty"`
+	// the initial score of the client (or the last one set by hand) plus the
+	// scores of its matches, including the ones deleted by the retention
+	NewScore             int64    `protobuf:"varint,3,opt,name=new_score,json=newScore,proto3" json:"new_score,omitempty"`
+	Drift                int64    `protobuf:"varint,4,opt,name=drift,proto3" json:"drift,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScoreDrift) Reset()         { *m = ScoreDrift{} }
+func (m *ScoreDrift) String() string { return proto.CompactTextString(m) }
+func (*ScoreDrift) ProtoMessage()    {}
+func (*ScoreDrift) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{79}
+}
+
+func (m *ScoreDrift) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScoreDrift.Unmarshal(m, b)
+}
+func (m *ScoreDrift) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScoreDrift.Marshal(b, m, deterministic)
+}
+func (m *ScoreDrift) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoreDrift.Merge(m, src)
+}
+func (m *ScoreDrift) XXX_Size() int {
+	return xxx_messageInfo_ScoreDrift.Size(m)
+}
+func (m *ScoreDrift) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoreDrift.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoreDrift proto.InternalMessageInfo
+
+func (m *ScoreDrift) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *ScoreDrift) GetOldScore() int64 {
+	if m != nil {
+		return m.OldScore
+	}
+	return 0
+}
+
+func (m *ScoreDrift) GetNewScore() int64 {
+	if m != nil {
+		return m.NewScore
+	}
+	return 0
+}
+
+func (m *ScoreDrift) GetDrift() int64 {
+	if m != nil {
+		return m.Drift
+	}
+	return 0
+}
+
+type RecalculateScoreResponse struct {
+	Checked int64 `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	// the clients whose score differed from its new_score; with client_id set,
+	// that client is always reported
+	Drifts               []*ScoreDrift `protobuf:"bytes,2,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RecalculateScoreResponse) Reset()         { *m = RecalculateScoreResponse{} }
+func (m *RecalculateScoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RecalculateScoreResponse) ProtoMessage()    {}
+func (*RecalculateScoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{80}
+}
+
+func (m *RecalculateScoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecalculateScoreResponse.Unmarshal(m, b)
+}
+func (m *RecalculateScoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecalculateScoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RecalculateScoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecalculateScoreResponse.Merge(m, src)
+}
+func (m *RecalculateScoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RecalculateScoreResponse.Size(m)
+}
+func (m *RecalculateScoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecalculateScoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecalculateScoreResponse proto.InternalMessageInfo
+
+func (m *RecalculateScoreResponse) GetChecked() int64 {
+	if m != nil {
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *RecalculateScoreResponse) GetDrifts() []*ScoreDrift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
+
+type GetScoreHistoryRequest struct {
+	ClientId             string             `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	From                 int64              `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64              `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Bucket               ScoreHistoryBucket `protobuf:"varint,4,opt,name=bucket,proto3,enum=pb.ScoreHistoryBucket" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetScoreHistoryRequest) Reset()         { *m = GetScoreHistoryRequest{} }
+func (m *GetScoreHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetScoreHistoryRequest) ProtoMessage()    {}
+func (*GetScoreHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{81}
+}
+
+func (m *GetScoreHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetScoreHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetScoreHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetScoreHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetScoreHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetScoreHistoryRequest.Merge(m, src)
+}
+func (m *GetScoreHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetScoreHistoryRequest.Size(m)
+}
+func (m *GetScoreHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetScoreHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetScoreHistoryRequest proto.InternalMessageInfo
+
+func (m *GetScoreHistoryRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *GetScoreHistoryRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetScoreHistoryRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *GetScoreHistoryRequest) GetBucket() ScoreHistoryBucket {
+	if m != nil {
+		return m.Bucket
+	}
+	return ScoreHistoryBucket_DAY
+}
+
+type ScorePoint struct {
+	BucketStart          int64    `protobuf:"varint,1,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"`
+	Delta                int64    `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScorePoint) Reset()         { *m = ScorePoint{} }
+func (m *ScorePoint) String() string { return proto.CompactTextString(m) }
+func (*ScorePoint) ProtoMessage()    {}
+func (*ScorePoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{82}
+}
+
+func (m *ScorePoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScorePoint.Unmarshal(m, b)
+}
+func (m *ScorePoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScorePoint.Marshal(b, m, deterministic)
+}
+func (m *ScorePoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScorePoint.Merge(m, src)
+}
+func (m *ScorePoint) XXX_Size() int {
+	return xxx_messageInfo_ScorePoint.Size(m)
+}
+func (m *ScorePoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScorePoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScorePoint proto.InternalMessageInfo
+
+func (m *ScorePoint) GetBucketStart() int64 {
+	if m != nil {
+		return m.BucketStart
+	}
+	return 0
+}
+
+func (m *ScorePoint) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *ScorePoint) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type GetScoreHistoryResponse struct {
+	// one point per bucket in the range, oldest first, including the buckets
+	// without matches
+	Points               []*ScorePoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetScoreHistoryResponse) Reset()         { *m = GetScoreHistoryResponse{} }
+func (m *GetScoreHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetScoreHistoryResponse) ProtoMessage()    {}
+func (*GetScoreHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{83}
+}
+
+func (m *GetScoreHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetScoreHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetScoreHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetScoreHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetScoreHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetScoreHistoryResponse.Merge(m, src)
+}
+func (m *GetScoreHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetScoreHistoryResponse.Size(m)
+}
+func (m *GetScoreHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetScoreHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetScoreHistoryResponse proto.InternalMessageInfo
+
+func (m *GetScoreHistoryResponse) GetPoints() []*ScorePoint {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type GetClientsStatsRequest struct {
+	ClientIds            []string    `protobuf:"bytes,1,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	MatchTypes           []MatchType `protobuf:"varint,2,rep,packed,name=match_types,json=matchTypes,proto3,enum=pb.MatchType" json:"match_types,omitempty"`
+	SeasonId             string      `protobuf:"bytes,3,opt,name=season_id,json=seasonId,proto3" json:"season_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetClientsStatsRequest) Reset()         { *m = GetClientsStatsRequest{} }
+func (m *GetClientsStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientsStatsRequest) ProtoMessage()    {}
+func (*GetClientsStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{84}
+}
+
+func (m *GetClientsStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsStatsRequest.Unmarshal(m, b)
+}
+func (m *GetClientsStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientsStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsStatsRequest.Merge(m, src)
+}
+func (m *GetClientsStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientsStatsRequest.Size(m)
+}
+func (m *GetClientsStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsStatsRequest proto.InternalMessageInfo
+
+func (m *GetClientsStatsRequest) GetClientIds() []string {
+	if m != nil {
+		return m.ClientIds
+	}
+	return nil
+}
+
+func (m *GetClientsStatsRequest) GetMatchTypes() []MatchType {
+	if m != nil {
+		return m.MatchTypes
+	}
+	return nil
+}
+
+func (m *GetClientsStatsRequest) GetSeasonId() string {
+	if m != nil {
+		return m.SeasonId
+	}
+	return ""
+}
+
+type GetClientsStatsResponse struct {
+	// keyed by client id; clients that don't exist are left out
+	Stats                map[string]*ClientStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetClientsStatsResponse) Reset()         { *m = GetClientsStatsResponse{} }
+func (m *GetClientsStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClientsStatsResponse) ProtoMessage()    {}
+func (*GetClientsStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{85}
+}
+
+func (m *GetClientsStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsStatsResponse.Unmarshal(m, b)
+}
+func (m *GetClientsStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClientsStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsStatsResponse.Merge(m, src)
+}
+func (m *GetClientsStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClientsStatsResponse.Size(m)
+}
+func (m *GetClientsStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsStatsResponse proto.InternalMessageInfo
+
+func (m *GetClientsStatsResponse) GetStats() map[string]*ClientStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type GetLeaderboardRequest struct {
+	Limit       int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset      int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	IncludeRank bool  `protobuf:"varint,3,opt,name=include_rank,json=includeRank,proto3" json:"include_rank,omitempty"`
+	// ranks by the season total instead of the all-time score; empty for all
+	// time
+	SeasonId             string   `protobuf:"bytes,4,opt,name=season_id,json=seasonId,proto3" json:"season_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLeaderboardRequest) Reset()         { *m = GetLeaderboardRequest{} }
+func (m *GetLeaderboardRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLeaderboardRequest) ProtoMessage()    {}
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{86}
+}
+
+func (m *GetLeaderboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLeaderboardRequest.Unmarshal(m, b)
+}
+func (m *GetLeaderboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLeaderboardRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLeaderboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLeaderboardRequest.Merge(m, src)
+}
+func (m *GetLeaderboardRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLeaderboardRequest.Size(m)
+}
+func (m *GetLeaderboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLeaderboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLeaderboardRequest proto.InternalMessageInfo
+
+func (m *GetLeaderboardRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetLeaderboardRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetLeaderboardRequest) GetIncludeRank() bool {
+	if m != nil {
+		return m.IncludeRank
+	}
+	return false
+}
+
+func (m *GetLeaderboardRequest) GetSeasonId() string {
+	if m != nil {
+		return m.SeasonId
+	}
+	return ""
+}
+
+type LeaderboardEntry struct {
+	Client               *Client  `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	Rank                 int64    `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	SeasonScore          int64    `protobuf:"varint,3,opt,name=season_score,json=seasonScore,proto3" json:"season_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderboardEntry) Reset()         { *m = LeaderboardEntry{} }
+func (m *LeaderboardEntry) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardEntry) ProtoMessage()    {}
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{87}
+}
+
+func (m *LeaderboardEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardEntry.Unmarshal(m, b)
+}
+func (m *LeaderboardEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardEntry.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardEntry.Merge(m, src)
+}
+func (m *LeaderboardEntry) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardEntry.Size(m)
+}
+func (m *LeaderboardEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardEntry proto.InternalMessageInfo
+
+func (m *LeaderboardEntry) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *LeaderboardEntry) GetRank() int64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *LeaderboardEntry) GetSeasonScore() int64 {
+	if m != nil {
+		return m.SeasonScore
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	// by score (or season score), highest first; ties go to the oldest client
+	Entries              []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetLeaderboardResponse) Reset()         { *m = GetLeaderboardResponse{} }
+func (m *GetLeaderboardResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLeaderboardResponse) ProtoMessage()    {}
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{88}
+}
+
+func (m *GetLeaderboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLeaderboardResponse.Unmarshal(m, b)
+}
+func (m *GetLeaderboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLeaderboardResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLeaderboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLeaderboardResponse.Merge(m, src)
+}
+func (m *GetLeaderboardResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLeaderboardResponse.Size(m)
+}
+func (m *GetLeaderboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLeaderboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLeaderboardResponse proto.InternalMessageInfo
+
+func (m *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type GetLeaderboardForPeriodRequest struct {
+	From                 int64    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Limit                int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	SeasonId             string   `protobuf:"bytes,4,opt,name=season_id,json=seasonId,proto3" json:"season_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLeaderboardForPeriodRequest) Reset()         { *m = GetLeaderboardForPeriodRequest{} }
+func (m *GetLeaderboardForPeriodRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLeaderboardForPeriodRequest) ProtoMessage()    {}
+func (*GetLeaderboardForPeriodRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{89}
+}
+
+func (m *GetLeaderboardForPeriodRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLeaderboardForPeriodRequest.Unmarshal(m, b)
+}
+func (m *GetLeaderboardForPeriodRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLeaderboardForPeriodRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLeaderboardForPeriodRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLeaderboardForPeriodRequest.Merge(m, src)
+}
+func (m *GetLeaderboardForPeriodRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLeaderboardForPeriodRequest.Size(m)
+}
+func (m *GetLeaderboardForPeriodRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLeaderboardForPeriodRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLeaderboardForPeriodRequest proto.InternalMessageInfo
+
+func (m *GetLeaderboardForPeriodRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetLeaderboardForPeriodRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *GetLeaderboardForPeriodRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetLeaderboardForPeriodRequest) GetSeasonId() string {
+	if m != nil {
+		return m.SeasonId
+	}
+	return ""
+}
+
+type PeriodLeaderboardEntry struct {
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Rank                 int64    `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeriodLeaderboardEntry) Reset()         { *m = PeriodLeaderboardEntry{} }
+func (m *PeriodLeaderboardEntry) String() string { return proto.CompactTextString(m) }
+func (*PeriodLeaderboardEntry) ProtoMessage()    {}
+func (*PeriodLeaderboardEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{90}
+}
+
+func (m *PeriodLeaderboardEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeriodLeaderboardEntry.Unmarshal(m, b)
+}
+func (m *PeriodLeaderboardEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeriodLeaderboardEntry.Marshal(b, m, deterministic)
+}
+func (m *PeriodLeaderboardEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeriodLeaderboardEntry.Merge(m, src)
+}
+func (m *PeriodLeaderboardEntry) XXX_Size() int {
+	return xxx_messageInfo_PeriodLeaderboardEntry.Size(m)
+}
+func (m *PeriodLeaderboardEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeriodLeaderboardEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeriodLeaderboardEntry proto.InternalMessageInfo
+
+func (m *PeriodLeaderboardEntry) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *PeriodLeaderboardEntry) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PeriodLeaderboardEntry) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PeriodLeaderboardEntry) GetRank() int64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+type GetLeaderboardForPeriodResponse struct {
+	Entries              []*PeriodLeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetLeaderboardForPeriodResponse) Reset()         { *m = GetLeaderboardForPeriodResponse{} }
+func (m *GetLeaderboardForPeriodResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLeaderboardForPeriodResponse) ProtoMessage()    {}
+func (*GetLeaderboardForPeriodResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{91}
+}
+
+func (m *GetLeaderboardForPeriodResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLeaderboardForPeriodResponse.Unmarshal(m, b)
+}
+func (m *GetLeaderboardForPeriodResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLeaderboardForPeriodResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLeaderboardForPeriodResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLeaderboardForPeriodResponse.Merge(m, src)
+}
+func (m *GetLeaderboardForPeriodResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLeaderboardForPeriodResponse.Size(m)
+}
+func (m *GetLeaderboardForPeriodResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLeaderboardForPeriodResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLeaderboardForPeriodResponse proto.InternalMessageInfo
+
+func (m *GetLeaderboardForPeriodResponse) GetEntries() []*PeriodLeaderboardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type AnonymizeClientRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnonymizeClientRequest) Reset()         { *m = AnonymizeClientRequest{} }
+func (m *AnonymizeClientRequest) String() string { return proto.CompactTextString(m) }
+func (*AnonymizeClientRequest) ProtoMessage()    {}
+func (*AnonymizeClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{92}
+}
+
+func (m *AnonymizeClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnonymizeClientRequest.Unmarshal(m, b)
+}
+func (m *AnonymizeClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnonymizeClientRequest.Marshal(b, m, deterministic)
+}
+func (m *AnonymizeClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnonymizeClientRequest.Merge(m, src)
+}
+func (m *AnonymizeClientRequest) XXX_Size() int {
+	return xxx_messageInfo_AnonymizeClientRequest.Size(m)
+}
+func (m *AnonymizeClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnonymizeClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnonymizeClientRequest proto.InternalMessageInfo
+
+func (m *AnonymizeClientRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type AnonymizeClientResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnonymizeClientResponse) Reset()         { *m = AnonymizeClientResponse{} }
+func (m *AnonymizeClientResponse) String() string { return proto.CompactTextString(m) }
+func (*AnonymizeClientResponse) ProtoMessage()    {}
+func (*AnonymizeClientResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{93}
+}
+
+func (m *AnonymizeClientResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnonymizeClientResponse.Unmarshal(m, b)
+}
+func (m *AnonymizeClientResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnonymizeClientResponse.Marshal(b, m, deterministic)
+}
+func (m *AnonymizeClientResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnonymizeClientResponse.Merge(m, src)
+}
+func (m *AnonymizeClientResponse) XXX_Size() int {
+	return xxx_messageInfo_AnonymizeClientResponse.Size(m)
+}
+func (m *AnonymizeClientResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnonymizeClientResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnonymizeClientResponse proto.InternalMessageInfo
+
+type MergeClientsRequest struct {
+	SourceId             string   `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId             string   `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Force                bool     `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MergeClientsRequest) Reset()         { *m = MergeClientsRequest{} }
+func (m *MergeClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*MergeClientsRequest) ProtoMessage()    {}
+func (*MergeClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{94}
+}
+
+func (m *MergeClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MergeClientsRequest.Unmarshal(m, b)
+}
+func (m *MergeClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MergeClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *MergeClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeClientsRequest.Merge(m, src)
+}
+func (m *MergeClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_MergeClientsRequest.Size(m)
+}
+func (m *MergeClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeClientsRequest proto.InternalMessageInfo
+
+func (m *MergeClientsRequest) GetSourceId() string {
+	if m != nil {
+		return m.SourceId
+	}
+	return ""
+}
+
+func (m *MergeClientsRequest) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
+
+func (m *MergeClientsRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type MergeClientsResponse struct {
+	MovedMatches         int64    `protobuf:"varint,1,opt,name=moved_matches,json=movedMatches,proto3" json:"moved_matches,omitempty"`
+	Score                int64    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MergeClientsResponse) Reset()         { *m = MergeClientsResponse{} }
+func (m *MergeClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*MergeClientsResponse) ProtoMessage()    {}
+func (*MergeClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{95}
+}
+
+func (m *MergeClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MergeClientsResponse.Unmarshal(m, b)
+}
+func (m *MergeClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MergeClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *MergeClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeClientsResponse.Merge(m, src)
+}
+func (m *MergeClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_MergeClientsResponse.Size(m)
+}
+func (m *MergeClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeClientsResponse proto.InternalMessageInfo
+
+func (m *MergeClientsResponse) GetMovedMatches() int64 {
+	if m != nil {
+		return m.MovedMatches
+	}
+	return 0
+}
+
+func (m *MergeClientsResponse) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type SortRequest struct {
+	Items            []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	RemoveDuplicates bool     `protobuf:"varint,2,opt,name=remove_duplicates,json=removeDuplicates,proto3" json:"remove_duplicates,omitempty"`
+	Mode             SortMode `protobuf:"varint,3,opt,name=mode,proto3,enum=pb.SortMode" json:"mode,omitempty"`
+	Descending       bool     `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// BCP-47 tag collating the items in STRING mode (e.g. "pt-BR"), bytewise when empty
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	// in STRING mode, items differing only in case are compared as equal, so also removed as duplicates: by
+	// the collation of locale, or else case-folded, being ordered bytewise and the first of them kept
+	IgnoreCase bool `protobuf:"varint,6,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`
+	// with locale, items differing only in diacritics are collated as equal
+	IgnoreDiacritics bool `protobuf:"varint,7,opt,name=ignore_diacritics,json=ignoreDiacritics,proto3" json:"ignore_diacritics,omitempty"`
+	// deprecated alias of ignore_case, kept for the clients still setting it
+	CaseInsensitive bool `protobuf:"varint,8,opt,name=case_insensitive,json=caseInsensitive,proto3" json:"case_insensitive,omitempty"` // Deprecated: Do not use.
+	// sets SortResponse.counts
+	ReturnCounts bool `protobuf:"varint,9,opt,name=return_counts,json=returnCounts,proto3" json:"return_counts,omitempty"`
+	// keeps the items in their given order, so with remove_duplicates the first given of the equal items
+	// is kept; exclusive with descending
+	PreserveOrder        bool     `protobuf:"varint,10,opt,name=preserve_order,json=preserveOrder,proto3" json:"preserve_order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SortRequest) Reset()         { *m = SortRequest{} }
+func (m *SortRequest) String() string { return proto.CompactTextString(m) }
+func (*SortRequest) ProtoMessage()    {}
+func (*SortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{96}
+}
+
+func (m *SortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SortRequest.Unmarshal(m, b)
+}
+func (m *SortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SortRequest.Marshal(b, m, deterministic)
+}
+func (m *SortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortRequest.Merge(m, src)
+}
+func (m *SortRequest) XXX_Size() int {
+	return xxx_messageInfo_SortRequest.Size(m)
+}
+func (m *SortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortRequest proto.InternalMessageInfo
+
+func (m *SortRequest) GetItems() []string {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *SortRequest) GetRemoveDuplicates() bool {
+	if m != nil {
+		return m.RemoveDuplicates
+	}
+	return false
+}
+
+func (m *SortRequest) GetMode() SortMode {
+	if m != nil {
+		return m.Mode
+	}
+	return SortMode_STRING
+}
+
+func (m *SortRequest) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *SortRequest) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *SortRequest) GetIgnoreCase() bool {
+	if m != nil {
+		return m.IgnoreCase
+	}
+	return false
+}
+
+func (m *SortRequest) GetIgnoreDiacritics() bool {
+	if m != nil {
+		return m.IgnoreDiacritics
+	}
+	return false
+}
+
+// Deprecated: Do not use.
+func (m *SortRequest) GetCaseInsensitive() bool {
+	if m != nil {
+		return m.CaseInsensitive
+	}
+	return false
+}
+
+func (m *SortRequest) GetReturnCounts() bool {
+	if m != nil {
+		return m.ReturnCounts
+	}
+	return false
+}
+
+func (m *SortRequest) GetPreserveOrder() bool {
+	if m != nil {
+		return m.PreserveOrder
+	}
+	return false
+}
+
+type SortResponse struct {
+	Items []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// with return_counts, how many of the given items each of the items stands for (all 1 without
+	// remove_duplicates)
+	Counts               []int64  `protobuf:"varint,2,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SortResponse) Reset()         { *m = SortResponse{} }
+func (m *SortResponse) String() string { return proto.CompactTextString(m) }
+func (*SortResponse) ProtoMessage()    {}
+func (*SortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{97}
+}
+
+func (m *SortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SortResponse.Unmarshal(m, b)
+}
+func (m *SortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SortResponse.Marshal(b, m, deterministic)
+}
+func (m *SortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortResponse.Merge(m, src)
+}
+func (m *SortResponse) XXX_Size() int {
+	return xxx_messageInfo_SortResponse.Size(m)
+}
+func (m *SortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortResponse proto.InternalMessageInfo
+
+func (m *SortResponse) GetItems() []string {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *SortResponse) GetCounts() []int64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type StartSeasonRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartSeasonRequest) Reset()         { *m = StartSeasonRequest{} }
+func (m *StartSeasonRequest) String() string { return proto.CompactTextString(m) }
+func (*StartSeasonRequest) ProtoMessage()    {}
+func (*StartSeasonRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{98}
+}
+
+func (m *StartSeasonRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSeasonRequest.Unmarshal(m, b)
+}
+func (m *StartSeasonRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSeasonRequest.Marshal(b, m, deterministic)
+}
+func (m *StartSeasonRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSeasonRequest.Merge(m, src)
+}
+func (m *StartSeasonRequest) XXX_Size() int {
+	return xxx_messageInfo_StartSeasonRequest.Size(m)
+}
+func (m *StartSeasonRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSeasonRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartSeasonRequest proto.InternalMessageInfo
+
+func (m *StartSeasonRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type StartSeasonResponse struct {
+	Season               *Season  `protobuf:"bytes,1,opt,name=season,proto3" json:"season,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartSeasonResponse) Reset()         { *m = StartSeasonResponse{} }
+func (m *StartSeasonResponse) String() string { return proto.CompactTextString(m) }
+func (*StartSeasonResponse) ProtoMessage()    {}
+func (*StartSeasonResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{99}
+}
+
+func (m *StartSeasonResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSeasonResponse.Unmarshal(m, b)
+}
+func (m *StartSeasonResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSeasonResponse.Marshal(b, m, deterministic)
+}
+func (m *StartSeasonResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSeasonResponse.Merge(m, src)
+}
+func (m *StartSeasonResponse) XXX_Size() int {
+	return xxx_messageInfo_StartSeasonResponse.Size(m)
+}
+func (m *StartSeasonResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSeasonResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartSeasonResponse proto.InternalMessageInfo
+
+func (m *StartSeasonResponse) GetSeason() *Season {
+	if m != nil {
+		return m.Season
+	}
+	return nil
+}
+
+type GetUpcomingBirthdaysRequest struct {
+	// birthdays from the reference day (0) up to days later, at most 365
+	Days int64 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// unixnano within the reference day, the database's current date by default
+	From                 *OptInt64 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Limit                int64     `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetUpcomingBirthdaysRequest) Reset()         { *m = GetUpcomingBirthdaysRequest{} }
+func (m *GetUpcomingBirthdaysRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUpcomingBirthdaysRequest) ProtoMessage()    {}
+func (*GetUpcomingBirthdaysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{100}
+}
+
+func (m *GetUpcomingBirthdaysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUpcomingBirthdaysRequest.Unmarshal(m, b)
+}
+func (m *GetUpcomingBirthdaysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUpcomingBirthdaysRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUpcomingBirthdaysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUpcomingBirthdaysRequest.Merge(m, src)
+}
+func (m *GetUpcomingBirthdaysRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUpcomingBirthdaysRequest.Size(m)
+}
+func (m *GetUpcomingBirthdaysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUpcomingBirthdaysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUpcomingBirthdaysRequest proto.InternalMessageInfo
+
+func (m *GetUpcomingBirthdaysRequest) GetDays() int64 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *GetUpcomingBirthdaysRequest) GetFrom() *OptInt64 {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *GetUpcomingBirthdaysRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type UpcomingBirthday struct {
+	Client *Client `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	// days from the reference day to the birthday, 0 for the day itself. Feb 29
+	// birthdays fall on Feb 28 in non-leap years
+	DaysUntil            int64    `protobuf:"varint,2,opt,name=days_until,json=daysUntil,proto3" json:"days_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpcomingBirthday) Reset()         { *m = UpcomingBirthday{} }
+func (m *UpcomingBirthday) String() string { return proto.CompactTextString(m) }
+func (*UpcomingBirthday) ProtoMessage()    {}
+func (*UpcomingBirthday) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{101}
+}
+
+func (m *UpcomingBirthday) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpcomingBirthday.Unmarshal(m, b)
+}
+func (m *UpcomingBirthday) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpcomingBirthday.Marshal(b, m, deterministic)
+}
+func (m *UpcomingBirthday) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpcomingBirthday.Merge(m, src)
+}
+func (m *UpcomingBirthday) XXX_Size() int {
+	return xxx_messageInfo_UpcomingBirthday.Size(m)
+}
+func (m *UpcomingBirthday) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpcomingBirthday.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpcomingBirthday proto.InternalMessageInfo
+
+func (m *UpcomingBirthday) GetClient() *Client {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *UpcomingBirthday) GetDaysUntil() int64 {
+	if m != nil {
+		return m.DaysUntil
+	}
+	return 0
+}
+
+// the soonest birthdays first
+type GetUpcomingBirthdaysResponse struct {
+	Birthdays            []*UpcomingBirthday `protobuf:"bytes,1,rep,name=birthdays,proto3" json:"birthdays,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetUpcomingBirthdaysResponse) Reset()         { *m = GetUpcomingBirthdaysResponse{} }
+func (m *GetUpcomingBirthdaysResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUpcomingBirthdaysResponse) ProtoMessage()    {}
+func (*GetUpcomingBirthdaysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{102}
+}
+
+func (m *GetUpcomingBirthdaysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUpcomingBirthdaysResponse.Unmarshal(m, b)
+}
+func (m *GetUpcomingBirthdaysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUpcomingBirthdaysResponse.Marshal(b, m, deterministic)
+}
+func (m *GetUpcomingBirthdaysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUpcomingBirthdaysResponse.Merge(m, src)
+}
+func (m *GetUpcomingBirthdaysResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUpcomingBirthdaysResponse.Size(m)
+}
+func (m *GetUpcomingBirthdaysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUpcomingBirthdaysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUpcomingBirthdaysResponse proto.InternalMessageInfo
+
+func (m *GetUpcomingBirthdaysResponse) GetBirthdays() []*UpcomingBirthday {
+	if m != nil {
+		return m.Birthdays
+	}
+	return nil
+}
+
+type FindDuplicateClientsRequest struct {
+	// also requires the same birth date; clients without a birthday are left out
+	MatchBirthday        bool     `protobuf:"varint,1,opt,name=match_birthday,json=matchBirthday,proto3" json:"match_birthday,omitempty"`
+	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindDuplicateClientsRequest) Reset()         { *m = FindDuplicateClientsRequest{} }
+func (m *FindDuplicateClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*FindDuplicateClientsRequest) ProtoMessage()    {}
+func (*FindDuplicateClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{103}
+}
+
+func (m *FindDuplicateClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindDuplicateClientsRequest.Unmarshal(m, b)
+}
+func (m *FindDuplicateClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindDuplicateClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *FindDuplicateClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindDuplicateClientsRequest.Merge(m, src)
+}
+func (m *FindDuplicateClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_FindDuplicateClientsRequest.Size(m)
+}
+func (m *FindDuplicateClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindDuplicateClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindDuplicateClientsRequest proto.InternalMessageInfo
+
+func (m *FindDuplicateClientsRequest) GetMatchBirthday() bool {
+	if m != nil {
+		return m.MatchBirthday
+	}
+	return false
+}
+
+func (m *FindDuplicateClientsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *FindDuplicateClientsRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type DuplicateClient struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Birthday             int64    `protobuf:"varint,5,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DuplicateClient) Reset()         { *m = DuplicateClient{} }
+func (m *DuplicateClient) String() string { return proto.CompactTextString(m) }
+func (*DuplicateClient) ProtoMessage()    {}
+func (*DuplicateClient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{104}
+}
+
+func (m *DuplicateClient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateClient.Unmarshal(m, b)
+}
+func (m *DuplicateClient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateClient.Marshal(b, m, deterministic)
+}
+func (m *DuplicateClient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateClient.Merge(m, src)
+}
+func (m *DuplicateClient) XXX_Size() int {
+	return xxx_messageInfo_DuplicateClient.Size(m)
+}
+func (m *DuplicateClient) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateClient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateClient proto.InternalMessageInfo
+
+func (m *DuplicateClient) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DuplicateClient) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DuplicateClient) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *DuplicateClient) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *DuplicateClient) GetBirthday() int64 {
+	if m != nil {
+		return m.Birthday
+	}
+	return 0
+}
+
+type DuplicateClientGroup struct {
+	// the name trimmed, lowercased and with its whitespace collapsed
+	NormalizedName       string             `protobuf:"bytes,1,opt,name=normalized_name,json=normalizedName,proto3" json:"normalized_name,omitempty"`
+	Birthday             int64              `protobuf:"varint,2,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Clients              []*DuplicateClient `protobuf:"bytes,3,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DuplicateClientGroup) Reset()         { *m = DuplicateClientGroup{} }
+func (m *DuplicateClientGroup) String() string { return proto.CompactTextString(m) }
+func (*DuplicateClientGroup) ProtoMessage()    {}
+func (*DuplicateClientGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{105}
+}
+
+func (m *DuplicateClientGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateClientGroup.Unmarshal(m, b)
+}
+func (m *DuplicateClientGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateClientGroup.Marshal(b, m, deterministic)
+}
+func (m *DuplicateClientGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateClientGroup.Merge(m, src)
+}
+func (m *DuplicateClientGroup) XXX_Size() int {
+	return xxx_messageInfo_DuplicateClientGroup.Size(m)
+}
+func (m *DuplicateClientGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateClientGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateClientGroup proto.InternalMessageInfo
+
+func (m *DuplicateClientGroup) GetNormalizedName() string {
+	if m != nil {
+		return m.NormalizedName
+	}
+	return ""
+}
+
+func (m *DuplicateClientGroup) GetBirthday() int64 {
+	if m != nil {
+		return m.Birthday
+	}
+	return 0
+}
+
+func (m *DuplicateClientGroup) GetClients() []*DuplicateClient {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+// the groups ordered by normalized_name (then birthday)
+type FindDuplicateClientsResponse struct {
+	Groups               []*DuplicateClientGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *FindDuplicateClientsResponse) Reset()         { *m = FindDuplicateClientsResponse{} }
+func (m *FindDuplicateClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*FindDuplicateClientsResponse) ProtoMessage()    {}
+func (*FindDuplicateClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b09ac349de90e68, []int{106}
+}
+
+func (m *FindDuplicateClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindDuplicateClientsResponse.Unmarshal(m, b)
+}
+func (m *FindDuplicateClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindDuplicateClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *FindDuplicateClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindDuplicateClientsResponse.Merge(m, src)
+}
+func (m *FindDuplicateClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_FindDuplicateClientsResponse.Size(m)
+}
+func (m *FindDuplicateClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindDuplicateClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindDuplicateClientsResponse proto.InternalMessageInfo
+
+func (m *FindDuplicateClientsResponse) GetGroups() []*DuplicateClientGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("pb.NameMatch", NameMatch_name, NameMatch_value)
+	proto.RegisterEnum("pb.ClientOrderBy", ClientOrderBy_name, ClientOrderBy_value)
+	proto.RegisterEnum("pb.ScoreHistoryBucket", ScoreHistoryBucket_name, ScoreHistoryBucket_value)
+	proto.RegisterEnum("pb.SortMode", SortMode_name, SortMode_value)
+	proto.RegisterType((*NewClientRequest)(nil), "pb.NewClientRequest")
+	proto.RegisterMapType((map[string]string)(nil), "pb.NewClientRequest.MetadataEntry")
+	proto.RegisterType((*NewClientResponse)(nil), "pb.NewClientResponse")
+	proto.RegisterType((*NewClientsRequest)(nil), "pb.NewClientsRequest")
+	proto.RegisterType((*NewClientsResponse)(nil), "pb.NewClientsResponse")
+	proto.RegisterType((*QueryClientsRequest)(nil), "pb.QueryClientsRequest")
+	proto.RegisterType((*ClientSortKey)(nil), "pb.ClientSortKey")
+	proto.RegisterType((*QueryClientsResponse)(nil), "pb.QueryClientsResponse")
+	proto.RegisterType((*QueryClientsPageToken)(nil), "pb.QueryClientsPageToken")
+	proto.RegisterType((*QueryClientsPageToken_Value)(nil), "pb.QueryClientsPageToken.Value")
+	proto.RegisterType((*CountClientsRequest)(nil), "pb.CountClientsRequest")
+	proto.RegisterType((*CountClientsResponse)(nil), "pb.CountClientsResponse")
+	proto.RegisterType((*SampleClientsRequest)(nil), "pb.SampleClientsRequest")
+	proto.RegisterType((*SampleClientsResponse)(nil), "pb.SampleClientsResponse")
+	proto.RegisterType((*SuggestClientsRequest)(nil), "pb.SuggestClientsRequest")
+	proto.RegisterType((*ClientSuggestion)(nil), "pb.ClientSuggestion")
+	proto.RegisterType((*SuggestClientsResponse)(nil), "pb.SuggestClientsResponse")
+	proto.RegisterType((*GetClientsRequest)(nil), "pb.GetClientsRequest")
+	proto.RegisterType((*GetClientsResponse)(nil), "pb.GetClientsResponse")
+	proto.RegisterType((*GetClientRequest)(nil), "pb.GetClientRequest")
+	proto.RegisterType((*GetClientResponse)(nil), "pb.GetClientResponse")
+	proto.RegisterType((*ClientExistsRequest)(nil), "pb.ClientExistsRequest")
+	proto.RegisterType((*ClientExistsResponse)(nil), "pb.ClientExistsResponse")
+	proto.RegisterType((*ClientsExistRequest)(nil), "pb.ClientsExistRequest")
+	proto.RegisterType((*ClientsExistResponse)(nil), "pb.ClientsExistResponse")
+	proto.RegisterType((*GetClientByEmailRequest)(nil), "pb.GetClientByEmailRequest")
+	proto.RegisterType((*GetClientByEmailResponse)(nil), "pb.GetClientByEmailResponse")
+	proto.RegisterType((*GetClientByExternalIdRequest)(nil), "pb.GetClientByExternalIdRequest")
+	proto.RegisterType((*GetClientByExternalIdResponse)(nil), "pb.GetClientByExternalIdResponse")
+	proto.RegisterType((*TouchClientRequest)(nil), "pb.TouchClientRequest")
+	proto.RegisterType((*TouchClientResponse)(nil), "pb.TouchClientResponse")
+	proto.RegisterType((*UpdateClientRequest)(nil), "pb.UpdateClientRequest")
+	proto.RegisterType((*UpdateClientResponse)(nil), "pb.UpdateClientResponse")
+	proto.RegisterType((*CloneClientRequest)(nil), "pb.CloneClientRequest")
+	proto.RegisterType((*CloneClientResponse)(nil), "pb.CloneClientResponse")
+	proto.RegisterType((*UpsertClientRequest)(nil), "pb.UpsertClientRequest")
+	proto.RegisterType((*UpsertClientResponse)(nil), "pb.UpsertClientResponse")
+	proto.RegisterType((*DeleteClientRequest)(nil), "pb.DeleteClientRequest")
+	proto.RegisterType((*DeleteClientResponse)(nil), "pb.DeleteClientResponse")
+	proto.RegisterType((*DeleteClientsRequest)(nil), "pb.DeleteClientsRequest")
+	proto.RegisterType((*DeleteClientsResponse)(nil), "pb.DeleteClientsResponse")
+	proto.RegisterType((*DeleteClientsByQueryRequest)(nil), "pb.DeleteClientsByQueryRequest")
+	proto.RegisterType((*DeleteClientsByQueryResponse)(nil), "pb.DeleteClientsByQueryResponse")
+	proto.RegisterType((*RestoreClientRequest)(nil), "pb.RestoreClientRequest")
+	proto.RegisterType((*RestoreClientResponse)(nil), "pb.RestoreClientResponse")
+	proto.RegisterType((*GetArchivedClientRequest)(nil), "pb.GetArchivedClientRequest")
+	proto.RegisterType((*GetArchivedClientResponse)(nil), "pb.GetArchivedClientResponse")
+	proto.RegisterType((*PurgeDeletedClientsRequest)(nil), "pb.PurgeDeletedClientsRequest")
+	proto.RegisterType((*PurgeDeletedClientsResponse)(nil), "pb.PurgeDeletedClientsResponse")
+	proto.RegisterType((*DeleteAllClientsRequest)(nil), "pb.DeleteAllClientsRequest")
+	proto.RegisterType((*DeleteAllClientsResponse)(nil), "pb.DeleteAllClientsResponse")
+	proto.RegisterType((*AddClientTagsRequest)(nil), "pb.AddClientTagsRequest")
+	proto.RegisterType((*AddClientTagsResponse)(nil), "pb.AddClientTagsResponse")
+	proto.RegisterType((*RemoveClientTagsRequest)(nil), "pb.RemoveClientTagsRequest")
+	proto.RegisterType((*RemoveClientTagsResponse)(nil), "pb.RemoveClientTagsResponse")
+	proto.RegisterType((*SetClientStatusRequest)(nil), "pb.SetClientStatusRequest")
+	proto.RegisterType((*SetClientStatusResponse)(nil), "pb.SetClientStatusResponse")
+	proto.RegisterType((*NewMatchRequest)(nil), "pb.NewMatchRequest")
+	proto.RegisterType((*NewMatchResponse)(nil), "pb.NewMatchResponse")
+	proto.RegisterType((*NewMatchesRequest)(nil), "pb.NewMatchesRequest")
+	proto.RegisterType((*NewMatchesResponse)(nil), "pb.NewMatchesResponse")
+	proto.RegisterType((*GetMatchRequest)(nil), "pb.GetMatchRequest")
+	proto.RegisterType((*GetMatchResponse)(nil), "pb.GetMatchResponse")
+	proto.RegisterType((*UpdateMatchRequest)(nil), "pb.UpdateMatchRequest")
+	proto.RegisterType((*UpdateMatchResponse)(nil), "pb.UpdateMatchResponse")
+	proto.RegisterType((*DeleteMatchRequest)(nil), "pb.DeleteMatchRequest")
+	proto.RegisterType((*DeleteMatchResponse)(nil), "pb.DeleteMatchResponse")
+	proto.RegisterType((*UndoLastMatchRequest)(nil), "pb.UndoLastMatchRequest")
+	proto.RegisterType((*UndoLastMatchResponse)(nil), "pb.UndoLastMatchResponse")
+	proto.RegisterType((*DeleteMatchesOlderThanRequest)(nil), "pb.DeleteMatchesOlderThanRequest")
+	proto.RegisterType((*DeleteMatchesOlderThanResponse)(nil), "pb.DeleteMatchesOlderThanResponse")
+	proto.RegisterType((*ListMatchesRequest)(nil), "pb.ListMatchesRequest")
+	proto.RegisterType((*ListMatchesResponse)(nil), "pb.ListMatchesResponse")
+	proto.RegisterType((*GetTopMatchesRequest)(nil), "pb.GetTopMatchesRequest")
+	proto.RegisterType((*GetTopMatchesResponse)(nil), "pb.GetTopMatchesResponse")
+	proto.RegisterType((*StreamMatchesRequest)(nil), "pb.StreamMatchesRequest")
+	proto.RegisterType((*GetHeadToHeadRequest)(nil), "pb.GetHeadToHeadRequest")
+	proto.RegisterType((*GetHeadToHeadResponse)(nil), "pb.GetHeadToHeadResponse")
+	proto.RegisterType((*GetClientStatsRequest)(nil), "pb.GetClientStatsRequest")
+	proto.RegisterType((*GetClientStatsResponse)(nil), "pb.GetClientStatsResponse")
+	proto.RegisterType((*RecalculateScoreRequest)(nil), "pb.RecalculateScoreRequest")
+	proto.RegisterType((*ScoreDrift)(nil), "pb.ScoreDrift")
+	proto.RegisterType((*RecalculateScoreResponse)(nil), "pb.RecalculateScoreResponse")
+	proto.RegisterType((*GetScoreHistoryRequest)(nil), "pb.GetScoreHistoryRequest")
+	proto.RegisterType((*ScorePoint)(nil), "pb.ScorePoint")
+	proto.RegisterType((*GetScoreHistoryResponse)(nil), "pb.GetScoreHistoryResponse")
+	proto.RegisterType((*GetClientsStatsRequest)(nil), "pb.GetClientsStatsRequest")
+	proto.RegisterType((*GetClientsStatsResponse)(nil), "pb.GetClientsStatsResponse")
+	proto.RegisterMapType((map[string]*ClientStats)(nil), "pb.GetClientsStatsResponse.StatsEntry")
+	proto.RegisterType((*GetLeaderboardRequest)(nil), "pb.GetLeaderboardRequest")
+	proto.RegisterType((*LeaderboardEntry)(nil), "pb.LeaderboardEntry")
+	proto.RegisterType((*GetLeaderboardResponse)(nil), "pb.GetLeaderboardResponse")
+	proto.RegisterType((*GetLeaderboardForPeriodRequest)(nil), "pb.GetLeaderboardForPeriodRequest")
+	proto.RegisterType((*PeriodLeaderboardEntry)(nil), "pb.PeriodLeaderboardEntry")
+	proto.RegisterType((*GetLeaderboardForPeriodResponse)(nil), "pb.GetLeaderboardForPeriodResponse")
+	proto.RegisterType((*AnonymizeClientRequest)(nil), "pb.AnonymizeClientRequest")
+	proto.RegisterType((*AnonymizeClientResponse)(nil), "pb.AnonymizeClientResponse")
+	proto.RegisterType((*MergeClientsRequest)(nil), "pb.MergeClientsRequest")
+	proto.RegisterType((*MergeClientsResponse)(nil), "pb.MergeClientsResponse")
+	proto.RegisterType((*SortRequest)(nil), "pb.SortRequest")
+	proto.RegisterType((*SortResponse)(nil), "pb.SortResponse")
+	proto.RegisterType((*StartSeasonRequest)(nil), "pb.StartSeasonRequest")
+	proto.RegisterType((*StartSeasonResponse)(nil), "pb.StartSeasonResponse")
+	proto.RegisterType((*GetUpcomingBirthdaysRequest)(nil), "pb.GetUpcomingBirthdaysRequest")
+	proto.RegisterType((*UpcomingBirthday)(nil), "pb.UpcomingBirthday")
+	proto.RegisterType((*GetUpcomingBirthdaysResponse)(nil), "pb.GetUpcomingBirthdaysResponse")
+	proto.RegisterType((*FindDuplicateClientsRequest)(nil), "pb.FindDuplicateClientsRequest")
+	proto.RegisterType((*DuplicateClient)(nil), "pb.DuplicateClient")
+	proto.RegisterType((*DuplicateClientGroup)(nil), "pb.DuplicateClientGroup")
+	proto.RegisterType((*FindDuplicateClientsResponse)(nil), "pb.FindDuplicateClientsResponse")
+}
+
+func init() { proto.RegisterFile("clservice.proto", fileDescriptor_1b09ac349de90e68) }
+
+var fileDescriptor_1b09ac349de90e68 = []byte{
+	// 4680 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3b, 0x5d, 0x73, 0xdb, 0x48,
+	0x72, 0xe2, 0x87, 0x28, 0xb2, 0xa9, 0x0f, 0x7a, 0x44, 0x49, 0x30, 0x65, 0xaf, 0xe4, 0xf1, 0xc7,
+	0xca, 0xbb, 0x6b, 0x79, 0xe3, 0xbd, 0xfd, 0xb8, 0xbd, 0xfd, 0x08, 0x25, 0xcb, 0x5e, 0xed, 0x5a,
+	0xb2, 0x0f, 0xa2, 0xce, 0x9b, 0x6c, 0x72, 0x2c, 0x88, 0x18, 0x49, 0x28, 0x83, 0x00, 0x0f, 0x00,
+	0x6d, 0xf3, 0x2a, 0xa9, 0x54, 0x52, 0x49, 0xaa, 0x92, 0xc7, 0x3c, 0x24, 0xef, 0x79, 0xca, 0x5b,
+	0x7e, 0x42, 0xfe, 0x40, 0x1e, 0xf3, 0x96, 0x3f, 0x91, 0x54, 0xfe, 0x40, 0x6a, 0xa6, 0x07, 0xc0,
+	0x00, 0x18, 0x4a, 0x72, 0x72, 0x55, 0xf7, 0x62, 0x13, 0x3d, 0x3d, 0x3d, 0x3d, 0x3d, 0xdd, 0x33,
+	0xfd, 0x25, 0x58, 0x1a, 0xb8, 0x21, 0x0b, 0x5e, 0x3b, 0x03, 0xb6, 0x3d, 0x0a, 0xfc, 0xc8, 0x27,
+	0xe5, 0xd1, 0x49, 0x67, 0x61, 0xe0, 0x46, 0x93, 0x11, 0x0b, 0x11, 0xd4, 0xd9, 0x3c, 0xf3, 0xfd,
+	0x33, 0x97, 0x3d, 0x14, 0x5f, 0x27, 0xe3, 0xd3, 0x87, 0xa7, 0x0e, 0x73, 0xed, 0xfe, 0xd0, 0x0a,
+	0x5f, 0x21, 0x06, 0xfd, 0xef, 0x32, 0xb4, 0x0e, 0xd9, 0x9b, 0x5d, 0xd7, 0x61, 0x5e, 0x64, 0xb2,
+	0xdf, 0x8c, 0x59, 0x18, 0x11, 0x02, 0x55, 0xcf, 0x1a, 0x32, 0xa3, 0xb4, 0x59, 0xda, 0x6a, 0x98,
+	0xe2, 0x37, 0xe9, 0x40, 0xfd, 0xc4, 0x09, 0xa2, 0x73, 0xdb, 0x9a, 0x18, 0xe5, 0xcd, 0xd2, 0x56,
+	0xc5, 0x4c, 0xbe, 0x49, 0x1b, 0x66, 0xc3, 0x81, 0x1f, 0x30, 0xa3, 0x22, 0x06, 0xf0, 0x83, 0xbc,
+	0x0f, 0x4b, 0x8e, 0xcd, 0x86, 0x23, 0x3f, 0x62, 0xde, 0x60, 0xd2, 0x7f, 0xc5, 0x26, 0x46, 0x55,
+	0x10, 0x5c, 0x54, 0xc0, 0x3f, 0x30, 0x31, 0x9d, 0x0d, 0x2d, 0xc7, 0x35, 0x66, 0xc5, 0x30, 0x7e,
+	0x70, 0xe8, 0xe8, 0xdc, 0xf7, 0x98, 0x51, 0x43, 0xa8, 0xf8, 0x20, 0xdf, 0x40, 0x7d, 0xc8, 0x22,
+	0xcb, 0xb6, 0x22, 0xcb, 0x98, 0xdb, 0xac, 0x6c, 0x35, 0x1f, 0xd1, 0xed, 0xd1, 0xc9, 0x76, 0x7e,
+	0x0b, 0xdb, 0x07, 0x12, 0x69, 0xcf, 0x8b, 0x82, 0x89, 0x99, 0xcc, 0xe1, 0x54, 0x3d, 0x3f, 0x62,
+	0xa1, 0x51, 0x47, 0xaa, 0xe2, 0x83, 0x6c, 0x40, 0x93, 0xbd, 0x8d, 0x58, 0xe0, 0x59, 0x6e, 0xdf,
+	0xb1, 0x8d, 0x86, 0x18, 0x83, 0x18, 0xb4, 0x6f, 0x93, 0x45, 0x28, 0x3b, 0xb6, 0x01, 0x02, 0x5e,
+	0x76, 0xec, 0xce, 0x2f, 0x60, 0x21, 0xb3, 0x02, 0x69, 0x41, 0x85, 0x6f, 0x10, 0x25, 0xc6, 0x7f,
+	0xf2, 0x95, 0x5e, 0x5b, 0xee, 0x98, 0x09, 0x69, 0x35, 0x4c, 0xfc, 0xf8, 0xb2, 0xfc, 0x45, 0x89,
+	0x3e, 0x85, 0x6b, 0x0a, 0xbf, 0xe1, 0xc8, 0xf7, 0x42, 0x26, 0x57, 0x28, 0xc5, 0x2b, 0x10, 0x0a,
+	0xb5, 0x81, 0xc0, 0x10, 0xf3, 0x9b, 0x8f, 0x80, 0x6f, 0x53, 0xce, 0x91, 0x23, 0x74, 0x57, 0x21,
+	0x14, 0xc6, 0x87, 0xb7, 0x0d, 0x73, 0x38, 0x1c, 0x1a, 0x25, 0x21, 0xa0, 0xb6, 0x4e, 0x40, 0x66,
+	0x8c, 0x44, 0x0f, 0x80, 0xa8, 0x44, 0x24, 0x3b, 0x2d, 0xa8, 0x38, 0x36, 0x52, 0x68, 0x98, 0xfc,
+	0x27, 0xb9, 0x0b, 0x8b, 0xa7, 0x96, 0xe3, 0x32, 0xbb, 0xef, 0x78, 0x36, 0x7b, 0xcb, 0x42, 0xa3,
+	0xbc, 0x59, 0xd9, 0xaa, 0x98, 0x0b, 0x08, 0xdd, 0x47, 0x20, 0xfd, 0x87, 0x26, 0x2c, 0xff, 0x72,
+	0xcc, 0x82, 0x49, 0x8e, 0xad, 0x9b, 0xc9, 0xfe, 0x9a, 0x8f, 0x16, 0x38, 0x47, 0xcf, 0x47, 0xd1,
+	0x51, 0x14, 0x38, 0xde, 0x99, 0xd8, 0xee, 0x2d, 0xa9, 0x72, 0x65, 0x1d, 0x02, 0x6a, 0xe0, 0x7d,
+	0x45, 0x03, 0x2b, 0x29, 0xda, 0xbe, 0x17, 0x7d, 0xf6, 0xb3, 0x5d, 0x7f, 0x38, 0x52, 0x14, 0xf2,
+	0x76, 0xac, 0x90, 0x55, 0x1d, 0x9e, 0xd4, 0xcf, 0x8f, 0x00, 0x06, 0x01, 0xb3, 0x22, 0x66, 0xf7,
+	0xad, 0x48, 0xe8, 0x5e, 0x01, 0xb3, 0x21, 0x11, 0xba, 0x11, 0x27, 0x89, 0x4a, 0x5a, 0xd3, 0x71,
+	0x28, 0x75, 0xf6, 0x76, 0xac, 0xb3, 0x73, 0x5a, 0x24, 0x54, 0x61, 0x02, 0xd5, 0xc8, 0x3a, 0xe3,
+	0x1a, 0xc8, 0x65, 0x2b, 0x7e, 0x93, 0x3b, 0xb0, 0xc8, 0xff, 0xef, 0x0f, 0xad, 0x68, 0x70, 0xde,
+	0xb7, 0x5c, 0x57, 0xe8, 0x60, 0xdd, 0x9c, 0xe7, 0xd0, 0x03, 0x0e, 0xec, 0xba, 0x2e, 0xe7, 0x78,
+	0x3c, 0xb2, 0x63, 0x8e, 0x41, 0xcb, 0xb1, 0x44, 0xe8, 0x46, 0x64, 0x0b, 0x6a, 0x61, 0x64, 0x45,
+	0xe3, 0xd0, 0x68, 0x6e, 0x56, 0xb6, 0x16, 0x1f, 0xb5, 0x52, 0x0d, 0x3a, 0x12, 0x70, 0x53, 0x8e,
+	0x93, 0xed, 0xac, 0xfa, 0xcf, 0xeb, 0x98, 0x57, 0xad, 0xe1, 0x21, 0xcc, 0xbb, 0x56, 0x18, 0xf5,
+	0x43, 0xc6, 0x3c, 0xce, 0xc9, 0x82, 0x8e, 0x13, 0xe0, 0x28, 0x47, 0x8c, 0x79, 0xdd, 0x88, 0xdb,
+	0x82, 0xeb, 0x0c, 0x9d, 0xc8, 0x58, 0xc4, 0x0b, 0x42, 0x7c, 0x90, 0x55, 0xa8, 0xf9, 0xa7, 0xa7,
+	0x21, 0x8b, 0x8c, 0x25, 0x01, 0x96, 0x5f, 0xe4, 0x3a, 0xd4, 0x3d, 0xbf, 0x8f, 0x13, 0x5a, 0x42,
+	0x0c, 0x73, 0x9e, 0xff, 0x4c, 0x4c, 0xb9, 0x09, 0x30, 0xb2, 0xce, 0x58, 0x3f, 0xf2, 0x5f, 0x31,
+	0xcf, 0xb8, 0x26, 0xac, 0xa5, 0xc1, 0x21, 0x3d, 0x0e, 0x20, 0xdb, 0xb0, 0xec, 0x78, 0x03, 0x77,
+	0x6c, 0x73, 0x8c, 0xc8, 0x72, 0xfb, 0x03, 0x7f, 0xec, 0x45, 0x06, 0x11, 0x44, 0xae, 0xc9, 0xa1,
+	0x1e, 0x1f, 0xd9, 0xe5, 0x03, 0xe4, 0x23, 0xa8, 0xfb, 0x81, 0xcd, 0x82, 0xfe, 0xc9, 0xc4, 0x58,
+	0xde, 0x2c, 0x6d, 0x2d, 0x3e, 0xba, 0x96, 0x0a, 0xe9, 0x39, 0x1f, 0xd9, 0x99, 0x98, 0x73, 0x3e,
+	0xfe, 0x20, 0x37, 0xa0, 0x61, 0x85, 0x03, 0xe6, 0xd9, 0x8e, 0x77, 0x66, 0xb4, 0x05, 0xcd, 0x14,
+	0x40, 0xee, 0x42, 0x35, 0xf4, 0x83, 0xc8, 0x58, 0x11, 0x46, 0xa7, 0xd0, 0x39, 0xf2, 0x83, 0xe8,
+	0x07, 0x36, 0x31, 0xc5, 0x30, 0x3f, 0x43, 0xae, 0xcd, 0x78, 0xd2, 0xc6, 0xaa, 0x58, 0x54, 0x48,
+	0xee, 0xd0, 0x1a, 0x32, 0x71, 0xd2, 0x66, 0xc3, 0x8b, 0x7f, 0x72, 0x11, 0x85, 0xcc, 0x0a, 0x06,
+	0xe7, 0xc6, 0x9a, 0xd8, 0xab, 0xfc, 0x22, 0xf7, 0xa1, 0x21, 0x94, 0xb8, 0x3f, 0x74, 0x3c, 0xc3,
+	0x10, 0xe2, 0x9f, 0x97, 0xe7, 0x25, 0x4e, 0xc0, 0xac, 0x8b, 0xe1, 0x03, 0xc7, 0x53, 0x50, 0xad,
+	0xb7, 0xc6, 0xf5, 0xe9, 0xa8, 0xd6, 0x5b, 0xf2, 0x07, 0xb0, 0x10, 0x9b, 0x50, 0xff, 0x34, 0xf0,
+	0x87, 0x46, 0x47, 0x83, 0x3e, 0x1f, 0xa3, 0x3c, 0x09, 0xfc, 0x21, 0x79, 0x00, 0xcd, 0x64, 0x4a,
+	0xe4, 0x1b, 0xeb, 0x9a, 0x09, 0x10, 0x23, 0xf4, 0xfc, 0xf8, 0x5a, 0xb9, 0x91, 0x5e, 0x2b, 0x9f,
+	0x42, 0x2b, 0x21, 0xe0, 0x84, 0x7d, 0x6f, 0xec, 0xba, 0xc6, 0x4d, 0x41, 0xa5, 0x29, 0xa9, 0xec,
+	0xf8, 0xbe, 0x6b, 0x2e, 0xc6, 0x48, 0xfb, 0xe1, 0xe1, 0xd8, 0x75, 0xf9, 0x6d, 0x14, 0x1b, 0xef,
+	0x1b, 0x27, 0x3a, 0x77, 0x3c, 0xe3, 0x3d, 0xa1, 0x43, 0x0b, 0x12, 0xfa, 0x52, 0x00, 0xc9, 0x57,
+	0xb0, 0x70, 0xea, 0xb8, 0x11, 0x0b, 0xfa, 0x67, 0x81, 0x3f, 0x1e, 0x85, 0xc6, 0x86, 0x38, 0x9d,
+	0x35, 0x4e, 0x5a, 0x73, 0x4b, 0x99, 0xf3, 0x88, 0xfd, 0x54, 0x20, 0x8b, 0x17, 0x4c, 0xaa, 0x93,
+	0xcd, 0x5c, 0x16, 0x31, 0xdb, 0xd8, 0x14, 0xc7, 0xbe, 0x28, 0xc1, 0x8f, 0x11, 0xca, 0xb9, 0x09,
+	0x58, 0x34, 0x0e, 0xbc, 0x7e, 0x7c, 0xf5, 0xde, 0x12, 0x78, 0x0b, 0x08, 0x95, 0x8b, 0x90, 0xbb,
+	0x30, 0xc7, 0x95, 0x97, 0x9f, 0x19, 0xd5, 0x08, 0xaa, 0x66, 0x9d, 0x89, 0x13, 0x8b, 0xd1, 0xac,
+	0xb7, 0xc6, 0xed, 0x69, 0x68, 0xd6, 0x5b, 0x72, 0x1f, 0x5a, 0x96, 0xeb, 0xfa, 0x6f, 0xfa, 0x63,
+	0x0f, 0xb9, 0x66, 0xb6, 0x71, 0x47, 0x2c, 0xbb, 0x24, 0xe0, 0xc7, 0x09, 0x98, 0xfe, 0x08, 0x0b,
+	0x19, 0x5d, 0x24, 0xf7, 0xa1, 0x36, 0xf0, 0xdd, 0xf1, 0xd0, 0x13, 0x37, 0xb2, 0x56, 0xed, 0x25,
+	0x42, 0x56, 0xeb, 0xcb, 0x39, 0xad, 0xa7, 0xff, 0x54, 0x82, 0x76, 0x56, 0x90, 0x53, 0x1f, 0x90,
+	0x7b, 0xb0, 0xe4, 0xb1, 0xb7, 0x51, 0x5f, 0x31, 0x60, 0x7c, 0x1a, 0x17, 0x38, 0xf8, 0x45, 0x62,
+	0xc4, 0x1b, 0xd0, 0x54, 0x8d, 0x17, 0x7d, 0x0a, 0x88, 0x52, 0xab, 0xbd, 0x93, 0xbe, 0x70, 0x55,
+	0x71, 0x9c, 0xea, 0xdb, 0x98, 0xbc, 0x6b, 0xff, 0x55, 0x82, 0x15, 0x95, 0xb3, 0x74, 0x81, 0xfc,
+	0x53, 0xbb, 0x01, 0x4d, 0xa9, 0x24, 0xe7, 0x56, 0x78, 0x2e, 0xde, 0x8c, 0x9a, 0x09, 0x08, 0xfa,
+	0xce, 0x0a, 0xcf, 0xc9, 0xe7, 0x50, 0x13, 0xaf, 0x77, 0x68, 0xd4, 0xc4, 0x7a, 0x1b, 0x79, 0xf5,
+	0x49, 0x68, 0x6f, 0xff, 0x8a, 0xe3, 0x99, 0x12, 0xbd, 0xf3, 0x13, 0xcc, 0x0a, 0x00, 0x59, 0x87,
+	0x86, 0xe3, 0x45, 0x7d, 0x74, 0x08, 0x4a, 0xe8, 0x3e, 0x39, 0x5e, 0x84, 0x83, 0xb7, 0x60, 0x3e,
+	0x14, 0x97, 0x6c, 0x5f, 0x75, 0x18, 0x9a, 0x08, 0x43, 0x14, 0xee, 0x91, 0x71, 0xcb, 0xa8, 0x08,
+	0xf9, 0x8b, 0xdf, 0xdf, 0x57, 0xeb, 0xe5, 0x56, 0xe5, 0xfb, 0x6a, 0xbd, 0xd2, 0xaa, 0x7e, 0x5f,
+	0xad, 0xcf, 0xb6, 0x6a, 0xf4, 0x09, 0x2c, 0x0b, 0x09, 0xe5, 0x9e, 0xde, 0x87, 0x50, 0xc3, 0xcd,
+	0xc8, 0xe7, 0x77, 0xaa, 0xf6, 0x4b, 0x34, 0xfa, 0x11, 0xb4, 0xb3, 0x74, 0xe4, 0x99, 0xb6, 0x61,
+	0x16, 0xcf, 0x04, 0x77, 0x80, 0x1f, 0xf4, 0x18, 0xda, 0x47, 0xd6, 0x70, 0xe4, 0xb2, 0xff, 0xe7,
+	0xb2, 0x64, 0x1e, 0x4a, 0x9e, 0xf4, 0x2d, 0x4b, 0x1e, 0xbd, 0x0f, 0x2b, 0x39, 0xb2, 0xd3, 0x34,
+	0x8b, 0x86, 0xb0, 0x72, 0x34, 0x3e, 0x3b, 0x63, 0x61, 0x7e, 0xe7, 0xab, 0x50, 0x1b, 0x05, 0xec,
+	0xd4, 0x79, 0x2b, 0x4f, 0x5b, 0x7e, 0xa5, 0xef, 0x51, 0x59, 0x7d, 0x8f, 0xd4, 0xd7, 0xa0, 0x72,
+	0xd9, 0x6b, 0x40, 0x3f, 0x83, 0x96, 0xb4, 0x29, 0x5c, 0xda, 0xf1, 0x8b, 0x9a, 0x45, 0x14, 0xaf,
+	0x46, 0x3a, 0xd2, 0xf4, 0x05, 0xac, 0xe6, 0x99, 0x95, 0x1b, 0xfb, 0x0c, 0x9a, 0x61, 0x42, 0x2b,
+	0xe3, 0xbd, 0xe5, 0x17, 0x32, 0x55, 0x44, 0xfa, 0x2f, 0x25, 0xb8, 0xf6, 0x94, 0xe5, 0xf7, 0x5e,
+	0x34, 0x40, 0xcd, 0x75, 0x56, 0xd6, 0x5e, 0x67, 0x9f, 0x43, 0x23, 0x60, 0x16, 0xc6, 0x09, 0xd2,
+	0xd5, 0xea, 0x6c, 0x63, 0x28, 0xb1, 0x1d, 0x87, 0x12, 0xdb, 0x4f, 0x78, 0x28, 0x71, 0x60, 0x85,
+	0xaf, 0xcc, 0x3a, 0x47, 0xe6, 0xbf, 0xb8, 0x25, 0x05, 0xec, 0x37, 0x63, 0x27, 0x60, 0xc2, 0x87,
+	0xa9, 0x0a, 0xea, 0x20, 0x41, 0x5d, 0xd7, 0xa5, 0x3f, 0x01, 0x51, 0x39, 0x95, 0x1b, 0xbf, 0x93,
+	0x77, 0x59, 0x75, 0x06, 0xcd, 0x89, 0x0f, 0x9d, 0x30, 0xe4, 0x76, 0xc2, 0x37, 0x56, 0x16, 0x1b,
+	0x03, 0x09, 0xda, 0xb7, 0x43, 0x4a, 0xa1, 0x95, 0x10, 0x8f, 0xa5, 0x90, 0x3b, 0x11, 0xfa, 0xb9,
+	0x22, 0xaa, 0x64, 0xfd, 0xd4, 0xd7, 0x2e, 0x4d, 0xf5, 0xb5, 0xef, 0xc2, 0x32, 0x42, 0xf6, 0xde,
+	0x3a, 0x61, 0x2a, 0xe5, 0x3c, 0xfd, 0x6d, 0x68, 0x67, 0xd1, 0xe4, 0x12, 0xab, 0x50, 0x63, 0x02,
+	0x22, 0x70, 0xeb, 0xa6, 0xfc, 0xa2, 0xef, 0xc7, 0x64, 0x43, 0x31, 0x61, 0xea, 0xe1, 0xd1, 0xad,
+	0x98, 0x70, 0x8c, 0x38, 0xd5, 0x1a, 0x1e, 0xc2, 0x5a, 0xb2, 0xc5, 0x9d, 0xc9, 0x1e, 0x77, 0x4c,
+	0x63, 0xb2, 0x49, 0xa4, 0x55, 0x52, 0x22, 0x2d, 0xfa, 0x0d, 0x18, 0xc5, 0x09, 0xef, 0x20, 0x9a,
+	0x6f, 0xe1, 0x86, 0x3a, 0x3f, 0xf1, 0x13, 0xe3, 0x55, 0x73, 0xd1, 0x55, 0x29, 0x1f, 0x5d, 0xd1,
+	0x5d, 0xb8, 0x39, 0x85, 0xc0, 0x3b, 0x70, 0x71, 0x07, 0x48, 0xcf, 0x1f, 0x0f, 0xce, 0x2f, 0x3e,
+	0xff, 0x15, 0x58, 0xce, 0x60, 0xe1, 0x02, 0xf4, 0xdf, 0x2a, 0xb0, 0x7c, 0x2c, 0x3c, 0xe7, 0x0b,
+	0xa7, 0x5f, 0x25, 0x4c, 0xd9, 0x2a, 0x84, 0x29, 0x39, 0x77, 0x2b, 0x89, 0x52, 0x68, 0x36, 0x4a,
+	0xc9, 0xa2, 0xc9, 0x20, 0xe5, 0xb6, 0x1a, 0x1b, 0x5f, 0x1a, 0x76, 0xd4, 0x2e, 0x08, 0x3b, 0x3e,
+	0xca, 0x44, 0xce, 0x1c, 0xaf, 0x95, 0xc1, 0x3b, 0xb0, 0x46, 0x4a, 0x9c, 0x9c, 0x4a, 0xbc, 0x3e,
+	0x4d, 0xe2, 0xe4, 0x17, 0xd0, 0xc4, 0x68, 0x03, 0x2f, 0x8a, 0xc6, 0xa5, 0x17, 0x85, 0x8c, 0x5e,
+	0xc4, 0x55, 0x71, 0x1f, 0x5a, 0xec, 0xed, 0x88, 0x0d, 0xb8, 0x07, 0xf7, 0x9a, 0x05, 0xa1, 0xe3,
+	0x7b, 0x22, 0xa2, 0xa9, 0x98, 0x4b, 0x31, 0xfc, 0x57, 0x08, 0xe6, 0xdb, 0xc3, 0x98, 0xbd, 0xa9,
+	0xdd, 0x9e, 0x18, 0xa3, 0x5f, 0x42, 0x3b, 0x7b, 0x80, 0xef, 0xa0, 0x3a, 0xff, 0x58, 0x02, 0xb2,
+	0xeb, 0xfa, 0x5e, 0xee, 0xf0, 0xd7, 0xa1, 0x11, 0xfa, 0xe3, 0x60, 0xc0, 0x52, 0xad, 0xad, 0x23,
+	0x60, 0xff, 0x4a, 0x9a, 0x70, 0x13, 0x60, 0xe0, 0x8f, 0x26, 0xfd, 0x34, 0x37, 0x52, 0x37, 0x1b,
+	0x1c, 0x72, 0x24, 0x8e, 0xf6, 0x16, 0xcc, 0x8b, 0x61, 0x11, 0x09, 0xb0, 0x50, 0xde, 0x96, 0x4d,
+	0x0e, 0x3b, 0x40, 0x10, 0xfd, 0x39, 0xbf, 0x1d, 0x14, 0xbe, 0xde, 0x61, 0x4f, 0xaf, 0xb8, 0x42,
+	0x87, 0x2c, 0xb8, 0xf8, 0x3e, 0xd4, 0xbd, 0x50, 0x99, 0x54, 0x4f, 0x65, 0x5a, 0xaa, 0xa7, 0xaa,
+	0xa4, 0x7a, 0xe8, 0xc7, 0x5c, 0xf8, 0xea, 0x62, 0x92, 0x51, 0x03, 0xe6, 0xa4, 0x3f, 0x2e, 0xaf,
+	0xbd, 0xf8, 0x93, 0x0e, 0x60, 0x19, 0x5f, 0x9b, 0x8b, 0xd9, 0x6b, 0xc3, 0xec, 0xa9, 0x1f, 0x0c,
+	0x98, 0x7c, 0xa8, 0xf0, 0x83, 0x7b, 0x92, 0xa7, 0x96, 0xe3, 0xf6, 0x9d, 0xd3, 0x44, 0x78, 0x28,
+	0x5d, 0x91, 0x8b, 0xd8, 0x3f, 0x8d, 0xc5, 0xf7, 0x2d, 0xb4, 0xb3, 0x8b, 0x48, 0xb6, 0xde, 0x87,
+	0x25, 0xf9, 0x00, 0x26, 0xf3, 0xd1, 0xa3, 0x59, 0x94, 0xe0, 0x98, 0xc0, 0x37, 0x59, 0x02, 0x17,
+	0xbc, 0xad, 0x5a, 0x46, 0xe9, 0x31, 0xac, 0xe4, 0xe6, 0xa7, 0x82, 0x89, 0x9f, 0x60, 0x5c, 0x39,
+	0xfe, 0x24, 0x14, 0x16, 0x3c, 0x3f, 0xea, 0x9f, 0xfa, 0x63, 0xcf, 0x56, 0xde, 0xb9, 0xa6, 0xe7,
+	0x47, 0x4f, 0x38, 0x8c, 0x3f, 0x74, 0x7f, 0x0e, 0xeb, 0x19, 0xb2, 0x3b, 0x13, 0xe1, 0x56, 0xfd,
+	0x9f, 0x1d, 0xaf, 0x35, 0x98, 0xb3, 0x83, 0x49, 0x3f, 0x18, 0x7b, 0x92, 0xfd, 0x9a, 0x1d, 0x4c,
+	0xcc, 0xb1, 0x97, 0xee, 0xaa, 0xa2, 0xee, 0xea, 0x0b, 0xb8, 0xa1, 0x5f, 0xfe, 0xb2, 0xcd, 0xd1,
+	0x7b, 0xd0, 0x36, 0x59, 0x18, 0xf9, 0xc1, 0xc5, 0xc7, 0x4e, 0xd7, 0x60, 0x25, 0x87, 0x27, 0xef,
+	0xe9, 0x0f, 0xc4, 0x53, 0xd5, 0x0d, 0x06, 0xe7, 0xce, 0x6b, 0x66, 0x5f, 0x4c, 0xe4, 0xd7, 0x70,
+	0x5d, 0x83, 0x7b, 0x75, 0x13, 0xe2, 0xf6, 0x1b, 0xab, 0x89, 0x15, 0xbb, 0x8a, 0x0d, 0x09, 0xe9,
+	0x46, 0xb4, 0x07, 0x9d, 0x17, 0xe3, 0xe0, 0x2c, 0xf6, 0x9a, 0x0a, 0xf9, 0x2e, 0xf0, 0x5d, 0xee,
+	0x4c, 0x46, 0xe7, 0x96, 0x27, 0xe5, 0xd0, 0x10, 0x90, 0xde, 0xb9, 0xe5, 0x4d, 0x15, 0x39, 0xfd,
+	0x14, 0xd6, 0xb5, 0x54, 0x53, 0x3f, 0x62, 0xc4, 0x87, 0x63, 0xd1, 0xca, 0x2f, 0xfa, 0x17, 0xb0,
+	0x86, 0x33, 0xba, 0xae, 0x9b, 0xe3, 0xe4, 0x36, 0x2c, 0x0c, 0x7c, 0xef, 0xd4, 0x09, 0x86, 0x7d,
+	0xd5, 0x7b, 0x9f, 0x97, 0x40, 0x8c, 0xa9, 0xa6, 0xaa, 0xc0, 0x55, 0x6d, 0xed, 0x4f, 0xc1, 0x28,
+	0x32, 0x70, 0xa9, 0xb6, 0x6b, 0x2c, 0xb1, 0xac, 0xb5, 0xc4, 0xa7, 0xd0, 0xee, 0xda, 0x52, 0x1a,
+	0x3d, 0xeb, 0x2c, 0x54, 0xee, 0x68, 0x3c, 0x2d, 0xe5, 0x8e, 0x46, 0xc0, 0xbe, 0x9d, 0x64, 0xda,
+	0xca, 0x69, 0xa6, 0x8d, 0x7e, 0x08, 0x2b, 0x39, 0x42, 0x92, 0xc9, 0x18, 0xb9, 0xa4, 0x20, 0x7f,
+	0x0f, 0x6b, 0x26, 0x1b, 0xfa, 0xaf, 0xd9, 0xef, 0x60, 0xe1, 0x6d, 0x30, 0x8a, 0xb4, 0x2e, 0x58,
+	0xdb, 0x84, 0xd5, 0xa3, 0xd8, 0x29, 0x92, 0xf9, 0xba, 0x29, 0x97, 0x64, 0x9a, 0xe8, 0x2b, 0x8b,
+	0xa8, 0x65, 0x6a, 0xa2, 0x8f, 0x7e, 0x0d, 0x6b, 0x05, 0x9a, 0xef, 0xf0, 0xa6, 0xfc, 0x55, 0x19,
+	0x96, 0x0e, 0xd9, 0x1b, 0xcc, 0x52, 0x5d, 0x45, 0x0e, 0xc9, 0x6b, 0x51, 0x56, 0x0b, 0x03, 0x1b,
+	0xd0, 0xf4, 0x47, 0x23, 0xdf, 0x93, 0x93, 0x2a, 0xe8, 0x0f, 0xc6, 0xa0, 0x7d, 0xae, 0x15, 0xb5,
+	0x80, 0x85, 0x63, 0x37, 0x12, 0xaf, 0xcc, 0xe2, 0xa3, 0x25, 0xce, 0x8b, 0x5c, 0x95, 0x83, 0x4d,
+	0x39, 0xcc, 0x17, 0x1f, 0xb9, 0xd6, 0x24, 0xcd, 0xe0, 0x56, 0xcc, 0x3a, 0x02, 0xba, 0x22, 0xd3,
+	0x86, 0xe9, 0xd4, 0x68, 0x32, 0x42, 0xd7, 0x48, 0x66, 0xda, 0x04, 0xa5, 0xde, 0x64, 0xc4, 0xcc,
+	0xc6, 0x30, 0xfe, 0xa9, 0xab, 0x56, 0xcc, 0xe9, 0xaa, 0x15, 0xf4, 0xa5, 0x28, 0x98, 0xc4, 0xdc,
+	0xe4, 0x93, 0xf7, 0x15, 0x71, 0x22, 0x37, 0x33, 0xa9, 0x65, 0x79, 0x73, 0xa4, 0xb9, 0x64, 0x6d,
+	0xbd, 0x84, 0xee, 0x88, 0x6c, 0xbe, 0x54, 0xf8, 0x58, 0xbc, 0x0f, 0x60, 0x2e, 0x7d, 0xa2, 0x78,
+	0x68, 0xb4, 0x2c, 0xb3, 0xf9, 0xea, 0x21, 0x98, 0x31, 0x0e, 0xbd, 0x27, 0x92, 0xf9, 0x09, 0x8d,
+	0x62, 0x8c, 0x50, 0xc1, 0x18, 0xe1, 0x16, 0x2c, 0x3d, 0x65, 0x51, 0xe6, 0x20, 0x73, 0x7b, 0xa0,
+	0x9f, 0x88, 0x68, 0x2a, 0xbb, 0xcf, 0x0d, 0x98, 0xc5, 0xbc, 0x25, 0xea, 0x48, 0x23, 0x3d, 0x17,
+	0x84, 0xd3, 0x2f, 0x81, 0x1c, 0x4b, 0x1f, 0x6f, 0x3a, 0x69, 0xbd, 0x5a, 0xd0, 0xcf, 0x62, 0x17,
+	0xfc, 0x1d, 0xd7, 0xbc, 0x03, 0x04, 0x6f, 0x9e, 0x0b, 0xb7, 0xb3, 0x12, 0x3b, 0x1c, 0x19, 0xea,
+	0xf4, 0x13, 0x68, 0x1f, 0x7b, 0xb6, 0xff, 0xcc, 0x0a, 0xa3, 0x2b, 0xab, 0x35, 0xfd, 0x02, 0x56,
+	0x72, 0x93, 0xae, 0xca, 0xeb, 0xe7, 0x70, 0x53, 0xe1, 0x82, 0x85, 0xcf, 0xe3, 0x07, 0x41, 0xc9,
+	0x58, 0x9c, 0xb0, 0x53, 0x2e, 0x1b, 0x79, 0xbf, 0xe3, 0x17, 0xfd, 0x12, 0xde, 0x9b, 0x36, 0xf1,
+	0xd2, 0x57, 0xf7, 0x3f, 0xca, 0x40, 0x9e, 0x39, 0x92, 0x57, 0x76, 0xb5, 0x1b, 0x8c, 0x3f, 0x1a,
+	0xb1, 0x06, 0x9f, 0x72, 0x57, 0xa2, 0x2c, 0x1f, 0x0d, 0xa9, 0xc4, 0x1c, 0xa6, 0x26, 0x61, 0x25,
+	0xd3, 0x95, 0x4c, 0x12, 0x76, 0x47, 0x00, 0xd3, 0x6c, 0x4b, 0x55, 0x9f, 0xfd, 0x9f, 0xcd, 0x64,
+	0xff, 0xb7, 0xa1, 0x99, 0x9a, 0x2d, 0x66, 0xdc, 0x0a, 0x76, 0x0b, 0x89, 0xdd, 0x86, 0xb9, 0x92,
+	0xc0, 0x5c, 0xbe, 0x24, 0xf0, 0x00, 0x9a, 0xf2, 0x8a, 0x10, 0x19, 0xed, 0xba, 0x2e, 0x41, 0x8d,
+	0x08, 0x22, 0x9f, 0x7d, 0x3f, 0xb9, 0x51, 0x22, 0x5f, 0x46, 0x34, 0xb9, 0xf0, 0x0d, 0x87, 0x7b,
+	0x3e, 0x3d, 0x81, 0xe5, 0x8c, 0x54, 0xe5, 0x39, 0xdc, 0xce, 0x5b, 0xac, 0xa2, 0x05, 0xf1, 0xc8,
+	0x55, 0x73, 0xa1, 0x74, 0x1f, 0xda, 0x4f, 0x59, 0xd4, 0xf3, 0x47, 0xef, 0x72, 0x76, 0xda, 0xec,
+	0x16, 0xfd, 0x0a, 0x56, 0x72, 0xa4, 0xde, 0x81, 0x61, 0xfa, 0xaf, 0x25, 0x68, 0x1f, 0x45, 0x01,
+	0xb3, 0x86, 0xbf, 0x2f, 0x2d, 0xca, 0xe9, 0x45, 0xf5, 0x12, 0xbd, 0xa0, 0x7f, 0x26, 0x44, 0xf7,
+	0x1d, 0xb3, 0xec, 0x9e, 0xcf, 0xff, 0x8d, 0x19, 0xbe, 0x0e, 0x92, 0xbf, 0xbe, 0x25, 0xf9, 0x95,
+	0x19, 0xa6, 0xae, 0x32, 0x74, 0x22, 0x8f, 0x43, 0x0e, 0xed, 0xe4, 0x57, 0xaf, 0x5c, 0xb6, 0xfa,
+	0x7f, 0x96, 0x84, 0xb8, 0xd5, 0xe5, 0x53, 0x3b, 0xcd, 0x06, 0x1d, 0x89, 0x52, 0x50, 0x58, 0x88,
+	0x39, 0xeb, 0xbf, 0x71, 0xbc, 0xd8, 0x15, 0x6a, 0x4a, 0xf6, 0x5e, 0x3a, 0x9e, 0x8a, 0x73, 0x82,
+	0x38, 0x15, 0x15, 0x67, 0x47, 0xe0, 0xb4, 0x61, 0xd6, 0x0e, 0xac, 0x37, 0x61, 0x6c, 0x6f, 0xe2,
+	0x83, 0xdc, 0x81, 0xc5, 0x84, 0x3a, 0xde, 0xbe, 0xb3, 0xf2, 0x30, 0x90, 0x3c, 0x06, 0xa5, 0x29,
+	0xd6, 0x89, 0xc4, 0xaa, 0xa9, 0x58, 0x3b, 0x02, 0x8b, 0xfe, 0x25, 0xee, 0x2e, 0x75, 0x24, 0xae,
+	0xa6, 0x0e, 0x39, 0x21, 0x96, 0x2f, 0x33, 0x6d, 0x1e, 0x80, 0x33, 0x2b, 0xf4, 0xbd, 0xd4, 0x4d,
+	0xa8, 0x23, 0x60, 0xdf, 0xa6, 0xdf, 0xc2, 0x6a, 0x9e, 0x05, 0x29, 0xe1, 0xbb, 0x30, 0xcb, 0xfd,
+	0x9d, 0x50, 0xde, 0xc2, 0x4b, 0x59, 0x77, 0x28, 0x34, 0x71, 0x94, 0x3e, 0xe7, 0xce, 0xdd, 0xc0,
+	0x72, 0x07, 0x63, 0xd7, 0x8a, 0x98, 0xd8, 0xd8, 0x95, 0x76, 0x31, 0xd5, 0x75, 0x9f, 0x00, 0x08,
+	0x2a, 0x8f, 0x03, 0xe7, 0xf4, 0x12, 0x1a, 0xeb, 0xc0, 0x63, 0x81, 0xbe, 0xfa, 0x0a, 0xd6, 0x7d,
+	0xd7, 0xc6, 0x33, 0x58, 0x87, 0x86, 0xc7, 0xde, 0xf4, 0x55, 0x17, 0xa1, 0xee, 0xb1, 0x37, 0x38,
+	0x28, 0x0e, 0xd7, 0x39, 0x8d, 0xd2, 0xc3, 0x75, 0x4e, 0x23, 0xfa, 0x27, 0xdc, 0xb9, 0xcc, 0xef,
+	0x45, 0x09, 0xc2, 0xcf, 0xd9, 0xe0, 0x55, 0xfa, 0x30, 0xc8, 0x4f, 0x72, 0x0f, 0x6a, 0x62, 0x3a,
+	0x1e, 0x45, 0xf3, 0xd1, 0x22, 0x97, 0x54, 0xba, 0x05, 0x53, 0x8e, 0xd2, 0xbf, 0x2b, 0x09, 0x59,
+	0x8b, 0x91, 0xef, 0x1c, 0x1e, 0x97, 0x4d, 0xae, 0xea, 0x06, 0x8b, 0x4b, 0x17, 0x37, 0x28, 0x7e,
+	0xf3, 0x77, 0x39, 0xf2, 0xe5, 0xae, 0xca, 0x91, 0x4f, 0xb6, 0xa1, 0x76, 0x32, 0x1e, 0xbc, 0x62,
+	0xb1, 0xaf, 0xb7, 0x9a, 0xf0, 0x20, 0x57, 0xda, 0x11, 0xa3, 0xa6, 0xc4, 0xa2, 0x3f, 0x49, 0x21,
+	0xbf, 0xf0, 0x1d, 0x2f, 0x22, 0xb7, 0x60, 0x1e, 0xe1, 0xfd, 0x30, 0xb2, 0x82, 0x38, 0xb4, 0x69,
+	0x22, 0xec, 0x88, 0x83, 0x84, 0xc0, 0x98, 0x1b, 0x59, 0xf1, 0x6d, 0x28, 0x3e, 0xa6, 0xb8, 0x60,
+	0x5d, 0x91, 0x3a, 0xcd, 0xee, 0x53, 0x4a, 0xf1, 0x1e, 0xd4, 0x46, 0x7c, 0xc9, 0xf8, 0x92, 0x4c,
+	0x65, 0x25, 0x38, 0x31, 0xe5, 0x28, 0xfd, 0xeb, 0x92, 0xa2, 0x97, 0x61, 0xc6, 0x36, 0xb8, 0x57,
+	0x18, 0xcb, 0x2a, 0xf6, 0xf5, 0x1b, 0xb1, 0xb0, 0xc2, 0xdf, 0xad, 0x75, 0xfc, 0x73, 0x49, 0xc9,
+	0x02, 0x87, 0x59, 0xfb, 0xf8, 0x2a, 0xb5, 0x0f, 0xbe, 0x93, 0x7b, 0x7c, 0x89, 0x29, 0xb8, 0xdb,
+	0xe2, 0x0b, 0x9b, 0x68, 0x70, 0x52, 0x67, 0x1f, 0x20, 0x05, 0x6a, 0xfa, 0x5e, 0xee, 0xaa, 0x7d,
+	0x2f, 0x3a, 0xeb, 0x4b, 0x1b, 0x61, 0xfe, 0x06, 0xaf, 0x91, 0x67, 0xcc, 0xb2, 0x59, 0x70, 0xe2,
+	0x5b, 0x81, 0xad, 0x24, 0xaa, 0xf1, 0x09, 0x2b, 0xe9, 0x5d, 0x86, 0x72, 0xc6, 0x65, 0xb8, 0x05,
+	0xf3, 0x71, 0x61, 0x23, 0xb0, 0xbc, 0x57, 0x32, 0x40, 0x6d, 0x4a, 0x98, 0x69, 0x79, 0xaf, 0xb2,
+	0xc2, 0xaa, 0xe6, 0x84, 0x35, 0x84, 0x96, 0xc2, 0x03, 0x6e, 0xec, 0x2a, 0x09, 0x02, 0x02, 0x55,
+	0xb1, 0x9e, 0xd4, 0x6f, 0xfe, 0x5b, 0x14, 0xf3, 0x70, 0x21, 0x55, 0xbf, 0x9a, 0x08, 0xc3, 0xdb,
+	0xf3, 0x3b, 0xa1, 0x21, 0x99, 0x5d, 0xcb, 0x93, 0xd9, 0x86, 0x39, 0xe6, 0x45, 0x81, 0xc3, 0x32,
+	0xd5, 0x9f, 0x3c, 0x6f, 0x66, 0x8c, 0x44, 0xdf, 0xc0, 0x7b, 0x59, 0x4a, 0x4f, 0xfc, 0xe0, 0x05,
+	0x0b, 0x1c, 0xdf, 0x56, 0x5a, 0xb9, 0x84, 0x09, 0x96, 0x0a, 0x26, 0x58, 0x4e, 0x4c, 0x30, 0x11,
+	0x76, 0x45, 0x15, 0xf6, 0x85, 0x12, 0x0b, 0x61, 0x15, 0xd7, 0x29, 0xc8, 0xed, 0xb2, 0x0b, 0xa1,
+	0x90, 0x6d, 0xd4, 0x37, 0x8f, 0xc5, 0xa2, 0xad, 0xa6, 0xa2, 0xa5, 0x2f, 0x61, 0x63, 0xea, 0x6e,
+	0xa5, 0x00, 0x7f, 0x96, 0x17, 0x60, 0x87, 0x0b, 0x50, 0xcf, 0x6a, 0x2a, 0xc6, 0x2d, 0x58, 0xed,
+	0x7a, 0xbe, 0x37, 0x19, 0x3a, 0xbf, 0xbd, 0x24, 0x31, 0x75, 0x1d, 0xd6, 0x0a, 0x98, 0x32, 0x92,
+	0x60, 0xb0, 0x7c, 0xc0, 0x82, 0xb3, 0x7c, 0xaa, 0xf0, 0xc2, 0x24, 0xf2, 0x3a, 0x34, 0x22, 0x2b,
+	0x38, 0x63, 0x42, 0x58, 0x28, 0x94, 0x3a, 0x02, 0xf6, 0xed, 0x29, 0xc9, 0xb7, 0x5f, 0x42, 0x3b,
+	0xbb, 0x4c, 0xe2, 0xc5, 0x2d, 0x0c, 0xfd, 0xd7, 0x85, 0x8c, 0xe6, 0xbc, 0x00, 0x4a, 0x9f, 0x6d,
+	0x4a, 0xe0, 0xf5, 0x3f, 0x65, 0x68, 0x1e, 0xf9, 0x41, 0xa4, 0x18, 0x9f, 0x13, 0xb1, 0x61, 0x7c,
+	0x45, 0xe1, 0x07, 0xf9, 0x10, 0xae, 0x05, 0x22, 0x7f, 0xd1, 0xb7, 0xc7, 0x23, 0xd7, 0x19, 0x58,
+	0x91, 0x4c, 0xd6, 0xd4, 0xcd, 0x16, 0x0e, 0x3c, 0x4e, 0xe0, 0x64, 0x13, 0xaa, 0x43, 0xdf, 0x66,
+	0xb2, 0x8c, 0x2a, 0x3c, 0x68, 0xbe, 0xc2, 0x81, 0x6f, 0x33, 0x53, 0x8c, 0x90, 0xf7, 0x00, 0x6c,
+	0x96, 0xf4, 0x15, 0xc8, 0x4a, 0x61, 0x0a, 0xe1, 0xb6, 0xee, 0xfa, 0x03, 0xcb, 0x65, 0xb2, 0x2b,
+	0x50, 0x7e, 0x91, 0x0d, 0x68, 0x3a, 0x67, 0x9e, 0x1f, 0xb0, 0xfe, 0xc0, 0x0a, 0xd1, 0x3b, 0xa9,
+	0x9b, 0x80, 0xa0, 0x5d, 0x2b, 0x64, 0x9c, 0x4f, 0x89, 0x60, 0x3b, 0xd6, 0x20, 0x70, 0x22, 0x67,
+	0x10, 0x8a, 0xb0, 0xa0, 0x6e, 0xb6, 0x70, 0xe0, 0x71, 0x02, 0x27, 0x0f, 0xa0, 0xc5, 0xc9, 0xf4,
+	0x1d, 0x2f, 0x64, 0x5e, 0xe8, 0x44, 0xce, 0x6b, 0x26, 0x42, 0x84, 0xfa, 0x4e, 0xd9, 0x28, 0x99,
+	0x4b, 0x7c, 0x6c, 0x3f, 0x1d, 0xe2, 0x42, 0x8e, 0xfb, 0x3c, 0xfc, 0x31, 0x7f, 0x0b, 0x64, 0x97,
+	0x96, 0x6c, 0xf3, 0x10, 0x30, 0xee, 0xcf, 0x8e, 0x02, 0x16, 0xb2, 0xe0, 0x35, 0xeb, 0x8b, 0x62,
+	0xb1, 0xa8, 0x6b, 0xd4, 0xcd, 0x85, 0x18, 0x2a, 0x4a, 0xc9, 0xf4, 0x2b, 0x98, 0x47, 0xa1, 0xa7,
+	0xc5, 0x75, 0x8d, 0xd4, 0x57, 0xa1, 0x26, 0x97, 0xc2, 0x6e, 0x3b, 0xf9, 0xc5, 0x83, 0x5e, 0xf1,
+	0xbc, 0x1d, 0x09, 0x8b, 0x9c, 0xa6, 0xae, 0x3f, 0x87, 0xe5, 0x0c, 0x56, 0x9a, 0xeb, 0x41, 0x4b,
+	0x56, 0xef, 0x36, 0x89, 0x23, 0x47, 0xa8, 0x03, 0xeb, 0x4f, 0x59, 0x74, 0x3c, 0x1a, 0xf8, 0x43,
+	0xc7, 0x3b, 0xdb, 0x91, 0xf9, 0xff, 0x50, 0xb9, 0x57, 0xf8, 0x67, 0x7c, 0xaf, 0xf0, 0xdf, 0xfc,
+	0xd0, 0x93, 0xe7, 0x3e, 0x1f, 0x36, 0xe1, 0xcd, 0xa3, 0xbd, 0x69, 0xe8, 0x31, 0xb4, 0xf2, 0xeb,
+	0x5c, 0x39, 0x3f, 0x6b, 0x4d, 0xc2, 0xfe, 0xd8, 0x8b, 0x1c, 0x37, 0xc9, 0xcf, 0x5a, 0x93, 0xf0,
+	0x98, 0x03, 0xa8, 0x29, 0xca, 0x92, 0x9a, 0x1d, 0x48, 0x29, 0x3c, 0x82, 0x46, 0x5c, 0xd6, 0xc8,
+	0x5c, 0xb7, 0xf9, 0x19, 0x66, 0x8a, 0x46, 0x03, 0x58, 0x7f, 0xe2, 0x78, 0x76, 0xa2, 0xe9, 0x39,
+	0x63, 0xbf, 0x0b, 0x8b, 0xf8, 0x84, 0x27, 0xf5, 0x13, 0x2c, 0x7b, 0x2c, 0x08, 0xe8, 0x8e, 0x52,
+	0x44, 0xd1, 0xb4, 0x1f, 0xa4, 0xaf, 0x5b, 0x45, 0x7d, 0xdd, 0xe8, 0xdf, 0x96, 0x60, 0x29, 0xb7,
+	0xe0, 0x95, 0xca, 0x38, 0xfa, 0x8b, 0x35, 0x9b, 0x9a, 0xaa, 0xe6, 0x53, 0x53, 0x6a, 0xed, 0x67,
+	0x36, 0x5b, 0xfb, 0xa1, 0x7f, 0x5f, 0x82, 0x76, 0x8e, 0x11, 0xd1, 0x28, 0x45, 0xde, 0x87, 0x25,
+	0xcf, 0x0f, 0x86, 0x96, 0xeb, 0xfc, 0x96, 0xd9, 0x7d, 0xa5, 0x75, 0x78, 0x31, 0x05, 0x1f, 0x5e,
+	0xd6, 0x44, 0xfc, 0x20, 0x6d, 0x02, 0xa8, 0xa4, 0x99, 0xae, 0xdc, 0x7a, 0x69, 0x7b, 0xcf, 0x0b,
+	0xb8, 0xa1, 0x3f, 0x09, 0x79, 0xba, 0x1f, 0x43, 0x4d, 0xb6, 0x7c, 0xe1, 0xd1, 0x1a, 0x1a, 0x6a,
+	0x82, 0x7b, 0x53, 0xe2, 0x7d, 0xf0, 0x35, 0x34, 0x92, 0x1e, 0x3c, 0xd2, 0x84, 0xb9, 0x17, 0xdd,
+	0x5e, 0x6f, 0xcf, 0x3c, 0x6c, 0xcd, 0x90, 0x06, 0xcc, 0xee, 0xfd, 0xd8, 0xdd, 0xed, 0xb5, 0x4a,
+	0x04, 0xa0, 0xf6, 0xc2, 0xdc, 0x7b, 0xb2, 0xff, 0x63, 0xab, 0x4c, 0xe6, 0xa1, 0xbe, 0xfb, 0xfc,
+	0xb0, 0xd7, 0xdd, 0x3f, 0x3c, 0x6a, 0x55, 0x3e, 0xd8, 0x89, 0x7b, 0xac, 0x64, 0xa7, 0x08, 0x9f,
+	0x75, 0xb4, 0xfb, 0xdc, 0xdc, 0x6b, 0xcd, 0x90, 0x3a, 0x54, 0x0f, 0xbb, 0x07, 0x7b, 0xad, 0x12,
+	0x59, 0x04, 0xd8, 0x35, 0xf7, 0xba, 0xbd, 0xbd, 0xc7, 0xfd, 0x6e, 0x0f, 0x69, 0xec, 0xec, 0x9b,
+	0xbd, 0xef, 0x1e, 0x77, 0xff, 0xa8, 0x55, 0xf9, 0xe0, 0x7d, 0x20, 0x45, 0xd7, 0x97, 0xcc, 0x41,
+	0x85, 0x0f, 0x0b, 0x32, 0x2f, 0xf7, 0xf6, 0x7e, 0x68, 0x95, 0x3e, 0xf8, 0x18, 0xea, 0xf1, 0x7d,
+	0xca, 0x59, 0x3a, 0xea, 0x99, 0xfb, 0x87, 0x4f, 0x5b, 0x33, 0x9c, 0xed, 0xc3, 0xe3, 0x83, 0x3d,
+	0x73, 0x7f, 0xb7, 0x55, 0x12, 0x1f, 0xdd, 0xde, 0xb1, 0xd9, 0x7d, 0xd6, 0x2a, 0x3f, 0xfa, 0xf7,
+	0x1b, 0xb0, 0x18, 0x7b, 0x78, 0xd8, 0x36, 0x4e, 0xbe, 0x84, 0x46, 0xd2, 0xf9, 0x4b, 0xb4, 0x5d,
+	0xc2, 0x9d, 0x95, 0x1c, 0x54, 0xbe, 0x75, 0x33, 0xe4, 0x6b, 0x80, 0xb4, 0x6b, 0x98, 0x64, 0xd1,
+	0x62, 0x73, 0xe8, 0xac, 0xe6, 0xc1, 0xc9, 0xf4, 0x5d, 0x98, 0x57, 0x0b, 0x52, 0x64, 0x5a, 0x89,
+	0xaa, 0x63, 0x14, 0x07, 0x54, 0x22, 0x6a, 0x9b, 0x12, 0x12, 0xd1, 0x34, 0x40, 0x21, 0x11, 0x5d,
+	0x47, 0x13, 0x9d, 0x21, 0x4f, 0x60, 0x21, 0xd3, 0x66, 0x44, 0x04, 0xb2, 0xae, 0xa1, 0xa9, 0x73,
+	0x5d, 0x33, 0x92, 0xd0, 0xd9, 0x87, 0xc5, 0x6c, 0x5b, 0x0f, 0x41, 0x74, 0x5d, 0x5f, 0x52, 0xa7,
+	0xa3, 0x1b, 0x52, 0x65, 0x9b, 0xba, 0xe3, 0x28, 0xdb, 0x42, 0x7b, 0x0f, 0xca, 0xb6, 0xd8, 0x4b,
+	0x43, 0x67, 0xf8, 0xb1, 0x26, 0x70, 0x3c, 0xd6, 0x7c, 0x57, 0x4c, 0x67, 0x25, 0x07, 0xcd, 0x88,
+	0x54, 0x69, 0x5f, 0x91, 0x22, 0x2d, 0xf6, 0xbd, 0x48, 0x91, 0x6a, 0x3a, 0x5d, 0x54, 0x22, 0xd8,
+	0xaa, 0xa2, 0x12, 0xc9, 0x74, 0xb9, 0xa8, 0x44, 0xb2, 0x5d, 0x2d, 0x74, 0x86, 0x3c, 0x57, 0x9a,
+	0x79, 0x64, 0x53, 0x0a, 0x59, 0xcf, 0xb0, 0x9d, 0xed, 0x6d, 0xe9, 0xdc, 0xd0, 0x0f, 0x26, 0x04,
+	0x7f, 0xad, 0xa4, 0x2c, 0xd4, 0x26, 0x13, 0xb2, 0x99, 0x9f, 0x98, 0x6f, 0x60, 0xe9, 0xdc, 0xba,
+	0x00, 0x23, 0xa1, 0xff, 0x87, 0xd0, 0x54, 0x3a, 0x4b, 0x88, 0x38, 0x9f, 0x62, 0x43, 0x4a, 0x67,
+	0xad, 0x00, 0x57, 0xe5, 0xa6, 0xb6, 0x30, 0xa0, 0xdc, 0x34, 0x5d, 0x29, 0x28, 0x37, 0x5d, 0xb7,
+	0x03, 0xb2, 0xa1, 0xb4, 0x0c, 0x20, 0x1b, 0xc5, 0xde, 0x86, 0xce, 0x5a, 0x01, 0x9e, 0x65, 0x23,
+	0x2d, 0xe6, 0xc7, 0x6c, 0x14, 0x7a, 0x09, 0x62, 0x36, 0x8a, 0x75, 0x7f, 0x24, 0xa2, 0xd6, 0x88,
+	0x91, 0x88, 0xa6, 0xe2, 0x8f, 0x44, 0x74, 0x55, 0x7a, 0xb4, 0xcd, 0x4c, 0xa1, 0x99, 0x14, 0x90,
+	0xb3, 0xb6, 0xa9, 0xad, 0xb5, 0xd3, 0x19, 0xf2, 0x53, 0xae, 0x8c, 0x2f, 0x0b, 0xd6, 0x64, 0xa3,
+	0x30, 0x29, 0x5b, 0x49, 0xef, 0x6c, 0x4e, 0x47, 0x50, 0x99, 0xcc, 0xd4, 0xaa, 0x91, 0x49, 0x5d,
+	0x99, 0x1b, 0x99, 0xd4, 0x17, 0xb6, 0x67, 0x88, 0x29, 0x3a, 0xd3, 0xb2, 0xe5, 0x6a, 0x12, 0x2b,
+	0xb5, 0xb6, 0xe2, 0xdd, 0xb9, 0x39, 0x65, 0x34, 0xa1, 0xf9, 0x23, 0x2c, 0x6b, 0x8a, 0xc9, 0xe4,
+	0x3d, 0x11, 0x14, 0x4d, 0xad, 0x5d, 0x77, 0x36, 0xa6, 0x8e, 0xab, 0xe6, 0x99, 0x2f, 0xf7, 0xa2,
+	0x79, 0x4e, 0xa9, 0x42, 0xa3, 0x79, 0x4e, 0xab, 0x10, 0xa3, 0x18, 0x33, 0x75, 0x59, 0x14, 0xa3,
+	0xae, 0xe6, 0x8b, 0x62, 0xd4, 0x16, 0x71, 0x91, 0xb1, 0x7c, 0x99, 0x15, 0x19, 0x9b, 0x52, 0xc8,
+	0x45, 0xc6, 0xa6, 0x55, 0x66, 0xe9, 0x0c, 0x79, 0x06, 0x4b, 0xb9, 0x9a, 0x29, 0xc1, 0xeb, 0x5b,
+	0x5b, 0x9c, 0xed, 0xac, 0x6b, 0xc7, 0x12, 0x6a, 0x9f, 0x43, 0x3d, 0x2e, 0xd0, 0x11, 0x5d, 0x29,
+	0xaf, 0xd3, 0xce, 0x02, 0x73, 0x0f, 0x6e, 0x1c, 0xc8, 0xad, 0xa8, 0x58, 0xac, 0xf0, 0xe0, 0xe6,
+	0x52, 0xfc, 0xb8, 0x8b, 0x5c, 0xe0, 0x8a, 0xbb, 0xd0, 0xc7, 0xbd, 0xb8, 0x8b, 0x69, 0x91, 0xae,
+	0xd8, 0x45, 0x5c, 0x1b, 0xc4, 0x5d, 0xe4, 0x8a, 0x89, 0x9d, 0x76, 0x16, 0xa8, 0xde, 0x4e, 0x4a,
+	0x8d, 0x0f, 0x6f, 0xa7, 0x62, 0xc1, 0xb0, 0xb3, 0x56, 0x80, 0xab, 0x14, 0x94, 0x42, 0x18, 0x52,
+	0x28, 0x96, 0xff, 0x3a, 0x6b, 0x05, 0xb8, 0xaa, 0x69, 0x99, 0xea, 0x1d, 0x6a, 0x9a, 0xae, 0x0a,
+	0x88, 0x9a, 0xa6, 0x2d, 0xf5, 0xd1, 0x19, 0x62, 0xc1, 0xaa, 0xbe, 0x24, 0x47, 0x6e, 0xe5, 0x16,
+	0x2f, 0xd6, 0xf9, 0x3a, 0xf4, 0x22, 0x14, 0x75, 0xb3, 0x4a, 0x89, 0x09, 0x37, 0x5b, 0xac, 0xe4,
+	0xe1, 0x66, 0x35, 0xb5, 0x28, 0x3a, 0x43, 0xbe, 0x80, 0x85, 0x4c, 0xd9, 0x46, 0xba, 0x37, 0x9a,
+	0x4a, 0x4e, 0x27, 0x2d, 0xfb, 0xd0, 0x99, 0x8f, 0x4b, 0x5c, 0x4c, 0x99, 0x7a, 0x11, 0xce, 0xd4,
+	0x55, 0xa3, 0x50, 0x4c, 0xda, 0xe2, 0x12, 0x8a, 0x3b, 0x53, 0x08, 0x49, 0xe8, 0x14, 0x4a, 0x33,
+	0x09, 0x9d, 0x62, 0xd5, 0x04, 0x1d, 0xac, 0x6c, 0xf6, 0x87, 0xc4, 0xe8, 0xc5, 0xfc, 0x21, 0x3a,
+	0x58, 0xfa, 0x24, 0x1b, 0x9d, 0x21, 0xb6, 0xc8, 0x8d, 0xea, 0x12, 0x49, 0x84, 0x16, 0x27, 0xe6,
+	0x73, 0x6a, 0x9d, 0xdb, 0x17, 0xe2, 0xe4, 0x18, 0x56, 0x52, 0x9f, 0x09, 0xc3, 0xc5, 0xba, 0x49,
+	0xc2, 0xb0, 0xa6, 0x9e, 0x81, 0xd6, 0x9b, 0xcb, 0x4b, 0x93, 0x78, 0x82, 0x26, 0x29, 0xdf, 0x59,
+	0xd7, 0x8e, 0x65, 0xaf, 0xc8, 0x6c, 0xb1, 0x20, 0xbe, 0x22, 0xb5, 0xe5, 0x90, 0xf8, 0x8a, 0xd4,
+	0xd7, 0x17, 0x12, 0xf6, 0xd4, 0xfc, 0x31, 0xe9, 0x68, 0x93, 0xca, 0x59, 0xf6, 0x74, 0x09, 0x67,
+	0x74, 0x1d, 0xd4, 0x0c, 0x17, 0xba, 0x0e, 0x9a, 0xd4, 0x1a, 0xba, 0x0e, 0xba, 0x64, 0x18, 0x3e,
+	0xf9, 0xba, 0xf0, 0x10, 0x9f, 0xfc, 0x0b, 0x42, 0x78, 0x7c, 0xf2, 0x2f, 0x8a, 0x2c, 0xe9, 0x0c,
+	0xf9, 0x10, 0xaa, 0x3c, 0xfa, 0x22, 0x4b, 0x71, 0x5e, 0x2b, 0x9e, 0xdc, 0x4a, 0x01, 0x09, 0xf2,
+	0xa7, 0x00, 0x1c, 0x82, 0x26, 0x77, 0xa5, 0x29, 0x5b, 0xa5, 0x8f, 0x4b, 0xdc, 0xf4, 0x95, 0xd4,
+	0x0d, 0x9a, 0x7e, 0x31, 0xe3, 0x83, 0xa6, 0xaf, 0xc9, 0xf1, 0xa0, 0x08, 0x74, 0xf9, 0x0f, 0x14,
+	0xc1, 0x05, 0xb9, 0x9d, 0xce, 0xe6, 0x74, 0x84, 0x98, 0xf8, 0xce, 0xa7, 0x7f, 0xfc, 0xc9, 0x99,
+	0x13, 0x9d, 0x8f, 0x4f, 0xb6, 0x07, 0xfe, 0xf0, 0xe1, 0x88, 0xd9, 0x8e, 0xed, 0x8f, 0xac, 0x33,
+	0xff, 0x61, 0x14, 0x58, 0x8e, 0xe7, 0x78, 0x67, 0xe1, 0xeb, 0xc1, 0x03, 0x19, 0xab, 0xe3, 0x9f,
+	0x1f, 0x87, 0x0f, 0x47, 0x27, 0x27, 0x35, 0xf1, 0xf3, 0x93, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff,
+	0x56, 0x9c, 0x4e, 0x0f, 0xbd, 0x3c, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ClientsServiceClient is the client API for ClientsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClientsServiceClient interface {
+	NewClient(ctx context.Context, in *NewClientRequest, opts ...grpc.CallOption) (*NewClientResponse, error)
+	NewClients(ctx context.Context, in *NewClientsRequest, opts ...grpc.CallOption) (*NewClientsResponse, error)
+	QueryClients(ctx context.Context, in *QueryClientsRequest, opts ...grpc.CallOption) (*QueryClientsResponse, error)
+	CountClients(ctx context.Context, in *CountClientsRequest, opts ...grpc.CallOption) (*CountClientsResponse, error)
+	SampleClients(ctx context.Context, in *SampleClientsRequest, opts ...grpc.CallOption) (*SampleClientsResponse, error)
+	SuggestClients(ctx context.Context, in *SuggestClientsRequest, opts ...grpc.CallOption) (*SuggestClientsResponse, error)
+	GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*GetClientsResponse, error)
+	GetClient(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*GetClientResponse, error)
+	ClientExists(ctx context.Context, in *ClientExistsRequest, opts ...grpc.CallOption) (*ClientExistsResponse, error)
+	ClientsExist(ctx context.Context, in *ClientsExistRequest, opts ...grpc.CallOption) (*ClientsExistResponse, error)
+	GetClientByEmail(ctx context.Context, in *GetClientByEmailRequest, opts ...grpc.CallOption) (*GetClientByEmailResponse, error)
+	GetClientByExternalId(ctx context.Context, in *GetClientByExternalIdRequest, opts ...grpc.CallOption) (*GetClientByExternalIdResponse, error)
+	TouchClient(ctx context.Context, in *TouchClientRequest, opts ...grpc.CallOption) (*TouchClientResponse, error)
+	UpdateClient(ctx context.Context, in *UpdateClientRequest, opts ...grpc.CallOption) (*UpdateClientResponse, error)
+	CloneClient(ctx context.Context, in *CloneClientRequest, opts ...grpc.CallOption) (*CloneClientResponse, error)
+	UpsertClient(ctx context.Context, in *UpsertClientRequest, opts ...grpc.CallOption) (*UpsertClientResponse, error)
+	DeleteClient(ctx context.Context, in *DeleteClientRequest, opts ...grpc.CallOption) (*DeleteClientResponse, error)
+	DeleteClients(ctx context.Context, in *DeleteClientsRequest, opts ...grpc.CallOption) (*DeleteClientsResponse, error)
+	DeleteClientsByQuery(ctx context.Context, in *DeleteClientsByQueryRequest, opts ...grpc.CallOption) (*DeleteClientsByQueryResponse, error)
+	RestoreClient(ctx context.Context, in *RestoreClientRequest, opts ...grpc.CallOption) (*RestoreClientResponse, error)
+	GetArchivedClient(ctx context.Context, in *GetArchivedClientRequest, opts ...grpc.CallOption) (*GetArchivedClientResponse, error)
+	PurgeDeletedClients(ctx context.Context, in *PurgeDeletedClientsRequest, opts ...grpc.CallOption) (*PurgeDeletedClientsResponse, error)
+	DeleteAllClients(ctx context.Context, in *DeleteAllClientsRequest, opts ...grpc.CallOption) (*DeleteAllClientsResponse, error)
+	AddClientTags(ctx context.Context, in *AddClientTagsRequest, opts ...grpc.CallOption) (*AddClientTagsResponse, error)
+	RemoveClientTags(ctx context.Context, in *RemoveClientTagsRequest, opts ...grpc.CallOption) (*RemoveClientTagsResponse, error)
+	SetClientStatus(ctx context.Context, in *SetClientStatusRequest, opts ...grpc.CallOption) (*SetClientStatusResponse, error)
+	NewMatch(ctx context.Context, in *NewMatchRequest, opts ...grpc.CallOption) (*NewMatchResponse, error)
+	NewMatches(ctx context.Context, in *NewMatchesRequest, opts ...grpc.CallOption) (*NewMatchesResponse, error)
+	AnonymizeClient(ctx context.Context, in *AnonymizeClientRequest, opts ...grpc.CallOption) (*AnonymizeClientResponse, error)
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error)
+	UpdateMatch(ctx context.Context, in *UpdateMatchRequest, opts ...grpc.CallOption) (*UpdateMatchResponse, error)
+	DeleteMatch(ctx context.Context, in *DeleteMatchRequest, opts ...grpc.CallOption) (*DeleteMatchResponse, error)
+	UndoLastMatch(ctx context.Context, in *UndoLastMatchRequest, opts ...grpc.CallOption) (*UndoLastMatchResponse, error)
+	DeleteMatchesOlderThan(ctx context.Context, in *DeleteMatchesOlderThanRequest, opts ...grpc.CallOption) (*DeleteMatchesOlderThanResponse, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	StreamMatches(ctx context.Context, in *StreamMatchesRequest, opts ...grpc.CallOption) (ClientsService_StreamMatchesClient, error)
+	GetTopMatches(ctx context.Context, in *GetTopMatchesRequest, opts ...grpc.CallOption) (*GetTopMatchesResponse, error)
+	GetHeadToHead(ctx context.Context, in *GetHeadToHeadRequest, opts ...grpc.CallOption) (*GetHeadToHeadResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	GetLeaderboardForPeriod(ctx context.Context, in *GetLeaderboardForPeriodRequest, opts ...grpc.CallOption) (*GetLeaderboardForPeriodResponse, error)
+	GetClientStats(ctx context.Context, in *GetClientStatsRequest, opts ...grpc.CallOption) (*GetClientStatsResponse, error)
+	GetScoreHistory(ctx context.Context, in *GetScoreHistoryRequest, opts ...grpc.CallOption) (*GetScoreHistoryResponse, error)
+	RecalculateScore(ctx context.Context, in *RecalculateScoreRequest, opts ...grpc.CallOption) (*RecalculateScoreResponse, error)
+	GetClientsStats(ctx context.Context, in *GetClientsStatsRequest, opts ...grpc.CallOption) (*GetClientsStatsResponse, error)
+	MergeClients(ctx context.Context, in *MergeClientsRequest, opts ...grpc.CallOption) (*MergeClientsResponse, error)
+	FindDuplicateClients(ctx context.Context, in *FindDuplicateClientsRequest, opts ...grpc.CallOption) (*FindDuplicateClientsResponse, error)
+	Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error)
+	// Sort for lists too large for a single message: the items are sent over any number of requests, the
+	// options being those of the first one, and returned sorted over any number of responses
+	SortStream(ctx context.Context, opts ...grpc.CallOption) (ClientsService_SortStreamClient, error)
+	StartSeason(ctx context.Context, in *StartSeasonRequest, opts ...grpc.CallOption) (*StartSeasonResponse, error)
+	GetUpcomingBirthdays(ctx context.Context, in *GetUpcomingBirthdaysRequest, opts ...grpc.CallOption) (*GetUpcomingBirthdaysResponse, error)
+}
+
+type clientsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClientsServiceClient(cc *grpc.ClientConn) ClientsServiceClient {
+	return &clientsServiceClient{cc}
+}
+
+func (c *clientsServiceClient) NewClient(ctx context.Context, in *NewClientRequest, opts ...grpc.CallOption) (*NewClientResponse, error) {
+	out := new(NewClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) NewClients(ctx context.Context, in *NewClientsRequest, opts ...grpc.CallOption) (*NewClientsResponse, error) {
+	out := new(NewClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) QueryClients(ctx context.Context, in *QueryClientsRequest, opts ...grpc.CallOption) (*QueryClientsResponse, error) {
+	out := new(QueryClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/QueryClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) CountClients(ctx context.Context, in *CountClientsRequest, opts ...grpc.CallOption) (*CountClientsResponse, error) {
+	out := new(CountClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/CountClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) SampleClients(ctx context.Context, in *SampleClientsRequest, opts ...grpc.CallOption) (*SampleClientsResponse, error) {
+	out := new(SampleClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/SampleClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) SuggestClients(ctx context.Context, in *SuggestClientsRequest, opts ...grpc.CallOption) (*SuggestClientsResponse, error) {
+	out := new(SuggestClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/SuggestClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*GetClientsResponse, error) {
+	out := new(GetClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClient(ctx context.Context, in *GetClientRequest, opts ...grpc.CallOption) (*GetClientResponse, error) {
+	out := new(GetClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) ClientExists(ctx context.Context, in *ClientExistsRequest, opts ...grpc.CallOption) (*ClientExistsResponse, error) {
+	out := new(ClientExistsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/ClientExists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) ClientsExist(ctx context.Context, in *ClientsExistRequest, opts ...grpc.CallOption) (*ClientsExistResponse, error) {
+	out := new(ClientsExistResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/ClientsExist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClientByEmail(ctx context.Context, in *GetClientByEmailRequest, opts ...grpc.CallOption) (*GetClientByEmailResponse, error) {
+	out := new(GetClientByEmailResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClientByEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClientByExternalId(ctx context.Context, in *GetClientByExternalIdRequest, opts ...grpc.CallOption) (*GetClientByExternalIdResponse, error) {
+	out := new(GetClientByExternalIdResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClientByExternalId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) TouchClient(ctx context.Context, in *TouchClientRequest, opts ...grpc.CallOption) (*TouchClientResponse, error) {
+	out := new(TouchClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/TouchClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) UpdateClient(ctx context.Context, in *UpdateClientRequest, opts ...grpc.CallOption) (*UpdateClientResponse, error) {
+	out := new(UpdateClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/UpdateClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) CloneClient(ctx context.Context, in *CloneClientRequest, opts ...grpc.CallOption) (*CloneClientResponse, error) {
+	out := new(CloneClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/CloneClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) UpsertClient(ctx context.Context, in *UpsertClientRequest, opts ...grpc.CallOption) (*UpsertClientResponse, error) {
+	out := new(UpsertClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/UpsertClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteClient(ctx context.Context, in *DeleteClientRequest, opts ...grpc.CallOption) (*DeleteClientResponse, error) {
+	out := new(DeleteClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteClients(ctx context.Context, in *DeleteClientsRequest, opts ...grpc.CallOption) (*DeleteClientsResponse, error) {
+	out := new(DeleteClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteClientsByQuery(ctx context.Context, in *DeleteClientsByQueryRequest, opts ...grpc.CallOption) (*DeleteClientsByQueryResponse, error) {
+	out := new(DeleteClientsByQueryResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteClientsByQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) RestoreClient(ctx context.Context, in *RestoreClientRequest, opts ...grpc.CallOption) (*RestoreClientResponse, error) {
+	out := new(RestoreClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/RestoreClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetArchivedClient(ctx context.Context, in *GetArchivedClientRequest, opts ...grpc.CallOption) (*GetArchivedClientResponse, error) {
+	out := new(GetArchivedClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetArchivedClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) PurgeDeletedClients(ctx context.Context, in *PurgeDeletedClientsRequest, opts ...grpc.CallOption) (*PurgeDeletedClientsResponse, error) {
+	out := new(PurgeDeletedClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/PurgeDeletedClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteAllClients(ctx context.Context, in *DeleteAllClientsRequest, opts ...grpc.CallOption) (*DeleteAllClientsResponse, error) {
+	out := new(DeleteAllClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteAllClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) AddClientTags(ctx context.Context, in *AddClientTagsRequest, opts ...grpc.CallOption) (*AddClientTagsResponse, error) {
+	out := new(AddClientTagsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/AddClientTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) RemoveClientTags(ctx context.Context, in *RemoveClientTagsRequest, opts ...grpc.CallOption) (*RemoveClientTagsResponse, error) {
+	out := new(RemoveClientTagsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/RemoveClientTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) SetClientStatus(ctx context.Context, in *SetClientStatusRequest, opts ...grpc.CallOption) (*SetClientStatusResponse, error) {
+	out := new(SetClientStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/SetClientStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) NewMatch(ctx context.Context, in *NewMatchRequest, opts ...grpc.CallOption) (*NewMatchResponse, error) {
+	out := new(NewMatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) NewMatches(ctx context.Context, in *NewMatchesRequest, opts ...grpc.CallOption) (*NewMatchesResponse, error) {
+	out := new(NewMatchesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) AnonymizeClient(ctx context.Context, in *AnonymizeClientRequest, opts ...grpc.CallOption) (*AnonymizeClientResponse, error) {
+	out := new(AnonymizeClientResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/AnonymizeClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error) {
+	out := new(GetMatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) UpdateMatch(ctx context.Context, in *UpdateMatchRequest, opts ...grpc.CallOption) (*UpdateMatchResponse, error) {
+	out := new(UpdateMatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/UpdateMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteMatch(ctx context.Context, in *DeleteMatchRequest, opts ...grpc.CallOption) (*DeleteMatchResponse, error) {
+	out := new(DeleteMatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) UndoLastMatch(ctx context.Context, in *UndoLastMatchRequest, opts ...grpc.CallOption) (*UndoLastMatchResponse, error) {
+	out := new(UndoLastMatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/UndoLastMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteMatchesOlderThan(ctx context.Context, in *DeleteMatchesOlderThanRequest, opts ...grpc.CallOption) (*DeleteMatchesOlderThanResponse, error) {
+	out := new(DeleteMatchesOlderThanResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/DeleteMatchesOlderThan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/ListMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) StreamMatches(ctx context.Context, in *StreamMatchesRequest, opts ...grpc.CallOption) (ClientsService_StreamMatchesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ClientsService_serviceDesc.Streams[0], "/pb.ClientsService/StreamMatches", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientsServiceStreamMatchesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClientsService_StreamMatchesClient interface {
+	Recv() (*Match, error)
+	grpc.ClientStream
+}
+
+type clientsServiceStreamMatchesClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientsServiceStreamMatchesClient) Recv() (*Match, error) {
+	m := new(Match)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *clientsServiceClient) GetTopMatches(ctx context.Context, in *GetTopMatchesRequest, opts ...grpc.CallOption) (*GetTopMatchesResponse, error) {
+	out := new(GetTopMatchesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetTopMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetHeadToHead(ctx context.Context, in *GetHeadToHeadRequest, opts ...grpc.CallOption) (*GetHeadToHeadResponse, error) {
+	out := new(GetHeadToHeadResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetHeadToHead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetLeaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetLeaderboardForPeriod(ctx context.Context, in *GetLeaderboardForPeriodRequest, opts ...grpc.CallOption) (*GetLeaderboardForPeriodResponse, error) {
+	out := new(GetLeaderboardForPeriodResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetLeaderboardForPeriod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClientStats(ctx context.Context, in *GetClientStatsRequest, opts ...grpc.CallOption) (*GetClientStatsResponse, error) {
+	out := new(GetClientStatsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClientStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetScoreHistory(ctx context.Context, in *GetScoreHistoryRequest, opts ...grpc.CallOption) (*GetScoreHistoryResponse, error) {
+	out := new(GetScoreHistoryResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetScoreHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) RecalculateScore(ctx context.Context, in *RecalculateScoreRequest, opts ...grpc.CallOption) (*RecalculateScoreResponse, error) {
+	out := new(RecalculateScoreResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/RecalculateScore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClientsStats(ctx context.Context, in *GetClientsStatsRequest, opts ...grpc.CallOption) (*GetClientsStatsResponse, error) {
+	out := new(GetClientsStatsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetClientsStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) MergeClients(ctx context.Context, in *MergeClientsRequest, opts ...grpc.CallOption) (*MergeClientsResponse, error) {
+	out := new(MergeClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/MergeClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) FindDuplicateClients(ctx context.Context, in *FindDuplicateClientsRequest, opts ...grpc.CallOption) (*FindDuplicateClientsResponse, error) {
+	out := new(FindDuplicateClientsResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/FindDuplicateClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error) {
+	out := new(SortResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/Sort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) SortStream(ctx context.Context, opts ...grpc.CallOption) (ClientsService_SortStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ClientsService_serviceDesc.Streams[1], "/pb.ClientsService/SortStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientsServiceSortStreamClient{stream}
+	return x, nil
+}
+
+type ClientsService_SortStreamClient interface {
+	Send(*SortRequest) error
+	Recv() (*SortResponse, error)
+	grpc.ClientStream
+}
+
+type clientsServiceSortStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientsServiceSortStreamClient) Send(m *SortRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *clientsServiceSortStreamClient) Recv() (*SortResponse, error) {
+	m := new(SortResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *clientsServiceClient) StartSeason(ctx context.Context, in *StartSeasonRequest, opts ...grpc.CallOption) (*StartSeasonResponse, error) {
+	out := new(StartSeasonResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/StartSeason", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetUpcomingBirthdays(ctx context.Context, in *GetUpcomingBirthdaysRequest, opts ...grpc.CallOption) (*GetUpcomingBirthdaysResponse, error) {
+	out := new(GetUpcomingBirthdaysResponse)
+	err := c.cc.Invoke(ctx, "/pb.ClientsService/GetUpcomingBirthdays", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsServiceServer is the server API for ClientsService service.
+type ClientsServiceServer interface {
+	NewClient(context.Context, *NewClientRequest) (*NewClientResponse, error)
+	NewClients(context.Context, *NewClientsRequest) (*NewClientsResponse, error)
+	QueryClients(context.Context, *QueryClientsRequest) (*QueryClientsResponse, error)
+	CountClients(context.Context, *CountClientsRequest) (*CountClientsResponse, error)
+	SampleClients(context.Context, *SampleClientsRequest) (*SampleClientsResponse, error)
+	SuggestClients(context.Context, *SuggestClientsRequest) (*SuggestClientsResponse, error)
+	GetClients(context.Context, *GetClientsRequest) (*GetClientsResponse, error)
+	GetClient(context.Context, *GetClientRequest) (*GetClientResponse, error)
+	ClientExists(context.Context, *ClientExistsRequest) (*ClientExistsResponse, error)
+	ClientsExist(context.Context, *ClientsExistRequest) (*ClientsExistResponse, error)
+	GetClientByEmail(context.Context, *GetClientByEmailRequest) (*GetClientByEmailResponse, error)
+	GetClientByExternalId(context.Context, *GetClientByExternalIdRequest) (*GetClientByExternalIdResponse, error)
+	TouchClient(context.Context, *TouchClientRequest) (*TouchClientResponse, error)
+	UpdateClient(context.Context, *UpdateClientRequest) (*UpdateClientResponse, error)
+	CloneClient(context.Context, *CloneClientRequest) (*CloneClientResponse, error)
+	UpsertClient(context.Context, *UpsertClientRequest) (*UpsertClientResponse, error)
+	DeleteClient(context.Context, *DeleteClientRequest) (*DeleteClientResponse, error)
+	DeleteClients(context.Context, *DeleteClientsRequest) (*DeleteClientsResponse, error)
+	DeleteClientsByQuery(context.Context, *DeleteClientsByQueryRequest) (*DeleteClientsByQueryResponse, error)
+	RestoreClient(context.Context, *RestoreClientRequest) (*RestoreClientResponse, error)
+	GetArchivedClient(context.Context, *GetArchivedClientRequest) (*GetArchivedClientResponse, error)
+	PurgeDeletedClients(context.Context, *PurgeDeletedClientsRequest) (*PurgeDeletedClientsResponse, error)
+	DeleteAllClients(context.Context, *DeleteAllClientsRequest) (*DeleteAllClientsResponse, error)
+	AddClientTags(context.Context, *AddClientTagsRequest) (*AddClientTagsResponse, error)
+	RemoveClientTags(context.Context, *RemoveClientTagsRequest) (*RemoveClientTagsResponse, error)
+	SetClientStatus(context.Context, *SetClientStatusRequest) (*SetClientStatusResponse, error)
+	NewMatch(context.Context, *NewMatchRequest) (*NewMatchResponse, error)
+	NewMatches(context.Context, *NewMatchesRequest) (*NewMatchesResponse, error)
+	AnonymizeClient(context.Context, *AnonymizeClientRequest) (*AnonymizeClientResponse, error)
+	GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error)
+	UpdateMatch(context.Context, *UpdateMatchRequest) (*UpdateMatchResponse, error)
+	DeleteMatch(context.Context, *DeleteMatchRequest) (*DeleteMatchResponse, error)
+	UndoLastMatch(context.Context, *UndoLastMatchRequest) (*UndoLastMatchResponse, error)
+	DeleteMatchesOlderThan(context.Context, *DeleteMatchesOlderThanRequest) (*DeleteMatchesOlderThanResponse, error)
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	StreamMatches(*StreamMatchesRequest, ClientsService_StreamMatchesServer) error
+	GetTopMatches(context.Context, *GetTopMatchesRequest) (*GetTopMatchesResponse, error)
+	GetHeadToHead(context.Context, *GetHeadToHeadRequest) (*GetHeadToHeadResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	GetLeaderboardForPeriod(context.Context, *GetLeaderboardForPeriodRequest) (*GetLeaderboardForPeriodResponse, error)
+	GetClientStats(context.Context, *GetClientStatsRequest) (*GetClientStatsResponse, error)
+	GetScoreHistory(context.Context, *GetScoreHistoryRequest) (*GetScoreHistoryResponse, error)
+	RecalculateScore(context.Context, *RecalculateScoreRequest) (*RecalculateScoreResponse, error)
+	GetClientsStats(context.Context, *GetClientsStatsRequest) (*GetClientsStatsResponse, error)
+	MergeClients(context.Context, *MergeClientsRequest) (*MergeClientsResponse, error)
+	FindDuplicateClients(context.Context, *FindDuplicateClientsRequest) (*FindDuplicateClientsResponse, error)
+	Sort(context.Context, *SortRequest) (*SortResponse, error)
+	// Sort for lists too large for a single message: the items are sent over any number of requests, the
+	// options being those of the first one, and returned sorted over any number of responses
+	SortStream(ClientsService_SortStreamServer) error
+	StartSeason(context.Context, *StartSeasonRequest) (*StartSeasonResponse, error)
+	GetUpcomingBirthdays(context.Context, *GetUpcomingBirthdaysRequest) (*GetUpcomingBirthdaysResponse, error)
+}
+
+// UnimplementedClientsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedClientsServiceServer struct {
+}
+
+func (*UnimplementedClientsServiceServer) NewClient(ctx context.Context, req *NewClientRequest) (*NewClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) NewClients(ctx context.Context, req *NewClientsRequest) (*NewClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) QueryClients(ctx context.Context, req *QueryClientsRequest) (*QueryClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) CountClients(ctx context.Context, req *CountClientsRequest) (*CountClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) SampleClients(ctx context.Context, req *SampleClientsRequest) (*SampleClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SampleClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) SuggestClients(ctx context.Context, req *SuggestClientsRequest) (*SuggestClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClients(ctx context.Context, req *GetClientsRequest) (*GetClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClient(ctx context.Context, req *GetClientRequest) (*GetClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) ClientExists(ctx context.Context, req *ClientExistsRequest) (*ClientExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientExists not implemented")
+}
+func (*UnimplementedClientsServiceServer) ClientsExist(ctx context.Context, req *ClientsExistRequest) (*ClientsExistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientsExist not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClientByEmail(ctx context.Context, req *GetClientByEmailRequest) (*GetClientByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientByEmail not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClientByExternalId(ctx context.Context, req *GetClientByExternalIdRequest) (*GetClientByExternalIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientByExternalId not implemented")
+}
+func (*UnimplementedClientsServiceServer) TouchClient(ctx context.Context, req *TouchClientRequest) (*TouchClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TouchClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) UpdateClient(ctx context.Context, req *UpdateClientRequest) (*UpdateClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) CloneClient(ctx context.Context, req *CloneClientRequest) (*CloneClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) UpsertClient(ctx context.Context, req *UpsertClientRequest) (*UpsertClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteClient(ctx context.Context, req *DeleteClientRequest) (*DeleteClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteClients(ctx context.Context, req *DeleteClientsRequest) (*DeleteClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteClientsByQuery(ctx context.Context, req *DeleteClientsByQueryRequest) (*DeleteClientsByQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientsByQuery not implemented")
+}
+func (*UnimplementedClientsServiceServer) RestoreClient(ctx context.Context, req *RestoreClientRequest) (*RestoreClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetArchivedClient(ctx context.Context, req *GetArchivedClientRequest) (*GetArchivedClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArchivedClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) PurgeDeletedClients(ctx context.Context, req *PurgeDeletedClientsRequest) (*PurgeDeletedClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteAllClients(ctx context.Context, req *DeleteAllClientsRequest) (*DeleteAllClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAllClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) AddClientTags(ctx context.Context, req *AddClientTagsRequest) (*AddClientTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddClientTags not implemented")
+}
+func (*UnimplementedClientsServiceServer) RemoveClientTags(ctx context.Context, req *RemoveClientTagsRequest) (*RemoveClientTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveClientTags not implemented")
+}
+func (*UnimplementedClientsServiceServer) SetClientStatus(ctx context.Context, req *SetClientStatusRequest) (*SetClientStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClientStatus not implemented")
+}
+func (*UnimplementedClientsServiceServer) NewMatch(ctx context.Context, req *NewMatchRequest) (*NewMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewMatch not implemented")
+}
+func (*UnimplementedClientsServiceServer) NewMatches(ctx context.Context, req *NewMatchesRequest) (*NewMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewMatches not implemented")
+}
+func (*UnimplementedClientsServiceServer) AnonymizeClient(ctx context.Context, req *AnonymizeClientRequest) (*AnonymizeClientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnonymizeClient not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetMatch(ctx context.Context, req *GetMatchRequest) (*GetMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (*UnimplementedClientsServiceServer) UpdateMatch(ctx context.Context, req *UpdateMatchRequest) (*UpdateMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMatch not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteMatch(ctx context.Context, req *DeleteMatchRequest) (*DeleteMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatch not implemented")
+}
+func (*UnimplementedClientsServiceServer) UndoLastMatch(ctx context.Context, req *UndoLastMatchRequest) (*UndoLastMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoLastMatch not implemented")
+}
+func (*UnimplementedClientsServiceServer) DeleteMatchesOlderThan(ctx context.Context, req *DeleteMatchesOlderThanRequest) (*DeleteMatchesOlderThanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatchesOlderThan not implemented")
+}
+func (*UnimplementedClientsServiceServer) ListMatches(ctx context.Context, req *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (*UnimplementedClientsServiceServer) StreamMatches(req *StreamMatchesRequest, srv ClientsService_StreamMatchesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatches not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetTopMatches(ctx context.Context, req *GetTopMatchesRequest) (*GetTopMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopMatches not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetHeadToHead(ctx context.Context, req *GetHeadToHeadRequest) (*GetHeadToHeadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeadToHead not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetLeaderboard(ctx context.Context, req *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetLeaderboardForPeriod(ctx context.Context, req *GetLeaderboardForPeriodRequest) (*GetLeaderboardForPeriodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboardForPeriod not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClientStats(ctx context.Context, req *GetClientStatsRequest) (*GetClientStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientStats not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetScoreHistory(ctx context.Context, req *GetScoreHistoryRequest) (*GetScoreHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreHistory not implemented")
+}
+func (*UnimplementedClientsServiceServer) RecalculateScore(ctx context.Context, req *RecalculateScoreRequest) (*RecalculateScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateScore not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetClientsStats(ctx context.Context, req *GetClientsStatsRequest) (*GetClientsStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientsStats not implemented")
+}
+func (*UnimplementedClientsServiceServer) MergeClients(ctx context.Context, req *MergeClientsRequest) (*MergeClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) FindDuplicateClients(ctx context.Context, req *FindDuplicateClientsRequest) (*FindDuplicateClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicateClients not implemented")
+}
+func (*UnimplementedClientsServiceServer) Sort(ctx context.Context, req *SortRequest) (*SortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sort not implemented")
+}
+func (*UnimplementedClientsServiceServer) SortStream(srv ClientsService_SortStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SortStream not implemented")
+}
+func (*UnimplementedClientsServiceServer) StartSeason(ctx context.Context, req *StartSeasonRequest) (*StartSeasonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSeason not implemented")
+}
+func (*UnimplementedClientsServiceServer) GetUpcomingBirthdays(ctx context.Context, req *GetUpcomingBirthdaysRequest) (*GetUpcomingBirthdaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpcomingBirthdays not implemented")
+}
+
+func RegisterClientsServiceServer(s *grpc.Server, srv ClientsServiceServer) {
+	s.RegisterService(&_ClientsService_serviceDesc, srv)
+}
+
+func _ClientsService_NewClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).NewClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/NewClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).NewClient(ctx, req.(*NewClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_NewClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).NewClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/NewClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).NewClients(ctx, req.(*NewClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_QueryClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).QueryClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/QueryClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).QueryClients(ctx, req.(*QueryClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_CountClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).CountClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/CountClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).CountClients(ctx, req.(*CountClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_SampleClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SampleClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).SampleClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/SampleClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).SampleClients(ctx, req.(*SampleClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_SuggestClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).SuggestClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/SuggestClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).SuggestClients(ctx, req.(*SuggestClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetClients(ctx, req.(*GetClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetClient(ctx, req.(*GetClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_ClientExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).ClientExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/ClientExists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).ClientExists(ctx, req.(*ClientExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_ClientsExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientsExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).ClientsExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/ClientsExist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).ClientsExist(ctx, req.(*ClientsExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetClientByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetClientByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetClientByEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetClientByEmail(ctx, req.(*GetClientByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetClientByExternalId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientByExternalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetClientByExternalId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetClientByExternalId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetClientByExternalId(ctx, req.(*GetClientByExternalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_TouchClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TouchClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).TouchClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/TouchClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).TouchClient(ctx, req.(*TouchClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_UpdateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).UpdateClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/UpdateClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).UpdateClient(ctx, req.(*UpdateClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_CloneClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).CloneClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/CloneClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).CloneClient(ctx, req.(*CloneClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_UpsertClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).UpsertClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/UpsertClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).UpsertClient(ctx, req.(*UpsertClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteClient(ctx, req.(*DeleteClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteClients(ctx, req.(*DeleteClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteClientsByQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClientsByQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteClientsByQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteClientsByQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteClientsByQuery(ctx, req.(*DeleteClientsByQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_RestoreClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).RestoreClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/RestoreClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).RestoreClient(ctx, req.(*RestoreClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetArchivedClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchivedClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetArchivedClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetArchivedClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetArchivedClient(ctx, req.(*GetArchivedClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_PurgeDeletedClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).PurgeDeletedClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/PurgeDeletedClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).PurgeDeletedClients(ctx, req.(*PurgeDeletedClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteAllClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAllClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteAllClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteAllClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteAllClients(ctx, req.(*DeleteAllClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_AddClientTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddClientTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).AddClientTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/AddClientTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).AddClientTags(ctx, req.(*AddClientTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_RemoveClientTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveClientTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).RemoveClientTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/RemoveClientTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).RemoveClientTags(ctx, req.(*RemoveClientTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_SetClientStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClientStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).SetClientStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/SetClientStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).SetClientStatus(ctx, req.(*SetClientStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_NewMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).NewMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/NewMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).NewMatch(ctx, req.(*NewMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_NewMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).NewMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/NewMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).NewMatches(ctx, req.(*NewMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_AnonymizeClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnonymizeClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).AnonymizeClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/AnonymizeClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).AnonymizeClient(ctx, req.(*AnonymizeClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_UpdateMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).UpdateMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/UpdateMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).UpdateMatch(ctx, req.(*UpdateMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteMatch(ctx, req.(*DeleteMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_UndoLastMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoLastMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).UndoLastMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/UndoLastMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).UndoLastMatch(ctx, req.(*UndoLastMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteMatchesOlderThan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchesOlderThanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteMatchesOlderThan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/DeleteMatchesOlderThan",
 	}
-	return out, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteMatchesOlderThan(ctx, req.(*DeleteMatchesOlderThanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clientsServiceClient) NewMatch(ctx context.Context, in *NewMatchRequest, opts ...grpc.CallOption) (*NewMatchResponse, error) {
-	out := new(NewMatchResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/NewMatch", in, out, opts...)
-	if err != nil {
+func _ClientsService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/ListMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *clientsServiceClient) Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error) {
-	out := new(SortResponse)
-	err := c.cc.Invoke(ctx, "/pb.ClientsService/Sort", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _ClientsService_StreamMatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(ClientsServiceServer).StreamMatches(m, &clientsServiceStreamMatchesServer{stream})
 }
 
-// ClientsServiceServer is the server API for ClientsService service.
-type ClientsServiceServer interface {
-	NewClient(context.Context, *NewClientRequest) (*NewClientResponse, error)
-	QueryClients(context.Context, *QueryClientsRequest) (*QueryClientsResponse, error)
-	GetClients(context.Context, *GetClientsRequest) (*GetClientsResponse, error)
-	DeleteClient(context.Context, *DeleteClientRequest) (*DeleteClientResponse, error)
-	DeleteAllClients(context.Context, *DeleteAllClientsRequest) (*DeleteAllClientsResponse, error)
-	NewMatch(context.Context, *NewMatchRequest) (*NewMatchResponse, error)
-	Sort(context.Context, *SortRequest) (*SortResponse, error)
+type ClientsService_StreamMatchesServer interface {
+	Send(*Match) error
+	grpc.ServerStream
 }
 
-// UnimplementedClientsServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedClientsServiceServer struct {
+type clientsServiceStreamMatchesServer struct {
+	grpc.ServerStream
 }
 
-func (*UnimplementedClientsServiceServer) NewClient(ctx context.Context, req *NewClientRequest) (*NewClientResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewClient not implemented")
-}
-func (*UnimplementedClientsServiceServer) QueryClients(ctx context.Context, req *QueryClientsRequest) (*QueryClientsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method QueryClients not implemented")
+func (x *clientsServiceStreamMatchesServer) Send(m *Match) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedClientsServiceServer) GetClients(ctx context.Context, req *GetClientsRequest) (*GetClientsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetClients not implemented")
-}
-func (*UnimplementedClientsServiceServer) DeleteClient(ctx context.Context, req *DeleteClientRequest) (*DeleteClientResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteClient not implemented")
-}
-func (*UnimplementedClientsServiceServer) DeleteAllClients(ctx context.Context, req *DeleteAllClientsRequest) (*DeleteAllClientsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteAllClients not implemented")
+
+func _ClientsService_GetTopMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetTopMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetTopMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetTopMatches(ctx, req.(*GetTopMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedClientsServiceServer) NewMatch(ctx context.Context, req *NewMatchRequest) (*NewMatchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewMatch not implemented")
+
+func _ClientsService_GetHeadToHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeadToHeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetHeadToHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetHeadToHead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetHeadToHead(ctx, req.(*GetHeadToHeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedClientsServiceServer) Sort(ctx context.Context, req *SortRequest) (*SortResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Sort not implemented")
+
+func _ClientsService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterClientsServiceServer(s *grpc.Server, srv ClientsServiceServer) {
-	s.RegisterService(&_ClientsService_serviceDesc, srv)
+func _ClientsService_GetLeaderboardForPeriod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardForPeriodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetLeaderboardForPeriod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetLeaderboardForPeriod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetLeaderboardForPeriod(ctx, req.(*GetLeaderboardForPeriodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_NewClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewClientRequest)
+func _ClientsService_GetClientStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).NewClient(ctx, in)
+		return srv.(ClientsServiceServer).GetClientStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/NewClient",
+		FullMethod: "/pb.ClientsService/GetClientStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).NewClient(ctx, req.(*NewClientRequest))
+		return srv.(ClientsServiceServer).GetClientStats(ctx, req.(*GetClientStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_QueryClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryClientsRequest)
+func _ClientsService_GetScoreHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).QueryClients(ctx, in)
+		return srv.(ClientsServiceServer).GetScoreHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/QueryClients",
+		FullMethod: "/pb.ClientsService/GetScoreHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).QueryClients(ctx, req.(*QueryClientsRequest))
+		return srv.(ClientsServiceServer).GetScoreHistory(ctx, req.(*GetScoreHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_GetClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetClientsRequest)
+func _ClientsService_RecalculateScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateScoreRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).GetClients(ctx, in)
+		return srv.(ClientsServiceServer).RecalculateScore(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/GetClients",
+		FullMethod: "/pb.ClientsService/RecalculateScore",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).GetClients(ctx, req.(*GetClientsRequest))
+		return srv.(ClientsServiceServer).RecalculateScore(ctx, req.(*RecalculateScoreRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_DeleteClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteClientRequest)
+func _ClientsService_GetClientsStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientsStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).DeleteClient(ctx, in)
+		return srv.(ClientsServiceServer).GetClientsStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/DeleteClient",
+		FullMethod: "/pb.ClientsService/GetClientsStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).DeleteClient(ctx, req.(*DeleteClientRequest))
+		return srv.(ClientsServiceServer).GetClientsStats(ctx, req.(*GetClientsStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_DeleteAllClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAllClientsRequest)
+func _ClientsService_MergeClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeClientsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).DeleteAllClients(ctx, in)
+		return srv.(ClientsServiceServer).MergeClients(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/DeleteAllClients",
+		FullMethod: "/pb.ClientsService/MergeClients",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).DeleteAllClients(ctx, req.(*DeleteAllClientsRequest))
+		return srv.(ClientsServiceServer).MergeClients(ctx, req.(*MergeClientsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ClientsService_NewMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewMatchRequest)
+func _ClientsService_FindDuplicateClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicateClientsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClientsServiceServer).NewMatch(ctx, in)
+		return srv.(ClientsServiceServer).FindDuplicateClients(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.ClientsService/NewMatch",
+		FullMethod: "/pb.ClientsService/FindDuplicateClients",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClientsServiceServer).NewMatch(ctx, req.(*NewMatchRequest))
+		return srv.(ClientsServiceServer).FindDuplicateClients(ctx, req.(*FindDuplicateClientsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -929,6 +7820,68 @@ func _ClientsService_Sort_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientsService_SortStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClientsServiceServer).SortStream(&clientsServiceSortStreamServer{stream})
+}
+
+type ClientsService_SortStreamServer interface {
+	Send(*SortResponse) error
+	Recv() (*SortRequest, error)
+	grpc.ServerStream
+}
+
+type clientsServiceSortStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientsServiceSortStreamServer) Send(m *SortResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *clientsServiceSortStreamServer) Recv() (*SortRequest, error) {
+	m := new(SortRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ClientsService_StartSeason_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSeasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).StartSeason(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/StartSeason",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).StartSeason(ctx, req.(*StartSeasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetUpcomingBirthdays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUpcomingBirthdaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetUpcomingBirthdays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ClientsService/GetUpcomingBirthdays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetUpcomingBirthdays(ctx, req.(*GetUpcomingBirthdaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ClientsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.ClientsService",
 	HandlerType: (*ClientsServiceServer)(nil),
@@ -937,31 +7890,207 @@ var _ClientsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewClient",
 			Handler:    _ClientsService_NewClient_Handler,
 		},
+		{
+			MethodName: "NewClients",
+			Handler:    _ClientsService_NewClients_Handler,
+		},
 		{
 			MethodName: "QueryClients",
 			Handler:    _ClientsService_QueryClients_Handler,
 		},
+		{
+			MethodName: "CountClients",
+			Handler:    _ClientsService_CountClients_Handler,
+		},
+		{
+			MethodName: "SampleClients",
+			Handler:    _ClientsService_SampleClients_Handler,
+		},
+		{
+			MethodName: "SuggestClients",
+			Handler:    _ClientsService_SuggestClients_Handler,
+		},
 		{
 			MethodName: "GetClients",
 			Handler:    _ClientsService_GetClients_Handler,
 		},
+		{
+			MethodName: "GetClient",
+			Handler:    _ClientsService_GetClient_Handler,
+		},
+		{
+			MethodName: "ClientExists",
+			Handler:    _ClientsService_ClientExists_Handler,
+		},
+		{
+			MethodName: "ClientsExist",
+			Handler:    _ClientsService_ClientsExist_Handler,
+		},
+		{
+			MethodName: "GetClientByEmail",
+			Handler:    _ClientsService_GetClientByEmail_Handler,
+		},
+		{
+			MethodName: "GetClientByExternalId",
+			Handler:    _ClientsService_GetClientByExternalId_Handler,
+		},
+		{
+			MethodName: "TouchClient",
+			Handler:    _ClientsService_TouchClient_Handler,
+		},
+		{
+			MethodName: "UpdateClient",
+			Handler:    _ClientsService_UpdateClient_Handler,
+		},
+		{
+			MethodName: "CloneClient",
+			Handler:    _ClientsService_CloneClient_Handler,
+		},
+		{
+			MethodName: "UpsertClient",
+			Handler:    _ClientsService_UpsertClient_Handler,
+		},
 		{
 			MethodName: "DeleteClient",
 			Handler:    _ClientsService_DeleteClient_Handler,
 		},
+		{
+			MethodName: "DeleteClients",
+			Handler:    _ClientsService_DeleteClients_Handler,
+		},
+		{
+			MethodName: "DeleteClientsByQuery",
+			Handler:    _ClientsService_DeleteClientsByQuery_Handler,
+		},
+		{
+			MethodName: "RestoreClient",
+			Handler:    _ClientsService_RestoreClient_Handler,
+		},
+		{
+			MethodName: "GetArchivedClient",
+			Handler:    _ClientsService_GetArchivedClient_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedClients",
+			Handler:    _ClientsService_PurgeDeletedClients_Handler,
+		},
 		{
 			MethodName: "DeleteAllClients",
 			Handler:    _ClientsService_DeleteAllClients_Handler,
 		},
+		{
+			MethodName: "AddClientTags",
+			Handler:    _ClientsService_AddClientTags_Handler,
+		},
+		{
+			MethodName: "RemoveClientTags",
+			Handler:    _ClientsService_RemoveClientTags_Handler,
+		},
+		{
+			MethodName: "SetClientStatus",
+			Handler:    _ClientsService_SetClientStatus_Handler,
+		},
 		{
 			MethodName: "NewMatch",
 			Handler:    _ClientsService_NewMatch_Handler,
 		},
+		{
+			MethodName: "NewMatches",
+			Handler:    _ClientsService_NewMatches_Handler,
+		},
+		{
+			MethodName: "AnonymizeClient",
+			Handler:    _ClientsService_AnonymizeClient_Handler,
+		},
+		{
+			MethodName: "GetMatch",
+			Handler:    _ClientsService_GetMatch_Handler,
+		},
+		{
+			MethodName: "UpdateMatch",
+			Handler:    _ClientsService_UpdateMatch_Handler,
+		},
+		{
+			MethodName: "DeleteMatch",
+			Handler:    _ClientsService_DeleteMatch_Handler,
+		},
+		{
+			MethodName: "UndoLastMatch",
+			Handler:    _ClientsService_UndoLastMatch_Handler,
+		},
+		{
+			MethodName: "DeleteMatchesOlderThan",
+			Handler:    _ClientsService_DeleteMatchesOlderThan_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _ClientsService_ListMatches_Handler,
+		},
+		{
+			MethodName: "GetTopMatches",
+			Handler:    _ClientsService_GetTopMatches_Handler,
+		},
+		{
+			MethodName: "GetHeadToHead",
+			Handler:    _ClientsService_GetHeadToHead_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _ClientsService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetLeaderboardForPeriod",
+			Handler:    _ClientsService_GetLeaderboardForPeriod_Handler,
+		},
+		{
+			MethodName: "GetClientStats",
+			Handler:    _ClientsService_GetClientStats_Handler,
+		},
+		{
+			MethodName: "GetScoreHistory",
+			Handler:    _ClientsService_GetScoreHistory_Handler,
+		},
+		{
+			MethodName: "RecalculateScore",
+			Handler:    _ClientsService_RecalculateScore_Handler,
+		},
+		{
+			MethodName: "GetClientsStats",
+			Handler:    _ClientsService_GetClientsStats_Handler,
+		},
+		{
+			MethodName: "MergeClients",
+			Handler:    _ClientsService_MergeClients_Handler,
+		},
+		{
+			MethodName: "FindDuplicateClients",
+			Handler:    _ClientsService_FindDuplicateClients_Handler,
+		},
 		{
 			MethodName: "Sort",
 			Handler:    _ClientsService_Sort_Handler,
 		},
+		{
+			MethodName: "StartSeason",
+			Handler:    _ClientsService_StartSeason_Handler,
+		},
+		{
+			MethodName: "GetUpcomingBirthdays",
+			Handler:    _ClientsService_GetUpcomingBirthdays_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMatches",
+			Handler:       _ClientsService_StreamMatches_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SortStream",
+			Handler:       _ClientsService_SortStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "clservice.proto",
 }
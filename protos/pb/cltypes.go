@@ -1,6 +1,8 @@
 package pb
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 )
 
@@ -8,9 +10,37 @@ func (x *Int64Comp) Where(column string, rq sq.SelectBuilder) sq.SelectBuilder {
 	if x == nil {
 		return rq
 	}
+	return rq.Where(x.Pred(column))
+}
+
+// Pred returns the comparison of column against x as a predicate usable by any statement builder
+func (x *Int64Comp) Pred(column string) sq.Sqlizer {
+	return sq.Expr(column+" "+x.op()+" ?", x.Value)
+}
+
+// TimePred is Pred for datetime columns: x.Value is taken as unixnano and compared as a time, since
+// MySQL would compare the column to a bare number as YYYYMMDDhhmmss
+func (x *Int64Comp) TimePred(column string) sq.Sqlizer {
+	return sq.Expr(column+" "+x.op()+" ?", time.Unix(0, x.Value))
+}
+
+// op is the comparison operator of x, defaulting to equality
+func (x *Int64Comp) op() string {
 	switch x.Op {
 	case ">", "<", ">=", "<=", "=", "!=":
-		return rq.Where(column+" "+x.Op+" ?", x.Value)
+		return x.Op
 	}
-	return rq.Where(column+" = ?", x.Value)
+	return "="
 }
+
+// NullPred returns column IS NULL when x is true and column IS NOT NULL when it's false
+func (x *OptBool) NullPred(column string) sq.Sqlizer {
+	if x.Value {
+		return sq.Eq{column: nil}
+	}
+	return sq.NotEq{column: nil}
+}
+
+// ClientsServiceDesc describes pb.ClientsService as RegisterClientsServiceServer registers it, for registering
+// it with wrapped handlers
+var ClientsServiceDesc = _ClientsService_serviceDesc
@@ -20,12 +20,110 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ClientStatus int32
+
+const (
+	ClientStatus_ACTIVE    ClientStatus = 0
+	ClientStatus_SUSPENDED ClientStatus = 1
+	ClientStatus_BANNED    ClientStatus = 2
+)
+
+var ClientStatus_name = map[int32]string{
+	0: "ACTIVE",
+	1: "SUSPENDED",
+	2: "BANNED",
+}
+
+var ClientStatus_value = map[string]int32{
+	"ACTIVE":    0,
+	"SUSPENDED": 1,
+	"BANNED":    2,
+}
+
+func (x ClientStatus) String() string {
+	return proto.EnumName(ClientStatus_name, int32(x))
+}
+
+func (ClientStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{0}
+}
+
+type MatchType int32
+
+const (
+	MatchType_RANKED   MatchType = 0
+	MatchType_PRACTICE MatchType = 1
+)
+
+var MatchType_name = map[int32]string{
+	0: "RANKED",
+	1: "PRACTICE",
+}
+
+var MatchType_value = map[string]int32{
+	"RANKED":   0,
+	"PRACTICE": 1,
+}
+
+func (x MatchType) String() string {
+	return proto.EnumName(MatchType_name, int32(x))
+}
+
+func (MatchType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{1}
+}
+
+type MatchResult int32
+
+const (
+	MatchResult_NO_RESULT MatchResult = 0
+	MatchResult_WIN       MatchResult = 1
+	MatchResult_LOSS      MatchResult = 2
+	MatchResult_DRAW      MatchResult = 3
+)
+
+var MatchResult_name = map[int32]string{
+	0: "NO_RESULT",
+	1: "WIN",
+	2: "LOSS",
+	3: "DRAW",
+}
+
+var MatchResult_value = map[string]int32{
+	"NO_RESULT": 0,
+	"WIN":       1,
+	"LOSS":      2,
+	"DRAW":      3,
+}
+
+func (x MatchResult) String() string {
+	return proto.EnumName(MatchResult_name, int32(x))
+}
+
+func (MatchResult) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{2}
+}
+
 type Client struct {
-	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Birthday int64  `protobuf:"varint,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
-	Score    int64  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
-	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id         string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Birthday   int64             `protobuf:"varint,3,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	Score      int64             `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	CreatedAt  int64             `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Email      string            `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Phone      string            `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone,omitempty"`
+	Tags       []string          `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata   map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Version    int64             `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt  int64             `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status     ClientStatus      `protobuf:"varint,12,opt,name=status,proto3,enum=pb.ClientStatus" json:"status,omitempty"`
+	Notes      string            `protobuf:"bytes,13,opt,name=notes,proto3" json:"notes,omitempty"`
+	ExternalId string            `protobuf:"bytes,14,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Anonymized bool              `protobuf:"varint,15,opt,name=anonymized,proto3" json:"anonymized,omitempty"`
+	LastSeenAt int64             `protobuf:"varint,16,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// unixnano of the soft deletion, only set on deleted clients returned with
+	// include_deleted
+	DeletedAt            int64    `protobuf:"varint,17,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -91,6 +189,272 @@ func (m *Client) GetCreatedAt() int64 {
 	return 0
 }
 
+func (m *Client) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *Client) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *Client) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Client) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Client) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Client) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *Client) GetStatus() ClientStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ClientStatus_ACTIVE
+}
+
+func (m *Client) GetNotes() string {
+	if m != nil {
+		return m.Notes
+	}
+	return ""
+}
+
+func (m *Client) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+func (m *Client) GetAnonymized() bool {
+	if m != nil {
+		return m.Anonymized
+	}
+	return false
+}
+
+func (m *Client) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
+func (m *Client) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return 0
+}
+
+type Match struct {
+	Id                   int64       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientId             string      `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Score                int64       `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	CreatedAt            int64       `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	OpponentId           string      `protobuf:"bytes,5,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"`
+	Result               MatchResult `protobuf:"varint,6,opt,name=result,proto3,enum=pb.MatchResult" json:"result,omitempty"`
+	PlayedAt             int64       `protobuf:"varint,7,opt,name=played_at,json=playedAt,proto3" json:"played_at,omitempty"`
+	MatchType            MatchType   `protobuf:"varint,8,opt,name=match_type,json=matchType,proto3,enum=pb.MatchType" json:"match_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Match) Reset()         { *m = Match{} }
+func (m *Match) String() string { return proto.CompactTextString(m) }
+func (*Match) ProtoMessage()    {}
+func (*Match) Descriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{1}
+}
+
+func (m *Match) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Match.Unmarshal(m, b)
+}
+func (m *Match) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Match.Marshal(b, m, deterministic)
+}
+func (m *Match) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Match.Merge(m, src)
+}
+func (m *Match) XXX_Size() int {
+	return xxx_messageInfo_Match.Size(m)
+}
+func (m *Match) XXX_DiscardUnknown() {
+	xxx_messageInfo_Match.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Match proto.InternalMessageInfo
+
+func (m *Match) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Match) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *Match) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Match) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Match) GetOpponentId() string {
+	if m != nil {
+		return m.OpponentId
+	}
+	return ""
+}
+
+func (m *Match) GetResult() MatchResult {
+	if m != nil {
+		return m.Result
+	}
+	return MatchResult_NO_RESULT
+}
+
+func (m *Match) GetPlayedAt() int64 {
+	if m != nil {
+		return m.PlayedAt
+	}
+	return 0
+}
+
+func (m *Match) GetMatchType() MatchType {
+	if m != nil {
+		return m.MatchType
+	}
+	return MatchType_RANKED
+}
+
+type ClientStats struct {
+	MatchCount           int64    `protobuf:"varint,1,opt,name=match_count,json=matchCount,proto3" json:"match_count,omitempty"`
+	TotalScore           int64    `protobuf:"varint,2,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
+	AverageScore         float64  `protobuf:"fixed64,3,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	BestScore            int64    `protobuf:"varint,4,opt,name=best_score,json=bestScore,proto3" json:"best_score,omitempty"`
+	WorstScore           int64    `protobuf:"varint,5,opt,name=worst_score,json=worstScore,proto3" json:"worst_score,omitempty"`
+	FirstMatchAt         int64    `protobuf:"varint,6,opt,name=first_match_at,json=firstMatchAt,proto3" json:"first_match_at,omitempty"`
+	LastMatchAt          int64    `protobuf:"varint,7,opt,name=last_match_at,json=lastMatchAt,proto3" json:"last_match_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientStats) Reset()         { *m = ClientStats{} }
+func (m *ClientStats) String() string { return proto.CompactTextString(m) }
+func (*ClientStats) ProtoMessage()    {}
+func (*ClientStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{2}
+}
+
+func (m *ClientStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientStats.Unmarshal(m, b)
+}
+func (m *ClientStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientStats.Marshal(b, m, deterministic)
+}
+func (m *ClientStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientStats.Merge(m, src)
+}
+func (m *ClientStats) XXX_Size() int {
+	return xxx_messageInfo_ClientStats.Size(m)
+}
+func (m *ClientStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientStats proto.InternalMessageInfo
+
+func (m *ClientStats) GetMatchCount() int64 {
+	if m != nil {
+		return m.MatchCount
+	}
+	return 0
+}
+
+func (m *ClientStats) GetTotalScore() int64 {
+	if m != nil {
+		return m.TotalScore
+	}
+	return 0
+}
+
+func (m *ClientStats) GetAverageScore() float64 {
+	if m != nil {
+		return m.AverageScore
+	}
+	return 0
+}
+
+func (m *ClientStats) GetBestScore() int64 {
+	if m != nil {
+		return m.BestScore
+	}
+	return 0
+}
+
+func (m *ClientStats) GetWorstScore() int64 {
+	if m != nil {
+		return m.WorstScore
+	}
+	return 0
+}
+
+func (m *ClientStats) GetFirstMatchAt() int64 {
+	if m != nil {
+		return m.FirstMatchAt
+	}
+	return 0
+}
+
+func (m *ClientStats) GetLastMatchAt() int64 {
+	if m != nil {
+		return m.LastMatchAt
+	}
+	return 0
+}
+
 type OptInt64 struct {
 	Value                int64    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -102,7 +466,7 @@ func (m *OptInt64) Reset()         { *m = OptInt64{} }
 func (m *OptInt64) String() string { return proto.CompactTextString(m) }
 func (*OptInt64) ProtoMessage()    {}
 func (*OptInt64) Descriptor() ([]byte, []int) {
-	return fileDescriptor_597723fcca9cabf3, []int{1}
+	return fileDescriptor_597723fcca9cabf3, []int{3}
 }
 
 func (m *OptInt64) XXX_Unmarshal(b []byte) error {
@@ -141,7 +505,7 @@ func (m *OptString) Reset()         { *m = OptString{} }
 func (m *OptString) String() string { return proto.CompactTextString(m) }
 func (*OptString) ProtoMessage()    {}
 func (*OptString) Descriptor() ([]byte, []int) {
-	return fileDescriptor_597723fcca9cabf3, []int{2}
+	return fileDescriptor_597723fcca9cabf3, []int{4}
 }
 
 func (m *OptString) XXX_Unmarshal(b []byte) error {
@@ -169,6 +533,86 @@ func (m *OptString) GetValue() string {
 	return ""
 }
 
+type OptStringMap struct {
+	Value                map[string]string `protobuf:"bytes,1,rep,name=value,proto3" json:"value,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OptStringMap) Reset()         { *m = OptStringMap{} }
+func (m *OptStringMap) String() string { return proto.CompactTextString(m) }
+func (*OptStringMap) ProtoMessage()    {}
+func (*OptStringMap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{5}
+}
+
+func (m *OptStringMap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OptStringMap.Unmarshal(m, b)
+}
+func (m *OptStringMap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OptStringMap.Marshal(b, m, deterministic)
+}
+func (m *OptStringMap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OptStringMap.Merge(m, src)
+}
+func (m *OptStringMap) XXX_Size() int {
+	return xxx_messageInfo_OptStringMap.Size(m)
+}
+func (m *OptStringMap) XXX_DiscardUnknown() {
+	xxx_messageInfo_OptStringMap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OptStringMap proto.InternalMessageInfo
+
+func (m *OptStringMap) GetValue() map[string]string {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// unset, true or false; as a filter of a nullable column, true matches NULL
+// and false matches any value
+type OptBool struct {
+	Value                bool     `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OptBool) Reset()         { *m = OptBool{} }
+func (m *OptBool) String() string { return proto.CompactTextString(m) }
+func (*OptBool) ProtoMessage()    {}
+func (*OptBool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{6}
+}
+
+func (m *OptBool) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OptBool.Unmarshal(m, b)
+}
+func (m *OptBool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OptBool.Marshal(b, m, deterministic)
+}
+func (m *OptBool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OptBool.Merge(m, src)
+}
+func (m *OptBool) XXX_Size() int {
+	return xxx_messageInfo_OptBool.Size(m)
+}
+func (m *OptBool) XXX_DiscardUnknown() {
+	xxx_messageInfo_OptBool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OptBool proto.InternalMessageInfo
+
+func (m *OptBool) GetValue() bool {
+	if m != nil {
+		return m.Value
+	}
+	return false
+}
+
 type Int64Comp struct {
 	Value                int64    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
 	Op                   string   `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
@@ -181,7 +625,7 @@ func (m *Int64Comp) Reset()         { *m = Int64Comp{} }
 func (m *Int64Comp) String() string { return proto.CompactTextString(m) }
 func (*Int64Comp) ProtoMessage()    {}
 func (*Int64Comp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_597723fcca9cabf3, []int{3}
+	return fileDescriptor_597723fcca9cabf3, []int{7}
 }
 
 func (m *Int64Comp) XXX_Unmarshal(b []byte) error {
@@ -216,31 +660,126 @@ func (m *Int64Comp) GetOp() string {
 	return ""
 }
 
+type Season struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartedAt            int64    `protobuf:"varint,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Season) Reset()         { *m = Season{} }
+func (m *Season) String() string { return proto.CompactTextString(m) }
+func (*Season) ProtoMessage()    {}
+func (*Season) Descriptor() ([]byte, []int) {
+	return fileDescriptor_597723fcca9cabf3, []int{8}
+}
+
+func (m *Season) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Season.Unmarshal(m, b)
+}
+func (m *Season) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Season.Marshal(b, m, deterministic)
+}
+func (m *Season) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Season.Merge(m, src)
+}
+func (m *Season) XXX_Size() int {
+	return xxx_messageInfo_Season.Size(m)
+}
+func (m *Season) XXX_DiscardUnknown() {
+	xxx_messageInfo_Season.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Season proto.InternalMessageInfo
+
+func (m *Season) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Season) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("pb.ClientStatus", ClientStatus_name, ClientStatus_value)
+	proto.RegisterEnum("pb.MatchType", MatchType_name, MatchType_value)
+	proto.RegisterEnum("pb.MatchResult", MatchResult_name, MatchResult_value)
 	proto.RegisterType((*Client)(nil), "pb.Client")
+	proto.RegisterMapType((map[string]string)(nil), "pb.Client.MetadataEntry")
+	proto.RegisterType((*Match)(nil), "pb.Match")
+	proto.RegisterType((*ClientStats)(nil), "pb.ClientStats")
 	proto.RegisterType((*OptInt64)(nil), "pb.OptInt64")
 	proto.RegisterType((*OptString)(nil), "pb.OptString")
+	proto.RegisterType((*OptStringMap)(nil), "pb.OptStringMap")
+	proto.RegisterMapType((map[string]string)(nil), "pb.OptStringMap.ValueEntry")
+	proto.RegisterType((*OptBool)(nil), "pb.OptBool")
 	proto.RegisterType((*Int64Comp)(nil), "pb.Int64Comp")
+	proto.RegisterType((*Season)(nil), "pb.Season")
 }
 
 func init() { proto.RegisterFile("cltypes.proto", fileDescriptor_597723fcca9cabf3) }
 
 var fileDescriptor_597723fcca9cabf3 = []byte{
-	// 245 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x50, 0x3d, 0x4b, 0x03, 0x41,
-	0x10, 0xe5, 0xee, 0x92, 0x90, 0x1d, 0xd0, 0x62, 0xb1, 0x58, 0x04, 0xe1, 0xbc, 0x2a, 0x8d, 0x39,
-	0x24, 0x6a, 0xaf, 0xa9, 0xac, 0x02, 0x67, 0x67, 0x23, 0xfb, 0xc5, 0x65, 0xe1, 0x6e, 0x77, 0xd8,
-	0x9d, 0x04, 0x52, 0xf8, 0xdf, 0x25, 0x9b, 0x28, 0x08, 0xe9, 0xe6, 0x7d, 0xf0, 0x98, 0xf7, 0xe0,
-	0x4a, 0x0f, 0x74, 0x40, 0x9b, 0x96, 0x18, 0x03, 0x05, 0x5e, 0xa2, 0x6a, 0xbe, 0x61, 0xb6, 0x1e,
-	0x9c, 0xf5, 0xc4, 0xaf, 0xa1, 0x74, 0x46, 0x14, 0x75, 0xb1, 0x60, 0x5d, 0xe9, 0x0c, 0xe7, 0x30,
-	0xf1, 0x72, 0xb4, 0xa2, 0xcc, 0x4c, 0xbe, 0xf9, 0x2d, 0xcc, 0x95, 0x8b, 0xb4, 0x35, 0xf2, 0x20,
-	0xaa, 0xba, 0x58, 0x54, 0xdd, 0x1f, 0xe6, 0x37, 0x30, 0x4d, 0x3a, 0x44, 0x2b, 0x26, 0x59, 0x38,
-	0x01, 0x7e, 0x07, 0xa0, 0xa3, 0x95, 0x64, 0xcd, 0x97, 0x24, 0x31, 0xcd, 0x12, 0x3b, 0x33, 0xaf,
-	0xd4, 0xd4, 0x30, 0xdf, 0x20, 0xbd, 0x7b, 0x7a, 0x79, 0x3a, 0x06, 0xec, 0xe5, 0xb0, 0xb3, 0xf9,
-	0x87, 0xaa, 0x3b, 0x81, 0xe6, 0x1e, 0xd8, 0x06, 0xe9, 0x83, 0xa2, 0xf3, 0xfd, 0x7f, 0x0b, 0xfb,
-	0xb5, 0x3c, 0x02, 0xcb, 0x09, 0xeb, 0x30, 0xe2, 0xe5, 0x94, 0x63, 0xb9, 0x80, 0xe7, 0x2a, 0x65,
-	0xc0, 0xb7, 0xe7, 0xcf, 0x55, 0xef, 0x68, 0xbb, 0x53, 0x4b, 0x1d, 0xc6, 0x16, 0xad, 0x71, 0x26,
-	0xa0, 0xec, 0x43, 0x4b, 0x51, 0x3a, 0xef, 0x7c, 0x9f, 0xf6, 0xfa, 0x41, 0xe7, 0x61, 0x52, 0x9b,
-	0xd7, 0x4a, 0x2d, 0x2a, 0x35, 0xcb, 0xe7, 0xea, 0x27, 0x00, 0x00, 0xff, 0xff, 0xd1, 0xc5, 0x70,
-	0xd2, 0x49, 0x01, 0x00, 0x00,
+	// 857 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdf, 0x6f, 0xe3, 0x44,
+	0x10, 0xae, 0xe3, 0x34, 0xb1, 0x27, 0x3f, 0xce, 0xac, 0x78, 0x58, 0xf5, 0x74, 0x34, 0x04, 0x10,
+	0x51, 0x05, 0xa9, 0xae, 0x77, 0x07, 0x07, 0x3c, 0xb9, 0x49, 0x1e, 0x22, 0xae, 0xc9, 0xc9, 0xee,
+	0x5d, 0x25, 0x5e, 0xa2, 0x4d, 0xbc, 0xa4, 0x16, 0xce, 0xee, 0xca, 0x9e, 0x14, 0x02, 0x7f, 0x12,
+	0xff, 0x23, 0x42, 0xbb, 0x6b, 0x27, 0x29, 0x3f, 0x1e, 0xee, 0x6d, 0xe6, 0x9b, 0xd9, 0xf1, 0x37,
+	0x33, 0xdf, 0x24, 0xd0, 0x59, 0x65, 0xb8, 0x53, 0xbc, 0x18, 0xaa, 0x5c, 0xa2, 0x24, 0x35, 0xb5,
+	0xec, 0xff, 0x59, 0x87, 0xc6, 0x28, 0x4b, 0xb9, 0x40, 0xd2, 0x85, 0x5a, 0x9a, 0x50, 0xa7, 0xe7,
+	0x0c, 0xfc, 0xa8, 0x96, 0x26, 0x84, 0x40, 0x5d, 0xb0, 0x0d, 0xa7, 0x35, 0x83, 0x18, 0x9b, 0x9c,
+	0x81, 0xb7, 0x4c, 0x73, 0xbc, 0x4f, 0xd8, 0x8e, 0xba, 0x3d, 0x67, 0xe0, 0x46, 0x7b, 0x9f, 0x7c,
+	0x0c, 0xa7, 0xc5, 0x4a, 0xe6, 0x9c, 0xd6, 0x4d, 0xc0, 0x3a, 0xe4, 0x19, 0xc0, 0x2a, 0xe7, 0x0c,
+	0x79, 0xb2, 0x60, 0x48, 0x4f, 0x4d, 0xc8, 0x2f, 0x91, 0x10, 0xf5, 0x23, 0xbe, 0x61, 0x69, 0x46,
+	0x1b, 0xe6, 0x2b, 0xd6, 0xd1, 0xa8, 0xba, 0x97, 0x82, 0xd3, 0xa6, 0x45, 0x8d, 0xa3, 0x09, 0x21,
+	0x5b, 0x17, 0xd4, 0xeb, 0xb9, 0x9a, 0x90, 0xb6, 0xc9, 0x4b, 0xf0, 0x36, 0x1c, 0x59, 0xc2, 0x90,
+	0x51, 0xbf, 0xe7, 0x0e, 0x5a, 0x57, 0x74, 0xa8, 0x96, 0x43, 0xdb, 0xd2, 0xf0, 0xa6, 0x0c, 0x4d,
+	0x04, 0xe6, 0xbb, 0x68, 0x9f, 0x49, 0x28, 0x34, 0x1f, 0x78, 0x5e, 0xa4, 0x52, 0x50, 0x30, 0x8c,
+	0x2a, 0x57, 0xd3, 0xdd, 0xaa, 0xa4, 0xa2, 0xdb, 0xb2, 0x74, 0x4b, 0x24, 0x44, 0x32, 0x80, 0x46,
+	0x81, 0x0c, 0xb7, 0x05, 0x6d, 0xf7, 0x9c, 0x41, 0xf7, 0x2a, 0x38, 0x7c, 0x2c, 0x36, 0x78, 0x54,
+	0xc6, 0x75, 0x0b, 0x42, 0x22, 0x2f, 0x68, 0xc7, 0xb6, 0x60, 0x1c, 0x72, 0x0e, 0x2d, 0xfe, 0x1b,
+	0xf2, 0x5c, 0xb0, 0x6c, 0x91, 0x26, 0xb4, 0x6b, 0x62, 0x50, 0x41, 0xd3, 0x84, 0x7c, 0x02, 0xc0,
+	0x84, 0x14, 0xbb, 0x4d, 0xfa, 0x3b, 0x4f, 0xe8, 0x93, 0x9e, 0x33, 0xf0, 0xa2, 0x23, 0x84, 0xf4,
+	0xa0, 0x9d, 0xb1, 0x02, 0x17, 0x05, 0xe7, 0x42, 0x33, 0x0c, 0x0c, 0x43, 0xd0, 0x58, 0xcc, 0xb9,
+	0x08, 0x51, 0x77, 0x90, 0xf0, 0x8c, 0x97, 0x1d, 0x7c, 0x64, 0x3b, 0x28, 0x91, 0x10, 0xcf, 0x7e,
+	0x80, 0xce, 0xa3, 0xa9, 0x90, 0x00, 0xdc, 0x5f, 0xf8, 0xae, 0xdc, 0xbb, 0x36, 0x35, 0xf5, 0x07,
+	0x96, 0x6d, 0xab, 0xcd, 0x5b, 0xe7, 0xfb, 0xda, 0x6b, 0xa7, 0xff, 0x97, 0x03, 0xa7, 0x37, 0x0c,
+	0x57, 0xf7, 0x47, 0x62, 0x71, 0x8d, 0x58, 0x9e, 0x82, 0xbf, 0x32, 0x63, 0xd0, 0x6d, 0xd9, 0x77,
+	0x9e, 0x05, 0xa6, 0xc9, 0x41, 0x19, 0xee, 0xff, 0x2b, 0xa3, 0xfe, 0x4f, 0x65, 0x9c, 0x43, 0x4b,
+	0x2a, 0x25, 0x45, 0x59, 0xf3, 0xd4, 0x8e, 0xaa, 0x82, 0xa6, 0x09, 0xf9, 0x12, 0x1a, 0x39, 0x2f,
+	0xb6, 0x19, 0x1a, 0xed, 0x74, 0xaf, 0x9e, 0xe8, 0x5d, 0x18, 0x76, 0x91, 0x81, 0xa3, 0x32, 0xac,
+	0xb9, 0xa9, 0x8c, 0xed, 0xec, 0x77, 0x9a, 0x56, 0xb5, 0x16, 0x08, 0x91, 0x7c, 0x05, 0xb0, 0xd1,
+	0x6f, 0x16, 0xfa, 0x32, 0xa8, 0x67, 0x2a, 0x75, 0xf6, 0x95, 0x6e, 0x77, 0x8a, 0x47, 0xfe, 0xa6,
+	0x32, 0xf5, 0x00, 0x5a, 0x87, 0x75, 0x9b, 0x7d, 0xda, 0xd7, 0x2b, 0xb9, 0x15, 0x58, 0xce, 0xc3,
+	0x16, 0x1c, 0x69, 0x44, 0x27, 0xa0, 0x44, 0x96, 0x2d, 0xec, 0x00, 0x6a, 0x36, 0xc1, 0x40, 0xb1,
+	0x99, 0xc2, 0x67, 0xd0, 0x61, 0x0f, 0x3c, 0x67, 0x6b, 0xbe, 0x38, 0xcc, 0xc8, 0x89, 0xda, 0x25,
+	0x18, 0x57, 0xa3, 0x5a, 0x72, 0xbd, 0xf5, 0xa3, 0xfb, 0xf2, 0x35, 0x62, 0xc3, 0xe7, 0xd0, 0xfa,
+	0x55, 0xe6, 0xfb, 0xb8, 0x3d, 0x32, 0x30, 0x90, 0x4d, 0xf8, 0x1c, 0xba, 0x3f, 0xa7, 0x3a, 0xc1,
+	0x92, 0x65, 0x76, 0x64, 0x6e, 0xd4, 0x36, 0xa8, 0xe9, 0x34, 0x44, 0xd2, 0x87, 0x8e, 0xd1, 0xd6,
+	0x3e, 0xc9, 0xce, 0xaa, 0xa5, 0xc1, 0x32, 0xa7, 0xdf, 0x03, 0x6f, 0xae, 0x70, 0x2a, 0xf0, 0x9b,
+	0x97, 0x07, 0x9d, 0xd8, 0xb6, 0xad, 0xd3, 0xff, 0x14, 0xfc, 0xb9, 0xc2, 0x18, 0xf3, 0x54, 0xac,
+	0x1f, 0xa7, 0x54, 0x52, 0xea, 0xff, 0x01, 0xed, 0x7d, 0xca, 0x0d, 0x53, 0xe4, 0xf9, 0x21, 0x4b,
+	0x5f, 0xf0, 0x53, 0x3d, 0xfe, 0xe3, 0x84, 0xe1, 0x7b, 0x1d, 0xb5, 0x47, 0x6c, 0x33, 0xcf, 0x5e,
+	0x03, 0x1c, 0xc0, 0x0f, 0xd2, 0xf0, 0x39, 0x34, 0xe7, 0x0a, 0xaf, 0xa5, 0xcc, 0x1e, 0xb3, 0xf3,
+	0x2a, 0x76, 0xcf, 0xc1, 0x37, 0xfd, 0x8d, 0xe4, 0x46, 0xfd, 0x77, 0x8f, 0x5a, 0xfd, 0x52, 0x95,
+	0xa5, 0x6b, 0x52, 0xf5, 0xbf, 0x85, 0x46, 0xcc, 0x59, 0x21, 0xc5, 0xbf, 0x7e, 0x44, 0x9f, 0x01,
+	0x14, 0xc8, 0xf2, 0x52, 0xe4, 0x76, 0xfd, 0x7e, 0x89, 0x84, 0x78, 0xf1, 0x0a, 0xda, 0xc7, 0xbf,
+	0x1e, 0x04, 0xa0, 0x11, 0x8e, 0x6e, 0xa7, 0xef, 0x27, 0xc1, 0x09, 0xe9, 0x80, 0x1f, 0xbf, 0x8b,
+	0xdf, 0x4e, 0x66, 0xe3, 0xc9, 0x38, 0x70, 0x74, 0xe8, 0x3a, 0x9c, 0xcd, 0x26, 0xe3, 0xa0, 0x76,
+	0xf1, 0x05, 0xf8, 0x7b, 0x79, 0xea, 0x40, 0x14, 0xce, 0x7e, 0x9c, 0x8c, 0x83, 0x13, 0xd2, 0x06,
+	0xef, 0x6d, 0xa4, 0x2b, 0x8c, 0x26, 0x81, 0x73, 0xf1, 0x1d, 0xb4, 0x8e, 0xee, 0x41, 0x17, 0x9c,
+	0xcd, 0x17, 0xd1, 0x24, 0x7e, 0xf7, 0xe6, 0x36, 0x38, 0x21, 0x4d, 0x70, 0xef, 0xa6, 0xb3, 0xc0,
+	0x21, 0x1e, 0xd4, 0xdf, 0xcc, 0xe3, 0x38, 0xa8, 0x69, 0x6b, 0x1c, 0x85, 0x77, 0x81, 0x7b, 0xfd,
+	0xea, 0xa7, 0x17, 0xeb, 0x14, 0xef, 0xb7, 0xcb, 0xe1, 0x4a, 0x6e, 0x2e, 0x15, 0x4f, 0xd2, 0x44,
+	0x2a, 0xb6, 0x96, 0x97, 0x98, 0xb3, 0x54, 0xa4, 0x62, 0x5d, 0x3c, 0xac, 0xbe, 0xb6, 0x07, 0x5e,
+	0x5c, 0x9a, 0xbf, 0x93, 0xe2, 0x52, 0x2d, 0x97, 0x0d, 0x63, 0xbe, 0xf8, 0x3b, 0x00, 0x00, 0xff,
+	0xff, 0x7e, 0xfc, 0x56, 0xba, 0x6a, 0x06, 0x00, 0x00,
 }
@@ -0,0 +1,12 @@
+package utils
+
+import "net/mail"
+
+// IsEmailValid checks if v is a bare email address (no display name)
+func IsEmailValid(v string) bool {
+	addr, err := mail.ParseAddress(v)
+	if err != nil {
+		return false
+	}
+	return addr.Address == v
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "testing"
+
+func TestIsEmailValid(t *testing.T) {
+	if !IsEmailValid("alice@example.com") {
+		t.Fail()
+	}
+	if IsEmailValid("alice") || IsEmailValid("alice@") || IsEmailValid("") {
+		t.Fail()
+	}
+	if IsEmailValid("Alice <alice@example.com>") {
+		t.Fail()
+	}
+}
@@ -2,16 +2,22 @@ package utils
 
 import (
 	srand "crypto/rand"
+	"sync"
 	"time"
 
 	ulid "github.com/oklog/ulid/v2"
 )
 
-var secureSource *ulid.MonotonicEntropy
+var (
+	secureSource   *ulid.MonotonicEntropy
+	secureSourceMu sync.Mutex // MonotonicEntropy isn't safe for concurrent use
+)
 
 // SecureID returns a Universally Unique Lexicographically Sortable Identifier
-// obtained via crypto/rand entropy
+// obtained via crypto/rand entropy. It is safe for concurrent use.
 func SecureID() ulid.ULID {
+	secureSourceMu.Lock()
+	defer secureSourceMu.Unlock()
 	return ulid.MustNew(ulid.Timestamp(time.Now()), secureSource)
 }
 
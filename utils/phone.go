@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// maxNationalPhoneDigits is the longest number (without + or 00 prefix) assumed to lack a country code
+const maxNationalPhoneDigits = 11
+
+// NormalizePhone converts v to the E.164 format (+ followed by up to 15 digits).
+// Spaces, dashes, dots and parentheses are ignored. Numbers starting with + or 00 carry their
+// country code; other numbers with up to 11 digits (after dropping trunk zeros) are prefixed
+// with defaultCountryCode, while longer ones are assumed to already start with a country code.
+func NormalizePhone(v, defaultCountryCode string) (string, error) {
+	v = strings.TrimSpace(v)
+	international := false
+	if strings.HasPrefix(v, "+") {
+		international, v = true, v[1:]
+	}
+	digits := make([]byte, 0, len(v))
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+		default:
+			return "", errors.New("invalid character in phone number")
+		}
+	}
+	number := string(digits)
+	if !international && strings.HasPrefix(number, "00") {
+		international, number = true, number[2:]
+	}
+	if !international {
+		number = strings.TrimLeft(number, "0")
+		if len(number) <= maxNationalPhoneDigits {
+			number = defaultCountryCode + number
+		}
+	}
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", errors.New("phone number is not plausible")
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"+55 11 91234-5678", "+5511912345678"},
+		{"5511 91234-5678", "+5511912345678"},
+		{"(11) 91234-5678", "+5511912345678"},
+		{"011 91234-5678", "+5511912345678"},
+		{"0055 11 91234.5678", "+5511912345678"},
+		{"+1 (415) 555-0100", "+14155550100"},
+		{"11 3456 7890", "+551134567890"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.in, "55")
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "+", "12", "+0 11 91234-5678", "+55 11 9abc-5678", "+1234567890123456", "0000"} {
+		if got, err := NormalizePhone(in, "55"); err == nil {
+			t.Errorf("NormalizePhone(%q) = %q; want error", in, got)
+		}
+	}
+}
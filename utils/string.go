@@ -1,5 +1,7 @@
 package utils
 
+import "strings"
+
 func NonEmptyString(v ...string) string {
 	for _, item := range v {
 		if item != "" {
@@ -8,3 +10,23 @@ func NonEmptyString(v ...string) string {
 	}
 	return ""
 }
+
+// ChunkStrings splits v into consecutive slices of at most size items
+func ChunkStrings(v []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(v)+size-1)/size)
+	for size < len(v) {
+		v, chunks = v[size:], append(chunks, v[:size:size])
+	}
+	if len(v) > 0 {
+		chunks = append(chunks, v)
+	}
+	return chunks
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards (and the escape character itself) of v, so it only matches
+// literally in a LIKE pattern using the default \ escape
+func EscapeLike(v string) string {
+	return likeEscaper.Replace(v)
+}
@@ -12,3 +12,26 @@ func TestNonEmptyString(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestChunkStrings(t *testing.T) {
+	x := ChunkStrings([]string{"a", "b", "c", "d", "e"}, 2)
+	if len(x) != 3 || len(x[0]) != 2 || len(x[2]) != 1 || x[2][0] != "e" {
+		t.Fail()
+	}
+	x = ChunkStrings([]string{"a", "b"}, 2)
+	if len(x) != 1 || len(x[0]) != 2 {
+		t.Fail()
+	}
+	if len(ChunkStrings(nil, 2)) != 0 {
+		t.Fail()
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if x := EscapeLike(`50%_off\now`); x != `50\%\_off\\now` {
+		t.Fatal(x)
+	}
+	if EscapeLike("Alice") != "Alice" {
+		t.Fail()
+	}
+}